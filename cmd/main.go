@@ -1,25 +1,62 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/shooooooma415/guess-title-game-api/config"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/backplane"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/chat"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/idempotency"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/lease"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room_emoji"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/theme"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/transaction"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
+	infrastructureBackplane "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/backplane"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
 	infrastructureEvent "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/event"
+	infrastructureModeration "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/moderation"
 	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/ratelimit"
 	"github.com/shooooooma415/guess-title-game-api/internal/interface/handler"
+	"github.com/shooooooma415/guess-title-game-api/internal/interface/middleware"
 	"github.com/shooooooma415/guess-title-game-api/internal/interface/websocket"
+	adminUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/admin"
+	chatUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/chat"
 	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
 	userUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/user"
 )
 
 func main() {
+	// Exit with a failure once the deferred cleanup has run if the server could not be started
+	exitCode := 0
+	defer func() {
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	}()
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	// Initialize database
+	// Initialize clock
+	clk := infrastructureClock.NewSystemClock()
+
+	// Initialize repositories
 	dbCfg := persistence.Config{
 		Host:     cfg.Database.Host,
 		Port:     cfg.Database.Port,
@@ -28,37 +65,123 @@ func main() {
 		DBName:   cfg.Database.DBName,
 		SSLMode:  cfg.Database.SSLMode,
 	}
-	db, err := persistence.NewDB(dbCfg)
-	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+	var (
+		db              *sql.DB
+		userRepo        user.Repository
+		roomRepo        room.Repository
+		themeRepo       theme.Repository
+		participantRepo participant.Repository
+		idempotencyRepo idempotency.Repository
+		auditRepo       audit.Repository
+		chatRepo        chat.Repository
+		roomEmojiRepo   room_emoji.Repository
+		leaseRepo       lease.Repository
+		txManager       transaction.Manager
+	)
+	switch cfg.Storage.Driver {
+	case "memory":
+		store := persistence.NewMemoryStore()
+		userRepo = persistence.NewInMemoryUserRepository(store)
+		roomRepo = persistence.NewInMemoryRoomRepository(store)
+		themeRepo = persistence.NewInMemoryThemeRepository(store)
+		participantRepo = persistence.NewInMemoryParticipantRepository(store)
+		idempotencyRepo = persistence.NewInMemoryIdempotencyRepository(store)
+		auditRepo = persistence.NewInMemoryAuditRepository(store)
+		chatRepo = persistence.NewInMemoryChatRepository(store)
+		roomEmojiRepo = persistence.NewInMemoryRoomEmojiRepository(store)
+		leaseRepo = persistence.NewInMemoryLeaseRepository(store, clk)
+		txManager = persistence.NewInMemoryTxManager(store)
+
+		log.Println("Using in-memory storage; data is lost when the server stops")
+	case "postgres":
+		db, err = persistence.NewDB(dbCfg)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer db.Close()
+
+		log.Println("Database connection established")
+
+		userRepo = persistence.NewUserRepository(db)
+		roomRepo = persistence.NewRoomRepository(db)
+		themeRepo = persistence.NewThemeRepository(db)
+		participantRepo = persistence.NewParticipantRepository(db)
+		idempotencyRepo = persistence.NewIdempotencyRepository(db)
+		auditRepo = persistence.NewAuditRepository(db)
+		chatRepo = persistence.NewChatRepository(db)
+		roomEmojiRepo = persistence.NewRoomEmojiRepository(db)
+		leaseRepo = persistence.NewLeaseRepository(db)
+		txManager = persistence.NewTxManager(db)
+	default:
+		log.Fatalf("Unknown storage: %s", cfg.Storage.Driver)
 	}
-	defer db.Close()
 
-	log.Println("Database connection established")
+	// Initialize backplane and event publisher
+	var bp backplane.Backplane
+	switch cfg.Backplane.Driver {
+	case "memory":
+		bp = infrastructureBackplane.NewInMemoryBackplane()
+	case "postgres":
+		if db == nil {
+			log.Fatalf("The postgres backplane requires STORAGE=postgres")
+		}
+		pgBackplane, err := infrastructureBackplane.NewPostgresBackplane(db, dbCfg.DSN(), cfg.Backplane.Channel)
+		if err != nil {
+			log.Fatalf("Failed to start backplane: %v", err)
+		}
+		defer pgBackplane.Close()
+		bp = pgBackplane
+	default:
+		log.Fatalf("Unknown backplane: %s", cfg.Backplane.Driver)
+	}
+	eventPublisher := infrastructureEvent.NewBackplaneEventPublisher(bp)
 
-	// Initialize event publisher
-	eventPublisher := infrastructureEvent.NewInMemoryEventPublisher()
+	// Initialize content moderator
+	moderator := infrastructureModeration.NewNGWordModerator(infrastructureModeration.Config{
+		NGWords: cfg.Moderation.NGWords,
+		Mask:    cfg.Moderation.Mask,
+		MaxLengths: map[moderation.Field]int{
+			moderation.FieldUserName:    cfg.Moderation.MaxUserNameLength,
+			moderation.FieldTopic:       cfg.Moderation.MaxTopicLength,
+			moderation.FieldAnswer:      cfg.Moderation.MaxAnswerLength,
+			moderation.FieldChatMessage: cfg.Moderation.MaxChatLength,
+		},
+	})
 
-	// Initialize repositories
-	userRepo := persistence.NewUserRepository(db)
-	roomRepo := persistence.NewRoomRepository(db)
-	themeRepo := persistence.NewThemeRepository(db)
-	participantRepo := persistence.NewParticipantRepository(db)
+	// Initialize rate limiters
+	chatLimiter := ratelimit.NewKeyedLimiter(cfg.Chat.RatePerSecond, cfg.Chat.Burst, clk)
+	reactionLimiter := ratelimit.NewKeyedLimiter(cfg.Reaction.RatePerSecond, cfg.Reaction.Burst, clk)
 
 	// Initialize use cases
-	joinRoomUseCase := userUseCase.NewJoinRoomUseCase(userRepo, roomRepo, participantRepo)
-	createRoomUseCase := roomUseCase.NewCreateRoomUseCase(userRepo, roomRepo, themeRepo, participantRepo)
-	startGameUseCase := roomUseCase.NewStartGameUseCase(roomRepo, participantRepo, eventPublisher)
-	setTopicUseCase := roomUseCase.NewSetTopicUseCase(roomRepo, participantRepo)
-	submitAnswerUseCase := roomUseCase.NewSubmitAnswerUseCase(roomRepo, participantRepo, eventPublisher)
-	skipDiscussionUseCase := roomUseCase.NewSkipDiscussionUseCase(roomRepo, participantRepo, eventPublisher)
-	finishGameUseCase := roomUseCase.NewFinishGameUseCase(roomRepo, participantRepo, eventPublisher)
+	joinRoomUseCase := userUseCase.NewJoinRoomUseCase(userRepo, roomRepo, participantRepo, moderator, txManager, clk)
+	createRoomUseCase := roomUseCase.NewCreateRoomUseCase(userRepo, roomRepo, themeRepo, participantRepo, auditRepo, txManager, clk)
+	startGameUseCase := roomUseCase.NewStartGameUseCase(roomRepo, participantRepo, eventPublisher, auditRepo, clk)
+	setTopicUseCase := roomUseCase.NewSetTopicUseCase(roomRepo, participantRepo, moderator, auditRepo, clk)
+	submitAnswerUseCase := roomUseCase.NewSubmitAnswerUseCase(roomRepo, participantRepo, eventPublisher, moderator, auditRepo, clk)
+	skipDiscussionUseCase := roomUseCase.NewSkipDiscussionUseCase(roomRepo, participantRepo, eventPublisher, auditRepo, clk)
+	finishGameUseCase := roomUseCase.NewFinishGameUseCase(roomRepo, participantRepo, eventPublisher, auditRepo, clk)
+	fetchReactionsUseCase := roomUseCase.NewFetchReactionCountsUseCase(roomRepo, roomEmojiRepo)
+	expirePhasesUseCase := roomUseCase.NewExpirePhasesUseCase(roomRepo, participantRepo, themeRepo, eventPublisher, auditRepo, clk)
+
+	// Initialize admin use cases
+	listRoomsUseCase := adminUseCase.NewListRoomsUseCase(roomRepo, clk)
+	fetchRoomDetailUseCase := adminUseCase.NewFetchRoomDetailUseCase(roomRepo, participantRepo, userRepo, themeRepo)
+	forceTransitionUseCase := adminUseCase.NewForceTransitionUseCase(roomRepo, eventPublisher, auditRepo, clk)
+	deleteRoomUseCase := adminUseCase.NewDeleteRoomUseCase(roomRepo, eventPublisher, auditRepo, clk)
+	fetchAuditLogsUseCase := adminUseCase.NewFetchAuditLogsUseCase(roomRepo, auditRepo)
 
 	// Initialize WebSocket-specific use cases
 	fetchRoomUseCase := roomUseCase.NewFetchRoomUseCase(roomRepo)
+	fetchDiscussingUseCase := roomUseCase.NewFetchDiscussingRoomsUseCase(roomRepo)
 	fetchParticipantsUseCase := roomUseCase.NewFetchRoomParticipantsUseCase(participantRepo, userRepo)
-	startDiscussionUseCase := roomUseCase.NewStartDiscussionUseCase(roomRepo, participantRepo)
-	submitFinalAnswerUseCase := roomUseCase.NewSubmitFinalAnswerUseCase(roomRepo)
+	startDiscussionUseCase := roomUseCase.NewStartDiscussionUseCase(roomRepo, participantRepo, auditRepo, clk)
+	submitFinalAnswerUseCase := roomUseCase.NewSubmitFinalAnswerUseCase(roomRepo, moderator, auditRepo, clk)
+	sendReactionUseCase := roomUseCase.NewSendReactionUseCase(roomRepo, participantRepo, roomEmojiRepo, reactionLimiter)
+
+	// Initialize chat use cases
+	sendChatUseCase := chatUseCase.NewSendMessageUseCase(chatRepo, participantRepo, userRepo, moderator, chatLimiter, clk)
+	fetchChatHistoryUseCase := chatUseCase.NewFetchHistoryUseCase(chatRepo, userRepo, cfg.Chat.HistoryLimit)
+	deleteChatUseCase := chatUseCase.NewDeleteMessageUseCase(chatRepo, participantRepo, clk)
 
 	// Initialize handlers
 	userHandler := handler.NewUserHandler(joinRoomUseCase)
@@ -69,33 +192,120 @@ func main() {
 		submitAnswerUseCase,
 		skipDiscussionUseCase,
 		finishGameUseCase,
+		fetchReactionsUseCase,
+	)
+	adminHandler := handler.NewAdminHandler(
+		listRoomsUseCase,
+		fetchRoomDetailUseCase,
+		forceTransitionUseCase,
+		deleteRoomUseCase,
+		fetchAuditLogsUseCase,
 	)
 
 	// Initialize WebSocket hub and timer
-	hub := websocket.NewHub()
-	timer := websocket.NewTimer(hub)
+	wsCfg := websocket.Config{
+		CheckOrigin:         middleware.CheckOrigin(cfg),
+		ReplayBufferSize:    cfg.WebSocket.ReplayBufferSize,
+		ReplayRetention:     cfg.WebSocket.ReplayRetention,
+		PingInterval:        cfg.WebSocket.PingInterval,
+		PongWait:            cfg.WebSocket.PongWait,
+		WriteWait:           cfg.WebSocket.WriteWait,
+		PresenceGracePeriod: cfg.WebSocket.PresenceGracePeriod,
+		MaxMessageSize:      cfg.WebSocket.MaxMessageSize,
+		EnableCompression:   cfg.WebSocket.EnableCompression,
+		HubShards:           cfg.WebSocket.HubShards,
+		HubQueueSize:        cfg.WebSocket.HubQueueSize,
+		TimerLeaseTTL:       cfg.WebSocket.TimerLeaseTTL,
+	}
+	hub := websocket.NewHub(wsCfg, bp, clk)
+	timer := websocket.NewTimer(hub, bp, leaseRepo, clk, wsCfg.TimerLeaseTTL)
 	wsHandler := websocket.NewHandler(
 		hub,
 		timer,
 		fetchRoomUseCase,
+		fetchDiscussingUseCase,
 		fetchParticipantsUseCase,
 		startDiscussionUseCase,
 		submitFinalAnswerUseCase,
 		themeRepo,
+		participantRepo,
+		sendChatUseCase,
+		fetchChatHistoryUseCase,
+		deleteChatUseCase,
+		sendReactionUseCase,
+		wsCfg,
 	)
 
-	// Start WebSocket hub
+	// Start WebSocket hub and the countdown scheduler
 	go hub.Run()
+	go timer.Run()
+
+	// Apply the fallbacks of phases whose deadline has passed
+	phaseWatcher := websocket.NewPhaseWatcher(expirePhasesUseCase, leaseRepo, clk, wsCfg.TimerLeaseTTL)
+	go phaseWatcher.Run()
 
 	// Setup event handlers for WebSocket
 	wsHandler.SetupEventHandlers(eventPublisher)
 
 	// Initialize router
-	e := handler.NewRouter(cfg, userHandler, roomHandler, wsHandler)
+	e := handler.NewRouter(cfg, userHandler, roomHandler, adminHandler, wsHandler, idempotencyRepo, clk)
 
-	// Start server
-	log.Printf("Starting server on port %s", cfg.Server.Port)
-	if err := e.Start(":" + cfg.Server.Port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	if cfg.Admin.APIKey == "" {
+		log.Println("ADMIN_API_KEY is not set; admin API is disabled")
 	}
+
+	// Shut down gracefully on SIGTERM (sent by Cloud Run before stopping an instance) or SIGINT
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Resume the countdowns of rooms that were in discussion before the restart
+	if restored, err := wsHandler.RestoreTimers(ctx); err != nil {
+		log.Printf("Failed to restore timers: %v", err)
+	} else if restored > 0 {
+		log.Printf("Restored %d timers", restored)
+	}
+
+	// Start server; a failure goes through the same shutdown as a signal
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("Starting server on port %s", cfg.Server.Port)
+		if err := e.Start(":" + cfg.Server.Port); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err := <-serverErr:
+		log.Printf("Failed to start server: %v", err)
+		exitCode = 1
+	}
+	stop()
+	log.Println("Shutting down server")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	// Disconnect WebSocket and SSE clients first; SSE streams would otherwise keep the HTTP server busy
+	if err := wsHandler.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to close WebSocket connections: %v", err)
+	}
+	// Stop accepting connections and wait for in-flight requests
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down HTTP server: %v", err)
+	}
+	// Stop applying phase fallbacks so that no event is published while draining
+	if err := phaseWatcher.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to stop phase watcher: %v", err)
+	}
+	// Let event handlers finish before the timers and the database go away
+	if err := eventPublisher.Drain(shutdownCtx); err != nil {
+		log.Printf("Failed to drain event handlers: %v", err)
+	}
+	if err := timer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to stop timers: %v", err)
+	}
+
+	// The backplane and the database are closed by the deferred calls
+	log.Println("Server stopped")
 }
@@ -1,21 +1,41 @@
 package config
 
 import (
+	"bufio"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Config represents application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	CORS     CORSConfig
+	Server      ServerConfig
+	Storage     StorageConfig
+	Database    DatabaseConfig
+	Origin      OriginConfig
+	Moderation  ModerationConfig
+	Idempotency IdempotencyConfig
+	Admin       AdminConfig
+	WebSocket   WebSocketConfig
+	Chat        ChatConfig
+	Reaction    ReactionConfig
+	Backplane   BackplaneConfig
 }
 
 // ServerConfig represents server configuration
 type ServerConfig struct {
 	Port string
+	// ShutdownTimeout bounds the graceful shutdown started by SIGTERM or SIGINT
+	ShutdownTimeout time.Duration
+}
+
+// StorageConfig represents where the repositories keep their data
+type StorageConfig struct {
+	// Driver is "postgres" for the database or "memory" to run without external services.
+	// Data in memory is lost when the process stops.
+	Driver string
 }
 
 // DatabaseConfig represents database configuration
@@ -28,9 +48,88 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
-// CORSConfig represents CORS configuration
-type CORSConfig struct {
+// OriginConfig represents the origin policy shared by CORS and WebSocket
+type OriginConfig struct {
+	// AllowOrigins lists allowed origins. Entries may be "*" or contain a
+	// wildcard subdomain such as "https://*.example.com".
 	AllowOrigins []string
+	// Strict rejects requests that carry no Origin header
+	Strict bool
+}
+
+// ModerationConfig represents content moderation configuration
+type ModerationConfig struct {
+	NGWords           []string
+	Mask              bool
+	MaxUserNameLength int
+	MaxTopicLength    int
+	MaxAnswerLength   int
+	MaxChatLength     int
+}
+
+// IdempotencyConfig represents Idempotency-Key handling configuration
+type IdempotencyConfig struct {
+	// TTL is how long the first response is kept for replay
+	TTL time.Duration
+}
+
+// AdminConfig represents admin API configuration
+type AdminConfig struct {
+	// APIKey is the shared secret required in the X-Admin-Key header.
+	// The admin API is disabled when empty.
+	APIKey string
+}
+
+// WebSocketConfig represents WebSocket configuration
+type WebSocketConfig struct {
+	// ReplayBufferSize is the number of broadcast messages kept per room for reconnecting clients
+	ReplayBufferSize int
+	// ReplayRetention is how long a room's replay buffer is kept after its last client leaves
+	ReplayRetention time.Duration
+	// PingInterval is how often the server pings each connection
+	PingInterval time.Duration
+	// PongWait is how long a connection may stay silent before it is closed
+	PongWait time.Duration
+	// WriteWait is the time allowed to write a single message
+	WriteWait time.Duration
+	// PresenceGracePeriod is how long a user stays online after their last connection closes
+	PresenceGracePeriod time.Duration
+	// MaxMessageSize is the largest frame in bytes accepted from a client
+	MaxMessageSize int64
+	// EnableCompression negotiates permessage-deflate with clients that support it
+	EnableCompression bool
+	// HubShards is the number of goroutines the hub partitions rooms across
+	HubShards int
+	// HubQueueSize is the number of deliveries each hub shard queues before dropping messages
+	HubQueueSize int
+	// TimerLeaseTTL is how long an instance owns a room countdown without renewing its lease
+	TimerLeaseTTL time.Duration
+}
+
+// ChatConfig represents in-room chat configuration
+type ChatConfig struct {
+	// RatePerSecond is the sustained number of messages a participant may send per second
+	RatePerSecond float64
+	// Burst is the number of messages a participant may send at once
+	Burst int
+	// HistoryLimit is the number of recent messages sent to joining clients
+	HistoryLimit int
+}
+
+// ReactionConfig represents emoji reaction configuration
+type ReactionConfig struct {
+	// RatePerSecond is the sustained number of reactions a participant may send per second
+	RatePerSecond float64
+	// Burst is the number of reactions a participant may send at once
+	Burst int
+}
+
+// BackplaneConfig represents the backplane relaying broadcasts between API instances
+type BackplaneConfig struct {
+	// Driver is "memory" for a single instance or "postgres" for LISTEN/NOTIFY across instances
+	Driver string
+	// Channel is the Postgres NOTIFY channel shared by the instances
+	Channel string
 }
 
 // Load loads configuration from environment variables
@@ -40,9 +139,22 @@ func Load() (*Config, error) {
 		dbPort = 5432
 	}
 
+	ngWords := parseList(getEnv("MODERATION_NG_WORDS", ""))
+	if path := getEnv("MODERATION_NG_WORDS_FILE", ""); path != "" {
+		fileWords, err := readLines(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read NG words file: %w", err)
+		}
+		ngWords = append(ngWords, fileWords...)
+	}
+
 	return &Config{
 		Server: ServerConfig{
-			Port: getEnv("PORT", getEnv("SERVER_PORT", "8080")),
+			Port:            getEnv("PORT", getEnv("SERVER_PORT", "8080")),
+			ShutdownTimeout: getEnvDuration("SHUTDOWN_TIMEOUT", 10*time.Second),
+		},
+		Storage: StorageConfig{
+			Driver: getEnv("STORAGE", "postgres"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -52,12 +164,96 @@ func Load() (*Config, error) {
 			DBName:   getEnv("DB_NAME", "guess_title_game"),
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
 		},
-		CORS: CORSConfig{
-			AllowOrigins: parseCORSOrigins(getEnv("CORS_ALLOW_ORIGINS", "http://localhost:3000,http://localhost:3001")),
+		Origin: OriginConfig{
+			AllowOrigins: parseList(getEnv("ALLOW_ORIGINS", getEnv("CORS_ALLOW_ORIGINS", "http://localhost:3000,http://localhost:3001"))),
+			Strict:       getEnvBool("ORIGIN_STRICT", false),
+		},
+		Moderation: ModerationConfig{
+			NGWords:           ngWords,
+			Mask:              getEnv("MODERATION_MODE", "reject") == "mask",
+			MaxUserNameLength: getEnvInt("MODERATION_MAX_USER_NAME_LENGTH", 20),
+			MaxTopicLength:    getEnvInt("MODERATION_MAX_TOPIC_LENGTH", 100),
+			MaxAnswerLength:   getEnvInt("MODERATION_MAX_ANSWER_LENGTH", 100),
+			MaxChatLength:     getEnvInt("MODERATION_MAX_CHAT_LENGTH", 200),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL: getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		},
+		Admin: AdminConfig{
+			APIKey: getEnv("ADMIN_API_KEY", ""),
+		},
+		Chat: ChatConfig{
+			RatePerSecond: getEnvFloat("CHAT_RATE_PER_SECOND", 0.5),
+			Burst:         getEnvInt("CHAT_RATE_BURST", 5),
+			HistoryLimit:  getEnvInt("CHAT_HISTORY_LIMIT", 50),
+		},
+		Reaction: ReactionConfig{
+			RatePerSecond: getEnvFloat("REACTION_RATE_PER_SECOND", 2),
+			Burst:         getEnvInt("REACTION_RATE_BURST", 10),
+		},
+		Backplane: BackplaneConfig{
+			Driver:  getEnv("BACKPLANE", "memory"),
+			Channel: getEnv("BACKPLANE_CHANNEL", "guess_title_backplane"),
+		},
+		WebSocket: WebSocketConfig{
+			ReplayBufferSize:    getEnvInt("WS_REPLAY_BUFFER_SIZE", 256),
+			ReplayRetention:     getEnvDuration("WS_REPLAY_RETENTION", 10*time.Minute),
+			PingInterval:        getEnvDuration("WS_PING_INTERVAL", 50*time.Second),
+			PongWait:            getEnvDuration("WS_PONG_WAIT", 60*time.Second),
+			WriteWait:           getEnvDuration("WS_WRITE_WAIT", 10*time.Second),
+			PresenceGracePeriod: getEnvDuration("WS_PRESENCE_GRACE_PERIOD", 15*time.Second),
+			MaxMessageSize:      int64(getEnvInt("WS_MAX_MESSAGE_SIZE", 8192)),
+			EnableCompression:   getEnvBool("WS_COMPRESSION", true),
+			HubShards:           getEnvInt("WS_HUB_SHARDS", 16),
+			HubQueueSize:        getEnvInt("WS_HUB_QUEUE_SIZE", 4096),
+			TimerLeaseTTL:       getEnvDuration("WS_TIMER_LEASE_TTL", 15*time.Second),
 		},
 	}, nil
 }
 
+// IsAllowed reports whether the given Origin header value is allowed
+func (c OriginConfig) IsAllowed(origin string) bool {
+	if origin == "" {
+		return !c.Strict
+	}
+
+	for _, allowed := range c.AllowOrigins {
+		if matchOrigin(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchOrigin matches an origin against an allowed pattern.
+// A pattern like "https://*.example.com" matches any subdomain of
+// example.com (but not example.com itself) with the same scheme and port.
+func matchOrigin(pattern, origin string) bool {
+	if pattern == "*" {
+		return true
+	}
+	if strings.EqualFold(pattern, origin) {
+		return true
+	}
+
+	scheme, host, ok := strings.Cut(pattern, "://")
+	if !ok || !strings.HasPrefix(host, "*.") {
+		return false
+	}
+	prefix := scheme + "://"
+	suffix := host[1:] // ".example.com"
+
+	if len(origin) <= len(prefix)+len(suffix) {
+		return false
+	}
+	if !strings.EqualFold(origin[:len(prefix)], prefix) || !strings.EqualFold(origin[len(origin)-len(suffix):], suffix) {
+		return false
+	}
+
+	subdomain := origin[len(prefix) : len(origin)-len(suffix)]
+	return !strings.ContainsAny(subdomain, "/:@")
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -65,17 +261,68 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
-func parseCORSOrigins(origins string) []string {
-	if origins == "" {
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(getEnv(key, strconv.FormatBool(defaultValue)))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnv(key, strconv.Itoa(defaultValue)))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, strconv.FormatFloat(defaultValue, 'f', -1, 64)), 64)
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, defaultValue.String()))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+func parseList(values string) []string {
+	if values == "" {
 		return []string{}
 	}
-	parts := strings.Split(origins, ",")
+	parts := strings.Split(values, ",")
 	result := make([]string, 0, len(parts))
-	for _, origin := range parts {
-		trimmed := strings.TrimSpace(origin)
+	for _, value := range parts {
+		trimmed := strings.TrimSpace(value)
 		if trimmed != "" {
 			result = append(result, trimmed)
 		}
 	}
 	return result
 }
+
+// readLines reads non-empty lines from a file, ignoring lines starting with '#'
+func readLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
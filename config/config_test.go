@@ -0,0 +1,95 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/shooooooma415/guess-title-game-api/config"
+)
+
+func TestOriginConfigIsAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    config.OriginConfig
+		origin string
+		want   bool
+	}{
+		{
+			name:   "完全一致のOriginは許可されること",
+			cfg:    config.OriginConfig{AllowOrigins: []string{"http://localhost:3000"}},
+			origin: "http://localhost:3000",
+			want:   true,
+		},
+		{
+			name:   "ポートが異なるOriginは拒否されること",
+			cfg:    config.OriginConfig{AllowOrigins: []string{"http://localhost:3000"}},
+			origin: "http://localhost:3002",
+			want:   false,
+		},
+		{
+			name:   "ワイルドカードでサブドメインが許可されること",
+			cfg:    config.OriginConfig{AllowOrigins: []string{"https://*.example.com"}},
+			origin: "https://app.example.com",
+			want:   true,
+		},
+		{
+			name:   "ワイルドカードで多段サブドメインが許可されること",
+			cfg:    config.OriginConfig{AllowOrigins: []string{"https://*.example.com"}},
+			origin: "https://a.b.example.com",
+			want:   true,
+		},
+		{
+			name:   "ワイルドカードはapexドメインを許可しないこと",
+			cfg:    config.OriginConfig{AllowOrigins: []string{"https://*.example.com"}},
+			origin: "https://example.com",
+			want:   false,
+		},
+		{
+			name:   "ワイルドカードはスキームが異なるOriginを許可しないこと",
+			cfg:    config.OriginConfig{AllowOrigins: []string{"https://*.example.com"}},
+			origin: "http://app.example.com",
+			want:   false,
+		},
+		{
+			name:   "ワイルドカードは似た別ドメインを許可しないこと",
+			cfg:    config.OriginConfig{AllowOrigins: []string{"https://*.example.com"}},
+			origin: "https://evil-example.com",
+			want:   false,
+		},
+		{
+			name:   "ワイルドカードはポート付きOriginを許可しないこと",
+			cfg:    config.OriginConfig{AllowOrigins: []string{"https://*.example.com"}},
+			origin: "https://evil.com:443.example.com",
+			want:   false,
+		},
+		{
+			name:   "Originなしは非strictモードで許可されること",
+			cfg:    config.OriginConfig{AllowOrigins: []string{"http://localhost:3000"}},
+			origin: "",
+			want:   true,
+		},
+		{
+			name:   "Originなしはstrictモードで拒否されること",
+			cfg:    config.OriginConfig{AllowOrigins: []string{"http://localhost:3000"}, Strict: true},
+			origin: "",
+			want:   false,
+		},
+		{
+			name:   "*はすべてのOriginを許可すること",
+			cfg:    config.OriginConfig{AllowOrigins: []string{"*"}},
+			origin: "https://anything.test",
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			got := tt.cfg.IsAllowed(tt.origin)
+
+			// assert
+			if got != tt.want {
+				t.Errorf("IsAllowed(%q) = %v, want %v", tt.origin, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+-- Create Audit_Log table
+-- room_id has no foreign key so that the trail survives room deletion
+CREATE TABLE audit_logs (
+    id UUID PRIMARY KEY,
+    room_id UUID NOT NULL,
+    actor_type VARCHAR(20) NOT NULL,
+    actor_id VARCHAR(255) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    before_status VARCHAR(20),
+    after_status VARCHAR(20),
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_logs_room_id_occurred_at ON audit_logs(room_id, occurred_at);
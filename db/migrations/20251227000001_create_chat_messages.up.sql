@@ -0,0 +1,12 @@
+-- Create Chat_Message table
+CREATE TABLE chat_messages (
+    id UUID PRIMARY KEY,
+    room_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT fk_chat_message_participant FOREIGN KEY (room_id, user_id) REFERENCES participants(room_id, user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_chat_messages_room_id_created_at ON chat_messages(room_id, created_at);
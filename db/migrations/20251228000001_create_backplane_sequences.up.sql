@@ -0,0 +1,5 @@
+-- Create Backplane_Sequence table (message sequence numbers shared by all API instances)
+CREATE TABLE backplane_sequences (
+    topic TEXT PRIMARY KEY,
+    seq BIGINT NOT NULL
+);
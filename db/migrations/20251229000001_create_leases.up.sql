@@ -0,0 +1,6 @@
+-- Create Lease table (exclusive ownership of per-room background work between API instances)
+CREATE TABLE leases (
+    name TEXT PRIMARY KEY,
+    owner TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,4 @@
+ALTER TABLE rooms
+    DROP COLUMN IF EXISTS discussion_starts_at,
+    DROP COLUMN IF EXISTS discussion_ends_at,
+    DROP COLUMN IF EXISTS discussion_paused_remaining_ms;
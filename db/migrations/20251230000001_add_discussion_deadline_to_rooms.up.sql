@@ -0,0 +1,5 @@
+-- Add discussion deadline to rooms (countdown schedule saved on shutdown and restored on startup)
+ALTER TABLE rooms
+    ADD COLUMN discussion_starts_at TIMESTAMPTZ,
+    ADD COLUMN discussion_ends_at TIMESTAMPTZ,
+    ADD COLUMN discussion_paused_remaining_ms BIGINT;
@@ -0,0 +1,11 @@
+ALTER TABLE themes
+    DROP COLUMN IF EXISTS emojis;
+
+DROP INDEX IF EXISTS idx_rooms_phase_ends_at;
+
+ALTER TABLE rooms
+    DROP COLUMN IF EXISTS setting_topic_timeout_sec,
+    DROP COLUMN IF EXISTS answering_timeout_sec,
+    DROP COLUMN IF EXISTS checking_timeout_sec,
+    DROP COLUMN IF EXISTS phase_ends_at,
+    DROP COLUMN IF EXISTS answer_is_correct;
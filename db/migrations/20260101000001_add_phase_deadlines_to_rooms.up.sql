@@ -0,0 +1,24 @@
+-- Add optional deadlines of the setting_topic, answering and checking phases to rooms
+ALTER TABLE rooms
+    ADD COLUMN setting_topic_timeout_sec INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN answering_timeout_sec INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN checking_timeout_sec INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN phase_ends_at TIMESTAMPTZ,
+    ADD COLUMN answer_is_correct BOOLEAN;
+
+CREATE INDEX idx_rooms_phase_ends_at ON rooms(phase_ends_at) WHERE phase_ends_at IS NOT NULL;
+
+-- Emojis picked for the room when the host does not set a topic in time
+ALTER TABLE themes
+    ADD COLUMN emojis TEXT[] NOT NULL DEFAULT '{}';
+
+UPDATE themes SET emojis = ARRAY['🏮', '👘', '🎆', '🍧', '🐟', '🥁'] WHERE title = '夏祭り';
+UPDATE themes SET emojis = ARRAY['☕', '🫘', '🥛', '🌅', '🍰', '🔥'] WHERE title = 'コーヒー';
+UPDATE themes SET emojis = ARRAY['🗻', '🏔️', '🌅', '🥾', '❄️', '🇯🇵'] WHERE title = '富士山';
+UPDATE themes SET emojis = ARRAY['🍜', '🥢', '🍥', '🥚', '🐷', '🔥'] WHERE title = 'ラーメン';
+UPDATE themes SET emojis = ARRAY['⚽', '🥅', '🏟️', '👟', '🏆', '🟨'] WHERE title = 'サッカー';
+UPDATE themes SET emojis = ARRAY['🍣', '🐟', '🍚', '🥢', '🍵', '🦐'] WHERE title = 'お寿司';
+UPDATE themes SET emojis = ARRAY['🌸', '🌳', '🍡', '🧺', '🌙', '🐦'] WHERE title = '桜';
+UPDATE themes SET emojis = ARRAY['♨️', '🛁', '🏔️', '🐒', '🧖', '🍶'] WHERE title = '温泉';
+UPDATE themes SET emojis = ARRAY['🎆', '🎇', '🌙', '👘', '💥', '🌊'] WHERE title = '花火';
+UPDATE themes SET emojis = ARRAY['📺', '🎌', '🤖', '✨', '📚', '🎤'] WHERE title = 'アニメ';
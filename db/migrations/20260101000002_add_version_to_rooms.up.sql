@@ -0,0 +1,3 @@
+-- Version of each room, advanced on every save so that concurrent writers are detected
+ALTER TABLE rooms
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
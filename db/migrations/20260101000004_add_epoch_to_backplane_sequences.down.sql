@@ -0,0 +1,2 @@
+ALTER TABLE backplane_sequences
+    DROP COLUMN IF EXISTS epoch;
@@ -0,0 +1,3 @@
+-- Epoch of each sequence counter, renewed when the counter of a topic is created again
+ALTER TABLE backplane_sequences
+    ADD COLUMN epoch TEXT NOT NULL DEFAULT gen_random_uuid()::text;
@@ -0,0 +1,8 @@
+-- Create Backplane_Payload table (messages too large for a NOTIFY, fetched by ID by every API instance)
+CREATE TABLE backplane_payloads (
+    id BIGSERIAL PRIMARY KEY,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_backplane_payloads_created_at ON backplane_payloads(created_at);
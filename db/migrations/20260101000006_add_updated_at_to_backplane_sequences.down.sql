@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_backplane_sequences_updated_at;
+ALTER TABLE backplane_sequences DROP COLUMN IF EXISTS updated_at;
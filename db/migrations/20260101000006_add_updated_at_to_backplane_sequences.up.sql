@@ -0,0 +1,5 @@
+-- Last publish of each sequence counter, used to sweep the counters of idle topics
+ALTER TABLE backplane_sequences
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE INDEX idx_backplane_sequences_updated_at ON backplane_sequences(updated_at);
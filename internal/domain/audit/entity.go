@@ -0,0 +1,64 @@
+package audit
+
+import "time"
+
+// AuditLog represents a record of a privileged operation on a room
+type AuditLog struct {
+	id           AuditLogID
+	roomID       RoomID
+	actor        Actor
+	action       Action
+	beforeStatus string
+	afterStatus  string
+	occurredAt   time.Time
+}
+
+// NewAuditLog creates a new AuditLog
+func NewAuditLog(
+	id AuditLogID,
+	roomID RoomID,
+	actor Actor,
+	action Action,
+	beforeStatus string,
+	afterStatus string,
+	occurredAt time.Time,
+) *AuditLog {
+	return &AuditLog{
+		id:           id,
+		roomID:       roomID,
+		actor:        actor,
+		action:       action,
+		beforeStatus: beforeStatus,
+		afterStatus:  afterStatus,
+		occurredAt:   occurredAt,
+	}
+}
+
+// Getters
+func (l *AuditLog) ID() AuditLogID {
+	return l.id
+}
+
+func (l *AuditLog) RoomID() RoomID {
+	return l.roomID
+}
+
+func (l *AuditLog) Actor() Actor {
+	return l.actor
+}
+
+func (l *AuditLog) Action() Action {
+	return l.action
+}
+
+func (l *AuditLog) BeforeStatus() string {
+	return l.beforeStatus
+}
+
+func (l *AuditLog) AfterStatus() string {
+	return l.afterStatus
+}
+
+func (l *AuditLog) OccurredAt() time.Time {
+	return l.occurredAt
+}
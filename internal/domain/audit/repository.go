@@ -0,0 +1,12 @@
+package audit
+
+import "context"
+
+// Repository defines the interface for audit log persistence
+type Repository interface {
+	// Save persists an audit log
+	Save(ctx context.Context, log *AuditLog) error
+
+	// FindByRoomID retrieves all audit logs of a room in chronological order
+	FindByRoomID(ctx context.Context, roomID RoomID) ([]*AuditLog, error)
+}
@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"errors"
+
+	"github.com/shooooooma415/guess-title-game-api/utils"
+)
+
+// AuditLogID represents an audit log identifier
+type AuditLogID struct {
+	value string
+}
+
+func NewAuditLogID() AuditLogID {
+	return AuditLogID{value: utils.GenerateUUID()}
+}
+
+func NewAuditLogIDFromString(value string) (AuditLogID, error) {
+	if err := utils.ValidateUUID(value); err != nil {
+		return AuditLogID{}, err
+	}
+	return AuditLogID{value: value}, nil
+}
+
+func (id AuditLogID) String() string {
+	return id.value
+}
+
+// RoomID represents a room identifier (reference to room domain)
+type RoomID struct {
+	value string
+}
+
+func NewRoomIDFromString(value string) (RoomID, error) {
+	if err := utils.ValidateUUID(value); err != nil {
+		return RoomID{}, err
+	}
+	return RoomID{value: value}, nil
+}
+
+func (id RoomID) String() string {
+	return id.value
+}
+
+// ActorType represents who performed an action
+type ActorType int
+
+const (
+	ActorUser ActorType = iota
+	ActorAdmin
+	ActorSystem
+)
+
+func (t ActorType) String() string {
+	switch t {
+	case ActorUser:
+		return "user"
+	case ActorAdmin:
+		return "admin"
+	case ActorSystem:
+		return "system"
+	default:
+		return "unknown"
+	}
+}
+
+func NewActorTypeFromString(value string) (ActorType, error) {
+	switch value {
+	case "user":
+		return ActorUser, nil
+	case "admin":
+		return ActorAdmin, nil
+	case "system":
+		return ActorSystem, nil
+	default:
+		return 0, errors.New("invalid actor type")
+	}
+}
+
+// Actor represents the user, admin or system process that performed an action
+type Actor struct {
+	actorType ActorType
+	id        string
+}
+
+// NewUserActor creates an Actor for a room participant
+func NewUserActor(userID string) Actor {
+	return Actor{actorType: ActorUser, id: userID}
+}
+
+// NewAdminActor creates an Actor for an operator using the admin API
+func NewAdminActor() Actor {
+	return Actor{actorType: ActorAdmin, id: "admin"}
+}
+
+// NewSystemActor creates an Actor for an automatic server-side action
+func NewSystemActor(name string) Actor {
+	return Actor{actorType: ActorSystem, id: name}
+}
+
+// NewActor reconstructs an Actor (for repository reconstruction)
+func NewActor(actorType ActorType, id string) Actor {
+	return Actor{actorType: actorType, id: id}
+}
+
+func (a Actor) Type() ActorType {
+	return a.actorType
+}
+
+func (a Actor) ID() string {
+	return a.id
+}
+
+// Action represents a privileged room operation
+type Action string
+
+const (
+	ActionStartGame       Action = "start_game"
+	ActionSetTopic        Action = "set_topic"
+	ActionSkipDiscussion  Action = "skip_discussion"
+	ActionSubmitAnswer    Action = "submit_answer"
+	ActionFinishGame      Action = "finish_game"
+	ActionUpdateSettings  Action = "update_settings"
+	ActionForceTransition Action = "force_transition"
+	ActionForceFinish     Action = "force_finish"
+	ActionDeleteRoom      Action = "delete_room"
+	ActionPhaseTimeout    Action = "phase_timeout"
+)
+
+func (a Action) String() string {
+	return string(a)
+}
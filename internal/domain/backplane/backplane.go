@@ -0,0 +1,31 @@
+package backplane
+
+import "context"
+
+// Envelope is a message relayed between API instances
+type Envelope struct {
+	Topic string
+	// Seq numbers the messages of a topic. It is assigned on publish and is the
+	// same on every instance, so consecutive values mean nothing was missed.
+	Seq uint64
+	// Epoch identifies the counter Seq was taken from. It changes whenever the
+	// counter of the topic starts over, so sequence numbers are only comparable
+	// within the same epoch.
+	Epoch   string
+	Payload []byte
+}
+
+// Handler receives the messages of subscribed topics
+type Handler func(envelope Envelope)
+
+// Backplane fans messages out to every API instance, including the publishing one.
+// Messages of a topic are delivered in the order of their Seq.
+type Backplane interface {
+	// Publish sends a payload to the subscribers of a topic on every instance
+	Publish(ctx context.Context, topic string, payload []byte) error
+	// Subscribe registers a handler for the topics starting with prefix
+	Subscribe(prefix string, handler Handler)
+	// Forget drops the sequence counter of a topic that sees no more messages.
+	// A later publish to the topic starts a new epoch.
+	Forget(ctx context.Context, topic string) error
+}
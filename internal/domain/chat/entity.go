@@ -0,0 +1,74 @@
+package chat
+
+import "time"
+
+// Message represents a chat message posted by a participant in a room
+type Message struct {
+	id        MessageID
+	roomID    RoomID
+	userID    UserID
+	body      Body
+	createdAt time.Time
+	deletedAt *time.Time
+}
+
+// NewMessage creates a new Message
+func NewMessage(
+	id MessageID,
+	roomID RoomID,
+	userID UserID,
+	body Body,
+	createdAt time.Time,
+) *Message {
+	return &Message{
+		id:        id,
+		roomID:    roomID,
+		userID:    userID,
+		body:      body,
+		createdAt: createdAt,
+	}
+}
+
+// Getters
+func (m *Message) ID() MessageID {
+	return m.id
+}
+
+func (m *Message) RoomID() RoomID {
+	return m.roomID
+}
+
+func (m *Message) UserID() UserID {
+	return m.userID
+}
+
+func (m *Message) Body() Body {
+	return m.body
+}
+
+func (m *Message) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+func (m *Message) DeletedAt() *time.Time {
+	return m.deletedAt
+}
+
+// IsDeleted reports whether the message has been deleted
+func (m *Message) IsDeleted() bool {
+	return m.deletedAt != nil
+}
+
+// Delete marks the message as deleted
+func (m *Message) Delete(now time.Time) error {
+	if m.deletedAt != nil {
+		return ErrAlreadyDeleted
+	}
+	m.deletedAt = &now
+	return nil
+}
+
+// SetDeletedAt sets the deletion time without validation (for repository reconstruction)
+func (m *Message) SetDeletedAt(deletedAt *time.Time) {
+	m.deletedAt = deletedAt
+}
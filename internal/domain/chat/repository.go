@@ -0,0 +1,15 @@
+package chat
+
+import "context"
+
+// Repository defines the interface for chat message persistence
+type Repository interface {
+	// Save persists a chat message
+	Save(ctx context.Context, message *Message) error
+
+	// FindByID retrieves a chat message by ID
+	FindByID(ctx context.Context, id MessageID) (*Message, error)
+
+	// FindRecentByRoomID retrieves up to limit of the latest non-deleted messages in a room, oldest first
+	FindRecentByRoomID(ctx context.Context, roomID RoomID, limit int) ([]*Message, error)
+}
@@ -0,0 +1,86 @@
+package chat
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/shooooooma415/guess-title-game-api/utils"
+)
+
+var (
+	ErrMessageNotFound   = errors.New("chat message not found")
+	ErrEmptyBody         = errors.New("chat message cannot be empty")
+	ErrRateLimited       = errors.New("too many chat messages")
+	ErrAlreadyDeleted    = errors.New("chat message is already deleted")
+	ErrNotInRoom         = errors.New("chat message does not belong to the room")
+	ErrOnlyHostCanDelete = errors.New("only host can delete chat messages")
+)
+
+// MessageID represents a chat message identifier
+type MessageID struct {
+	value string
+}
+
+func NewMessageID() MessageID {
+	return MessageID{value: utils.GenerateUUID()}
+}
+
+func NewMessageIDFromString(value string) (MessageID, error) {
+	if err := utils.ValidateUUID(value); err != nil {
+		return MessageID{}, err
+	}
+	return MessageID{value: value}, nil
+}
+
+func (id MessageID) String() string {
+	return id.value
+}
+
+// RoomID represents a room identifier (reference to room domain)
+type RoomID struct {
+	value string
+}
+
+func NewRoomIDFromString(value string) (RoomID, error) {
+	if err := utils.ValidateUUID(value); err != nil {
+		return RoomID{}, err
+	}
+	return RoomID{value: value}, nil
+}
+
+func (id RoomID) String() string {
+	return id.value
+}
+
+// UserID represents the user who posted a message (reference to user domain)
+type UserID struct {
+	value string
+}
+
+func NewUserIDFromString(value string) (UserID, error) {
+	if err := utils.ValidateUUID(value); err != nil {
+		return UserID{}, err
+	}
+	return UserID{value: value}, nil
+}
+
+func (id UserID) String() string {
+	return id.value
+}
+
+// Body represents the text of a chat message
+type Body struct {
+	value string
+}
+
+func NewBody(value string) (Body, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return Body{}, ErrEmptyBody
+	}
+	return Body{value: trimmed}, nil
+}
+
+func (b Body) String() string {
+	return b.value
+}
@@ -0,0 +1,44 @@
+package clock
+
+import "time"
+
+// Clock provides the current time and timers.
+// Code that depends on time takes a Clock so tests can control time.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// After sends the current time on the returned channel once d has elapsed
+	After(d time.Duration) <-chan time.Time
+
+	// NewTicker returns a ticker that sends the current time every d
+	NewTicker(d time.Duration) Ticker
+
+	// NewTimer returns a timer that sends the current time once d has elapsed
+	NewTimer(d time.Duration) Timer
+
+	// AfterFunc calls f in its own goroutine once d has elapsed.
+	// The returned timer has no channel; Stop prevents the call.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Ticker delivers ticks at intervals
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered
+	C() <-chan time.Time
+
+	// Stop turns off the ticker
+	Stop()
+}
+
+// Timer delivers a single tick and can be rearmed
+type Timer interface {
+	// C returns the channel on which the tick is delivered
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing and discards an undelivered tick
+	Stop()
+
+	// Reset stops the timer and arms it to fire once d has elapsed
+	Reset(d time.Duration)
+}
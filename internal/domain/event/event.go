@@ -35,11 +35,11 @@ type GameStartedEvent struct {
 	Status string // "setting_topic"
 }
 
-func NewGameStartedEvent(roomID string) *GameStartedEvent {
+func NewGameStartedEvent(roomID string, occurredAt time.Time) *GameStartedEvent {
 	return &GameStartedEvent{
 		BaseEvent: BaseEvent{
 			eventType:   "GameStarted",
-			occurredAt:  time.Now(),
+			occurredAt:  occurredAt,
 			aggregateID: roomID,
 		},
 		RoomID: roomID,
@@ -54,11 +54,11 @@ type DiscussionSkippedEvent struct {
 	Status string // "answering"
 }
 
-func NewDiscussionSkippedEvent(roomID string) *DiscussionSkippedEvent {
+func NewDiscussionSkippedEvent(roomID string, occurredAt time.Time) *DiscussionSkippedEvent {
 	return &DiscussionSkippedEvent{
 		BaseEvent: BaseEvent{
 			eventType:   "DiscussionSkipped",
-			occurredAt:  time.Now(),
+			occurredAt:  occurredAt,
 			aggregateID: roomID,
 		},
 		RoomID: roomID,
@@ -73,11 +73,11 @@ type AnswerSubmittedEvent struct {
 	Status string // "checking"
 }
 
-func NewAnswerSubmittedEvent(roomID string) *AnswerSubmittedEvent {
+func NewAnswerSubmittedEvent(roomID string, occurredAt time.Time) *AnswerSubmittedEvent {
 	return &AnswerSubmittedEvent{
 		BaseEvent: BaseEvent{
 			eventType:   "AnswerSubmitted",
-			occurredAt:  time.Now(),
+			occurredAt:  occurredAt,
 			aggregateID: roomID,
 		},
 		RoomID: roomID,
@@ -92,11 +92,107 @@ type GameFinishedEvent struct {
 	Status string // "finished"
 }
 
-func NewGameFinishedEvent(roomID string) *GameFinishedEvent {
+func NewGameFinishedEvent(roomID string, occurredAt time.Time) *GameFinishedEvent {
 	return &GameFinishedEvent{
 		BaseEvent: BaseEvent{
 			eventType:   "GameFinished",
-			occurredAt:  time.Now(),
+			occurredAt:  occurredAt,
+			aggregateID: roomID,
+		},
+		RoomID: roomID,
+		Status: "finished",
+	}
+}
+
+// DiscussionStartedEvent is fired when discussion starts (SETTING_TOPIC -> DISCUSSING)
+type DiscussionStartedEvent struct {
+	BaseEvent
+	RoomID string
+	Status string // "discussing"
+}
+
+func NewDiscussionStartedEvent(roomID string, occurredAt time.Time) *DiscussionStartedEvent {
+	return &DiscussionStartedEvent{
+		BaseEvent: BaseEvent{
+			eventType:   "DiscussionStarted",
+			occurredAt:  occurredAt,
+			aggregateID: roomID,
+		},
+		RoomID: roomID,
+		Status: "discussing",
+	}
+}
+
+// RoomDeletedEvent is fired when a room is deleted
+type RoomDeletedEvent struct {
+	BaseEvent
+	RoomID string
+}
+
+func NewRoomDeletedEvent(roomID string, occurredAt time.Time) *RoomDeletedEvent {
+	return &RoomDeletedEvent{
+		BaseEvent: BaseEvent{
+			eventType:   "RoomDeleted",
+			occurredAt:  occurredAt,
+			aggregateID: roomID,
+		},
+		RoomID: roomID,
+	}
+}
+
+// TopicAutoSelectedEvent is fired when the setting_topic deadline passes and
+// emojis are picked from the theme preset (SETTING_TOPIC -> DISCUSSING)
+type TopicAutoSelectedEvent struct {
+	BaseEvent
+	RoomID string
+	Status string // "discussing"
+}
+
+func NewTopicAutoSelectedEvent(roomID string, occurredAt time.Time) *TopicAutoSelectedEvent {
+	return &TopicAutoSelectedEvent{
+		BaseEvent: BaseEvent{
+			eventType:   "TopicAutoSelected",
+			occurredAt:  occurredAt,
+			aggregateID: roomID,
+		},
+		RoomID: roomID,
+		Status: "discussing",
+	}
+}
+
+// AnswerTimedOutEvent is fired when the answering deadline passes and no
+// answer is submitted as incorrect (ANSWERING -> CHECKING)
+type AnswerTimedOutEvent struct {
+	BaseEvent
+	RoomID string
+	Status string // "checking"
+}
+
+func NewAnswerTimedOutEvent(roomID string, occurredAt time.Time) *AnswerTimedOutEvent {
+	return &AnswerTimedOutEvent{
+		BaseEvent: BaseEvent{
+			eventType:   "AnswerTimedOut",
+			occurredAt:  occurredAt,
+			aggregateID: roomID,
+		},
+		RoomID: roomID,
+		Status: "checking",
+	}
+}
+
+// GameAutoFinishedEvent is fired when the checking deadline passes and the
+// game is finished automatically (CHECKING -> FINISHED)
+type GameAutoFinishedEvent struct {
+	BaseEvent
+	RoomID string
+	Status string // "finished"
+}
+
+func NewGameAutoFinishedEvent(roomID string, occurredAt time.Time) *GameAutoFinishedEvent {
+	return &GameAutoFinishedEvent{
+		BaseEvent: BaseEvent{
+			eventType:   "GameAutoFinished",
+			occurredAt:  occurredAt,
 			aggregateID: roomID,
 		},
 		RoomID: roomID,
@@ -6,5 +6,12 @@ type Publisher interface {
 	Subscribe(eventType string, handler EventHandler)
 }
 
+// FanOutSubscriber is implemented by publishers that relay events between API instances.
+// Handlers registered with Subscribe run only on the instance that published the event.
+type FanOutSubscriber interface {
+	// SubscribeAll registers a handler that runs on every instance
+	SubscribeAll(eventType string, handler EventHandler)
+}
+
 // EventHandler is a function that handles an event
 type EventHandler func(event Event)
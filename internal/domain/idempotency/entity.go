@@ -0,0 +1,94 @@
+package idempotency
+
+import "time"
+
+// Record stores the first response returned for an idempotency key
+type Record struct {
+	key         Key
+	fingerprint Fingerprint
+	completed   bool
+	statusCode  int
+	contentType string
+	body        []byte
+	createdAt   time.Time
+	expiresAt   time.Time
+}
+
+// NewRecord creates a new in-progress Record
+func NewRecord(key Key, fingerprint Fingerprint, createdAt time.Time, ttl time.Duration) *Record {
+	return &Record{
+		key:         key,
+		fingerprint: fingerprint,
+		createdAt:   createdAt,
+		expiresAt:   createdAt.Add(ttl),
+	}
+}
+
+// RestoreRecord reconstructs a Record (for repository reconstruction)
+func RestoreRecord(
+	key Key,
+	fingerprint Fingerprint,
+	completed bool,
+	statusCode int,
+	contentType string,
+	body []byte,
+	createdAt time.Time,
+	expiresAt time.Time,
+) *Record {
+	return &Record{
+		key:         key,
+		fingerprint: fingerprint,
+		completed:   completed,
+		statusCode:  statusCode,
+		contentType: contentType,
+		body:        body,
+		createdAt:   createdAt,
+		expiresAt:   expiresAt,
+	}
+}
+
+// Getters
+func (r *Record) Key() Key {
+	return r.key
+}
+
+func (r *Record) Fingerprint() Fingerprint {
+	return r.fingerprint
+}
+
+func (r *Record) IsCompleted() bool {
+	return r.completed
+}
+
+func (r *Record) StatusCode() int {
+	return r.statusCode
+}
+
+func (r *Record) ContentType() string {
+	return r.contentType
+}
+
+func (r *Record) Body() []byte {
+	return r.body
+}
+
+func (r *Record) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *Record) ExpiresAt() time.Time {
+	return r.expiresAt
+}
+
+// IsExpired reports whether the record is past its replay window
+func (r *Record) IsExpired(now time.Time) bool {
+	return !now.Before(r.expiresAt)
+}
+
+// Complete stores the response to replay for retries
+func (r *Record) Complete(statusCode int, contentType string, body []byte) {
+	r.completed = true
+	r.statusCode = statusCode
+	r.contentType = contentType
+	r.body = body
+}
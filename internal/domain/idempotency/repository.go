@@ -0,0 +1,25 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for idempotency record persistence
+type Repository interface {
+	// Create stores a new record. It returns ErrKeyAlreadyExists if an
+	// unexpired record with the same key exists; expired records are replaced.
+	Create(ctx context.Context, record *Record) error
+
+	// Save updates an existing record
+	Save(ctx context.Context, record *Record) error
+
+	// FindByKey retrieves a record by key
+	FindByKey(ctx context.Context, key Key) (*Record, error)
+
+	// Delete removes a record
+	Delete(ctx context.Context, key Key) error
+
+	// DeleteExpired removes all records that expired before now
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
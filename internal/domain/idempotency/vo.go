@@ -0,0 +1,58 @@
+package idempotency
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrKeyAlreadyExists = errors.New("idempotency key already exists")
+	ErrRecordNotFound   = errors.New("idempotency record not found")
+)
+
+// MaxKeyLength is the maximum length of a client-supplied idempotency key
+const MaxKeyLength = 255
+
+// Key identifies a request by its client-supplied idempotency key,
+// scoped to the HTTP method and path it was sent to
+type Key struct {
+	value string
+}
+
+// NewKey creates a Key from the client-supplied key and the request method and path
+func NewKey(clientKey, method, path string) (Key, error) {
+	clientKey = strings.TrimSpace(clientKey)
+	if clientKey == "" {
+		return Key{}, errors.New("idempotency key cannot be empty")
+	}
+	if len(clientKey) > MaxKeyLength {
+		return Key{}, errors.New("idempotency key is too long")
+	}
+	return Key{value: method + " " + path + " " + clientKey}, nil
+}
+
+// NewKeyFromString reconstructs a Key from its stored representation
+func NewKeyFromString(value string) Key {
+	return Key{value: value}
+}
+
+func (k Key) String() string {
+	return k.value
+}
+
+// Fingerprint is a hash of the request body used to detect key reuse with a different request
+type Fingerprint struct {
+	value string
+}
+
+func NewFingerprint(value string) Fingerprint {
+	return Fingerprint{value: value}
+}
+
+func (f Fingerprint) String() string {
+	return f.value
+}
+
+func (f Fingerprint) Equals(other Fingerprint) bool {
+	return f.value == other.value
+}
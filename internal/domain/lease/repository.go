@@ -0,0 +1,18 @@
+package lease
+
+import (
+	"context"
+	"time"
+)
+
+// Repository coordinates exclusive ownership of named resources between API instances.
+// A lease expires unless its owner renews it, so another instance can take over
+// the resource when the owner dies.
+type Repository interface {
+	// Acquire takes the lease for owner, or renews it when owner already holds it.
+	// It reports false when another owner holds an unexpired lease.
+	Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+
+	// Release gives up the lease if owner holds it
+	Release(ctx context.Context, name, owner string) error
+}
@@ -0,0 +1,58 @@
+package moderation
+
+import "errors"
+
+// Error codes returned to clients when moderation rejects input
+const (
+	CodeInappropriateContent = "INAPPROPRIATE_CONTENT"
+	CodeContentTooLong       = "CONTENT_TOO_LONG"
+)
+
+var (
+	ErrInappropriateContent = errors.New("content contains inappropriate words")
+	ErrContentTooLong       = errors.New("content is too long")
+)
+
+// Field identifies the kind of user-supplied text being moderated
+type Field int
+
+const (
+	FieldUserName Field = iota
+	FieldTopic
+	FieldAnswer
+	FieldChatMessage
+)
+
+func (f Field) String() string {
+	switch f {
+	case FieldUserName:
+		return "user_name"
+	case FieldTopic:
+		return "topic"
+	case FieldAnswer:
+		return "answer"
+	case FieldChatMessage:
+		return "chat_message"
+	default:
+		return "unknown"
+	}
+}
+
+// Moderator validates and sanitizes user-supplied text before it is stored or broadcast
+type Moderator interface {
+	// Moderate returns the sanitized value, or an error if the value is rejected
+	Moderate(field Field, value string) (string, error)
+}
+
+// ErrorCode returns the client-facing error code for a moderation error,
+// or an empty string if err is not a moderation error
+func ErrorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrInappropriateContent):
+		return CodeInappropriateContent
+	case errors.Is(err, ErrContentTooLong):
+		return CodeContentTooLong
+	default:
+		return ""
+	}
+}
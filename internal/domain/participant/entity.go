@@ -18,6 +18,7 @@ func NewParticipant(
 	roomID RoomID,
 	userID UserID,
 	role ParticipantRole,
+	joinedAt time.Time,
 ) *Participant {
 	return &Participant{
 		id:       id,
@@ -25,7 +26,7 @@ func NewParticipant(
 		userID:   userID,
 		role:     role,
 		isLeader: false,
-		joinedAt: time.Now(),
+		joinedAt: joinedAt,
 	}
 }
 
@@ -0,0 +1,7 @@
+package ratelimit
+
+// Limiter limits how often a key may perform an action
+type Limiter interface {
+	// Allow reports whether the action is allowed now, consuming a token if so
+	Allow(key string) bool
+}
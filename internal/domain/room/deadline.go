@@ -0,0 +1,89 @@
+package room
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DiscussionStartDelay is the time between entering discussing and the start of the countdown
+	DiscussionStartDelay = 5 * time.Second
+	// DiscussionDuration is the length of the discussion countdown
+	DiscussionDuration = 300 * time.Second
+)
+
+var (
+	ErrDiscussionNotScheduled = errors.New("discussion deadline is not scheduled")
+	ErrDiscussionPaused       = errors.New("discussion is already paused")
+	ErrDiscussionNotPaused    = errors.New("discussion is not paused")
+)
+
+// DiscussionDeadline represents the schedule of a room's discussion countdown.
+// While paused, the countdown keeps the remaining time instead of running to endsAt.
+type DiscussionDeadline struct {
+	startsAt        time.Time
+	endsAt          time.Time
+	pausedRemaining *time.Duration
+}
+
+// NewDiscussionDeadline creates a deadline for a countdown of the given duration starting at startsAt
+func NewDiscussionDeadline(startsAt time.Time, duration time.Duration) DiscussionDeadline {
+	return DiscussionDeadline{startsAt: startsAt, endsAt: startsAt.Add(duration)}
+}
+
+// ReconstructDiscussionDeadline creates a deadline from persisted values (for repository reconstruction)
+func ReconstructDiscussionDeadline(startsAt, endsAt time.Time, pausedRemaining *time.Duration) DiscussionDeadline {
+	return DiscussionDeadline{startsAt: startsAt, endsAt: endsAt, pausedRemaining: pausedRemaining}
+}
+
+func (d DiscussionDeadline) StartsAt() time.Time {
+	return d.startsAt
+}
+
+func (d DiscussionDeadline) EndsAt() time.Time {
+	return d.endsAt
+}
+
+// PausedRemaining returns the time left when the countdown was paused, or nil while it runs
+func (d DiscussionDeadline) PausedRemaining() *time.Duration {
+	return d.pausedRemaining
+}
+
+func (d DiscussionDeadline) IsPaused() bool {
+	return d.pausedRemaining != nil
+}
+
+// Remaining returns the time left at now, never negative
+func (d DiscussionDeadline) Remaining(now time.Time) time.Duration {
+	if d.pausedRemaining != nil {
+		return *d.pausedRemaining
+	}
+	if now.Before(d.startsAt) {
+		return d.endsAt.Sub(d.startsAt)
+	}
+	return max(d.endsAt.Sub(now), 0)
+}
+
+// Pause freezes the countdown at its remaining time
+func (d DiscussionDeadline) Pause(now time.Time) (DiscussionDeadline, error) {
+	if d.IsPaused() {
+		return d, ErrDiscussionPaused
+	}
+	remaining := d.Remaining(now)
+	d.pausedRemaining = &remaining
+	return d, nil
+}
+
+// Resume restarts the countdown from its remaining time
+func (d DiscussionDeadline) Resume(now time.Time) (DiscussionDeadline, error) {
+	if !d.IsPaused() {
+		return d, ErrDiscussionNotPaused
+	}
+	remaining := *d.pausedRemaining
+	if now.Before(d.startsAt) {
+		now = d.startsAt
+	}
+	d.endsAt = now.Add(remaining)
+	d.pausedRemaining = nil
+	return d, nil
+}
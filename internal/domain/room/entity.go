@@ -19,6 +19,15 @@ type Room struct {
 	dummyIndex      *DummyIndex
 	dummyEmoji      *DummyEmoji
 	assignments     *Assignments
+	// Discussion countdown, scheduled when the room enters discussing
+	discussionDeadline *DiscussionDeadline
+	// Optional deadlines of the other phases, scheduled when the room enters them
+	phaseTimeouts PhaseTimeouts
+	phaseDeadline *time.Time
+	// Set to false when the answer was forfeited; nil when it has not been judged
+	answerCorrect *bool
+	// Version of the stored room this one was read from; zero until first saved
+	version int
 }
 
 // NewRoom creates a new Room
@@ -27,6 +36,7 @@ func NewRoom(
 	code RoomCode,
 	themeID ThemeID,
 	hostUserID HostUserID,
+	createdAt time.Time,
 ) *Room {
 	return &Room{
 		id:         id,
@@ -34,7 +44,7 @@ func NewRoom(
 		themeID:    themeID,
 		hostUserID: hostUserID,
 		status:     StatusWaiting,
-		createdAt:  time.Now(),
+		createdAt:  createdAt,
 	}
 }
 
@@ -96,6 +106,33 @@ func (r *Room) Assignments() *Assignments {
 	return r.assignments
 }
 
+func (r *Room) DiscussionDeadline() *DiscussionDeadline {
+	return r.discussionDeadline
+}
+
+func (r *Room) PhaseTimeouts() PhaseTimeouts {
+	return r.phaseTimeouts
+}
+
+// PhaseDeadline returns when the current phase falls back, or nil when it has no deadline
+func (r *Room) PhaseDeadline() *time.Time {
+	return r.phaseDeadline
+}
+
+func (r *Room) AnswerCorrect() *bool {
+	return r.answerCorrect
+}
+
+// Version returns the version of the stored room, which Save compares to detect concurrent writers
+func (r *Room) Version() int {
+	return r.version
+}
+
+// PhaseDeadlinePassed reports whether the deadline of the current phase is over at now
+func (r *Room) PhaseDeadlinePassed(now time.Time) bool {
+	return r.phaseDeadline != nil && !now.Before(*r.phaseDeadline)
+}
+
 // SetTopic sets the topic for the room
 func (r *Room) SetTopic(topic Topic) error {
 	if r.status != StatusSettingTopic {
@@ -117,9 +154,30 @@ func (r *Room) SetGameData(originalEmojis, displayedEmojis EmojiList, dummyIndex
 // SetAnswer sets the answer for the room
 func (r *Room) SetAnswer(answer Answer) error {
 	r.answer = &answer
+	r.answerCorrect = nil
 	return nil
 }
 
+// ForfeitAnswer submits NoAnswer, marks it incorrect and moves on to checking
+func (r *Room) ForfeitAnswer(now time.Time) error {
+	if r.status != StatusAnswering {
+		return ErrInvalidStatusTransition
+	}
+	answer, err := NewAnswer(NoAnswer)
+	if err != nil {
+		return err
+	}
+	incorrect := false
+	r.answer = &answer
+	r.answerCorrect = &incorrect
+	return r.ChangeStatus(StatusChecking, now)
+}
+
+// SetPhaseTimeouts sets the deadlines of the setting_topic, answering and checking phases
+func (r *Room) SetPhaseTimeouts(timeouts PhaseTimeouts) {
+	r.phaseTimeouts = timeouts
+}
+
 // SetAssignments sets the emoji assignments
 func (r *Room) SetAssignments(assignments Assignments) error {
 	r.assignments = &assignments
@@ -127,22 +185,63 @@ func (r *Room) SetAssignments(assignments Assignments) error {
 }
 
 // Start starts the room discussion
-func (r *Room) Start() error {
+func (r *Room) Start(now time.Time) error {
 	if r.status != StatusWaiting {
 		return ErrInvalidStatusTransition
 	}
-	now := time.Now()
 	r.startedAt = &now
-	r.status = StatusSettingTopic
+	r.enter(StatusSettingTopic, now)
 	return nil
 }
 
 // ChangeStatus changes the room status with validation
-func (r *Room) ChangeStatus(status RoomStatus) error {
+func (r *Room) ChangeStatus(status RoomStatus, now time.Time) error {
 	if !r.status.CanTransitionTo(status) {
 		return ErrInvalidStatusTransition
 	}
+	r.enter(status, now)
+	return nil
+}
+
+// enter sets the status and schedules the discussion countdown when entering discussing,
+// or the deadline of the phase when the room has one
+func (r *Room) enter(status RoomStatus, now time.Time) {
 	r.status = status
+	if status == StatusDiscussing {
+		deadline := NewDiscussionDeadline(now.Add(DiscussionStartDelay), DiscussionDuration)
+		r.discussionDeadline = &deadline
+	}
+
+	r.phaseDeadline = nil
+	if timeout := r.phaseTimeouts.For(status); timeout > 0 {
+		deadline := now.Add(timeout)
+		r.phaseDeadline = &deadline
+	}
+}
+
+// PauseDiscussion freezes the discussion countdown
+func (r *Room) PauseDiscussion(now time.Time) error {
+	if r.status != StatusDiscussing || r.discussionDeadline == nil {
+		return ErrDiscussionNotScheduled
+	}
+	deadline, err := r.discussionDeadline.Pause(now)
+	if err != nil {
+		return err
+	}
+	r.discussionDeadline = &deadline
+	return nil
+}
+
+// ResumeDiscussion restarts a paused discussion countdown
+func (r *Room) ResumeDiscussion(now time.Time) error {
+	if r.status != StatusDiscussing || r.discussionDeadline == nil {
+		return ErrDiscussionNotScheduled
+	}
+	deadline, err := r.discussionDeadline.Resume(now)
+	if err != nil {
+		return err
+	}
+	r.discussionDeadline = &deadline
 	return nil
 }
 
@@ -155,3 +254,37 @@ func (r *Room) SetStatus(status RoomStatus) {
 func (r *Room) SetTopicUnchecked(topic *Topic) {
 	r.topic = topic
 }
+
+// SetTimestamps sets the creation and start times (for repository reconstruction)
+func (r *Room) SetTimestamps(createdAt time.Time, startedAt *time.Time) {
+	r.createdAt = createdAt
+	r.startedAt = startedAt
+}
+
+// SetDiscussionDeadline sets the discussion countdown (for repository reconstruction)
+func (r *Room) SetDiscussionDeadline(deadline *DiscussionDeadline) {
+	r.discussionDeadline = deadline
+}
+
+// SetPhaseDeadline sets the deadline of the current phase (for repository reconstruction)
+func (r *Room) SetPhaseDeadline(deadline *time.Time) {
+	r.phaseDeadline = deadline
+}
+
+// SetAnswerCorrect sets whether the answer was correct (for repository reconstruction)
+func (r *Room) SetAnswerCorrect(correct *bool) {
+	r.answerCorrect = correct
+}
+
+// SetVersion sets the version of the stored room (for repository reconstruction and after saving)
+func (r *Room) SetVersion(version int) {
+	r.version = version
+}
+
+// ForceStatus sets the room status without transition validation (for administrative intervention)
+func (r *Room) ForceStatus(status RoomStatus, now time.Time) {
+	if r.startedAt == nil && status != StatusWaiting {
+		r.startedAt = &now
+	}
+	r.enter(status, now)
+}
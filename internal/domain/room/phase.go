@@ -0,0 +1,108 @@
+package room
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxPhaseTimeout is the longest deadline a room can set for a phase
+const MaxPhaseTimeout = time.Hour
+
+// NoAnswer is the answer submitted when the leader does not answer before the deadline
+const NoAnswer = "(no answer)"
+
+var (
+	ErrInvalidPhaseTimeout  = errors.New("phase timeout must be between 0 and 1 hour")
+	ErrPhaseDeadlineNotDue  = errors.New("phase deadline has not passed")
+	ErrNotEnoughPresetEmoji = errors.New("emoji preset needs at least two emojis")
+)
+
+// DefaultEmojiPreset is used when the theme of a room has no emoji preset
+var DefaultEmojiPreset = []string{"😀", "🎉", "🌟", "🍀", "🎵", "🔥", "🌈", "🍎"}
+
+// PhaseTimeouts represents the per-room deadlines of the setting_topic,
+// answering and checking phases. A zero timeout disables the deadline.
+type PhaseTimeouts struct {
+	settingTopic time.Duration
+	answering    time.Duration
+	checking     time.Duration
+}
+
+// NewPhaseTimeouts creates PhaseTimeouts, rejecting negative or too long timeouts
+func NewPhaseTimeouts(settingTopic, answering, checking time.Duration) (PhaseTimeouts, error) {
+	for _, timeout := range []time.Duration{settingTopic, answering, checking} {
+		if timeout < 0 || timeout > MaxPhaseTimeout {
+			return PhaseTimeouts{}, ErrInvalidPhaseTimeout
+		}
+	}
+	return PhaseTimeouts{settingTopic: settingTopic, answering: answering, checking: checking}, nil
+}
+
+func (t PhaseTimeouts) SettingTopic() time.Duration {
+	return t.settingTopic
+}
+
+func (t PhaseTimeouts) Answering() time.Duration {
+	return t.answering
+}
+
+func (t PhaseTimeouts) Checking() time.Duration {
+	return t.checking
+}
+
+// IsZero reports whether no phase has a deadline
+func (t PhaseTimeouts) IsZero() bool {
+	return t == PhaseTimeouts{}
+}
+
+// For returns the timeout of a status, or zero for phases without a deadline
+func (t PhaseTimeouts) For(status RoomStatus) time.Duration {
+	switch status {
+	case StatusSettingTopic:
+		return t.settingTopic
+	case StatusAnswering:
+		return t.answering
+	case StatusChecking:
+		return t.checking
+	default:
+		return 0
+	}
+}
+
+// AutoTopic is the game data picked from an emoji preset when the host does not set a topic in time
+type AutoTopic struct {
+	OriginalEmojis  EmojiList
+	DisplayedEmojis EmojiList
+	DummyIndex      DummyIndex
+	DummyEmoji      DummyEmoji
+}
+
+// NewAutoTopic picks count emojis from the preset and replaces the one at dummyIndex
+// with the next emoji of the preset. count is reduced to fit the preset.
+func NewAutoTopic(preset []string, count, dummyIndex int) (AutoTopic, error) {
+	if len(preset) < 2 {
+		return AutoTopic{}, ErrNotEnoughPresetEmoji
+	}
+	count = min(max(count, 1), len(preset)-1)
+	dummyIndex = min(max(dummyIndex, 0), count-1)
+
+	original := append([]string(nil), preset[:count]...)
+	displayed := append([]string(nil), original...)
+	displayed[dummyIndex] = preset[count]
+
+	index, err := NewDummyIndex(dummyIndex)
+	if err != nil {
+		return AutoTopic{}, err
+	}
+	dummy, err := NewDummyEmoji(preset[count])
+	if err != nil {
+		return AutoTopic{}, err
+	}
+
+	return AutoTopic{
+		OriginalEmojis:  NewEmojiList(original),
+		DisplayedEmojis: NewEmojiList(displayed),
+		DummyIndex:      index,
+		DummyEmoji:      dummy,
+	}, nil
+}
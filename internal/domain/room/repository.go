@@ -1,10 +1,43 @@
 package room
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// MaxConflictRetries is how many times RetryOnConflict re-runs a change after a version conflict
+const MaxConflictRetries = 3
+
+var (
+	ErrVersionConflict = errors.New("room was modified concurrently")
+)
+
+// SearchCriteria represents filters for listing rooms.
+// Nil fields are not applied. Players are the participants other than the host.
+type SearchCriteria struct {
+	Status        *RoomStatus
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	MinPlayers    *int
+	MaxPlayers    *int
+	// Rooms whose phase deadline is at or before this time
+	PhaseEndsBefore *time.Time
+	Limit           int
+}
+
+// SearchResult represents a room found by Search
+type SearchResult struct {
+	Room *Room
+	// PlayerCount is the number of participants other than the host
+	PlayerCount int
+}
 
 // Repository defines the interface for room persistence
 type Repository interface {
-	// Save persists a room
+	// Save persists a room and advances its version. It fails with
+	// ErrVersionConflict when the stored room is no longer at the version
+	// the room was read from, or when a new room already exists.
 	Save(ctx context.Context, room *Room) error
 
 	// FindByID retrieves a room by ID
@@ -13,6 +46,20 @@ type Repository interface {
 	// FindByCode retrieves a room by code
 	FindByCode(ctx context.Context, code RoomCode) (*Room, error)
 
+	// Search retrieves rooms matching the criteria with their player counts, newest first
+	Search(ctx context.Context, criteria SearchCriteria) ([]SearchResult, error)
+
 	// Delete removes a room
 	Delete(ctx context.Context, id RoomID) error
 }
+
+// RetryOnConflict runs a read-modify-save of a room again while it fails with
+// ErrVersionConflict, so each attempt applies its change to a fresh read.
+// It gives up after MaxConflictRetries retries and returns the conflict.
+func RetryOnConflict(attempt func() error) error {
+	err := attempt()
+	for i := 0; i < MaxConflictRetries && errors.Is(err, ErrVersionConflict); i++ {
+		err = attempt()
+	}
+	return err
+}
@@ -39,3 +39,9 @@ func (re *RoomEmoji) ParticipantID() *ParticipantID {
 func (re *RoomEmoji) Emoji() Emoji {
 	return re.emoji
 }
+
+// ReactionCount represents how many times an emoji was sent in a room
+type ReactionCount struct {
+	Emoji Emoji
+	Count int
+}
@@ -13,6 +13,9 @@ type Repository interface {
 	// FindByRoomID retrieves all emojis in a room
 	FindByRoomID(ctx context.Context, roomID RoomID) ([]*RoomEmoji, error)
 
+	// CountByRoomID aggregates the emojis in a room, most frequent first
+	CountByRoomID(ctx context.Context, roomID RoomID) ([]ReactionCount, error)
+
 	// Delete removes a room emoji
 	Delete(ctx context.Context, id RoomEmojiID) error
 }
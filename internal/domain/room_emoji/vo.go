@@ -2,10 +2,22 @@ package room_emoji
 
 import (
 	"errors"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/shooooooma415/guess-title-game-api/utils"
 )
 
+var (
+	ErrRoomEmojiNotFound  = errors.New("room emoji not found")
+	ErrInvalidReaction    = errors.New("reaction must be a single emoji")
+	ErrReactionNotAllowed = errors.New("reactions are only allowed during discussion and checking")
+	ErrTooManyReactions   = errors.New("too many reactions")
+)
+
+// maxReactionRunes bounds a reaction to one emoji, allowing for modifiers and ZWJ sequences
+const maxReactionRunes = 8
+
 // RoomEmojiID represents a room emoji identifier
 type RoomEmojiID struct {
 	value string
@@ -76,3 +88,21 @@ func NewEmoji(value string) (Emoji, error) {
 func (e Emoji) String() string {
 	return e.value
 }
+
+// NewReactionEmoji creates an Emoji for a reaction, which must be a single emoji
+// without letters, digits or whitespace
+func NewReactionEmoji(value string) (Emoji, error) {
+	emoji, err := NewEmoji(value)
+	if err != nil {
+		return Emoji{}, ErrInvalidReaction
+	}
+	if utf8.RuneCountInString(value) > maxReactionRunes {
+		return Emoji{}, ErrInvalidReaction
+	}
+	for _, r := range value {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsControl(r) {
+			return Emoji{}, ErrInvalidReaction
+		}
+	}
+	return emoji, nil
+}
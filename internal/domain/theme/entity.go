@@ -5,6 +5,8 @@ type Theme struct {
 	id    ThemeID
 	title ThemeTitle
 	hint  Hint
+	// Emojis picked for the room when the host does not set a topic in time
+	emojiPreset []string
 }
 
 // NewTheme creates a new Theme
@@ -29,6 +31,15 @@ func (t *Theme) Hint() Hint {
 	return t.hint
 }
 
+func (t *Theme) EmojiPreset() []string {
+	return t.emojiPreset
+}
+
+// SetEmojiPreset sets the emojis picked when the host does not set a topic in time
+func (t *Theme) SetEmojiPreset(emojis []string) {
+	t.emojiPreset = emojis
+}
+
 // UpdateHint updates the theme hint
 func (t *Theme) UpdateHint(hint Hint) {
 	t.hint = hint
@@ -0,0 +1,12 @@
+package transaction
+
+import "context"
+
+// Manager runs units of work that span several repositories.
+// Repositories called with the context passed to fn take part in the
+// transaction, so their changes are committed or rolled back together.
+type Manager interface {
+	// Do runs fn in a transaction, committing it when fn returns nil and rolling
+	// it back otherwise. Called inside another Do, fn joins the outer transaction.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -10,11 +10,11 @@ type User struct {
 }
 
 // NewUser creates a new User
-func NewUser(id UserID, name UserName) *User {
+func NewUser(id UserID, name UserName, createdAt time.Time) *User {
 	return &User{
 		id:        id,
 		name:      name,
-		createdAt: time.Now(),
+		createdAt: createdAt,
 	}
 }
 
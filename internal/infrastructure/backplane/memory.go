@@ -0,0 +1,106 @@
+package backplane
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/backplane"
+)
+
+// subscription is a handler registered for a topic prefix
+type subscription struct {
+	prefix  string
+	handler backplane.Handler
+}
+
+const (
+	// sequenceRetention is how long the counter of a topic is kept without a publish
+	sequenceRetention = 24 * time.Hour
+	// sweepInterval is how often idle counters and stored payloads are swept
+	sweepInterval = time.Minute
+)
+
+// sequence is the counter of a topic
+type sequence struct {
+	epoch       string
+	seq         uint64
+	publishedAt time.Time
+}
+
+// InMemoryBackplane is an in-process implementation of backplane.Backplane for a single instance
+type InMemoryBackplane struct {
+	mu            sync.Mutex
+	seqs          map[string]sequence
+	sweptAt       time.Time
+	subscriptions []subscription
+}
+
+// NewInMemoryBackplane creates a new InMemoryBackplane
+func NewInMemoryBackplane() *InMemoryBackplane {
+	return &InMemoryBackplane{
+		seqs: make(map[string]sequence),
+	}
+}
+
+// Publish delivers a payload to the subscribers of the topic.
+// Handlers run synchronously so that messages keep their publish order.
+func (b *InMemoryBackplane) Publish(ctx context.Context, topic string, payload []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(b.sweptAt) >= sweepInterval {
+		b.sweep(now)
+	}
+
+	next, ok := b.seqs[topic]
+	if !ok {
+		// Counters live in the process, so each one starts a new epoch
+		next.epoch = uuid.NewString()
+	}
+	next.seq++
+	next.publishedAt = now
+	b.seqs[topic] = next
+	envelope := backplane.Envelope{
+		Topic:   topic,
+		Seq:     next.seq,
+		Epoch:   next.epoch,
+		Payload: payload,
+	}
+	for _, sub := range b.subscriptions {
+		if strings.HasPrefix(topic, sub.prefix) {
+			sub.handler(envelope)
+		}
+	}
+	return nil
+}
+
+// Subscribe registers a handler for the topics starting with prefix
+func (b *InMemoryBackplane) Subscribe(prefix string, handler backplane.Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscriptions = append(b.subscriptions, subscription{prefix: prefix, handler: handler})
+}
+
+// Forget drops the counter of a topic
+func (b *InMemoryBackplane) Forget(ctx context.Context, topic string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.seqs, topic)
+	return nil
+}
+
+// sweep drops the counters not published to within sequenceRetention
+func (b *InMemoryBackplane) sweep(now time.Time) {
+	for topic, seq := range b.seqs {
+		if now.Sub(seq.publishedAt) >= sequenceRetention {
+			delete(b.seqs, topic)
+		}
+	}
+	b.sweptAt = now
+}
@@ -0,0 +1,97 @@
+package backplane_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/backplane"
+	infrastructureBackplane "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/backplane"
+)
+
+func TestInMemoryBackplane(t *testing.T) {
+	t.Run("プレフィックスに一致するトピックのみ配信されること", func(t *testing.T) {
+		// arrange
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		var got []string
+		bp.Subscribe("room/", func(envelope backplane.Envelope) {
+			got = append(got, envelope.Topic)
+		})
+
+		// act
+		bp.Publish(context.Background(), "room/1", []byte("a"))
+		bp.Publish(context.Background(), "event/GameStarted", []byte("b"))
+
+		// assert
+		if len(got) != 1 || got[0] != "room/1" {
+			t.Errorf("Expected only room/1 to be delivered, got: %v", got)
+		}
+	})
+
+	t.Run("シーケンス番号がトピックごとに連番で振られること", func(t *testing.T) {
+		// arrange
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		seqs := map[string][]uint64{}
+		bp.Subscribe("", func(envelope backplane.Envelope) {
+			seqs[envelope.Topic] = append(seqs[envelope.Topic], envelope.Seq)
+		})
+
+		// act
+		bp.Publish(context.Background(), "room/1", nil)
+		bp.Publish(context.Background(), "room/2", nil)
+		bp.Publish(context.Background(), "room/1", nil)
+
+		// assert
+		if got := seqs["room/1"]; len(got) != 2 || got[0] != 1 || got[1] != 2 {
+			t.Errorf("Expected room/1 to have seqs [1 2], got: %v", got)
+		}
+		if got := seqs["room/2"]; len(got) != 1 || got[0] != 1 {
+			t.Errorf("Expected room/2 to have seqs [1], got: %v", got)
+		}
+	})
+
+	t.Run("同じトピックのメッセージには同じエポックが振られトピックごとに異なること", func(t *testing.T) {
+		// arrange
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		epochs := map[string][]string{}
+		bp.Subscribe("", func(envelope backplane.Envelope) {
+			epochs[envelope.Topic] = append(epochs[envelope.Topic], envelope.Epoch)
+		})
+
+		// act
+		bp.Publish(context.Background(), "room/1", nil)
+		bp.Publish(context.Background(), "room/1", nil)
+		bp.Publish(context.Background(), "room/2", nil)
+
+		// assert
+		room1, room2 := epochs["room/1"], epochs["room/2"]
+		if room1[0] == "" || room1[0] != room1[1] {
+			t.Errorf("Expected room/1 to keep one epoch, got: %v", room1)
+		}
+		if room2[0] == room1[0] {
+			t.Errorf("Expected room/2 to have its own epoch, got: %s", room2[0])
+		}
+	})
+
+	t.Run("破棄したトピックは新しいエポックで1から採番されること", func(t *testing.T) {
+		// arrange
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		var got []backplane.Envelope
+		bp.Subscribe("", func(envelope backplane.Envelope) {
+			got = append(got, envelope)
+		})
+		bp.Publish(context.Background(), "room/1", nil)
+		bp.Publish(context.Background(), "room/1", nil)
+
+		// act
+		err := bp.Forget(context.Background(), "room/1")
+		bp.Publish(context.Background(), "room/1", nil)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Failed to forget topic: %v", err)
+		}
+		if got[2].Seq != 1 || got[2].Epoch == got[1].Epoch {
+			t.Errorf("Expected seq 1 in a new epoch, got: seq %d epoch %s (previously %s)", got[2].Seq, got[2].Epoch, got[1].Epoch)
+		}
+	})
+}
@@ -0,0 +1,218 @@
+package backplane
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/backplane"
+)
+
+const (
+	// maxNotifyPayload is the NOTIFY payload limit of Postgres; payloads must be shorter
+	maxNotifyPayload = 8000
+	// notifyFormatting covers the spaces json_build_object adds around keys and values
+	notifyFormatting = 64
+)
+
+// notification is the NOTIFY payload sent on the channel.
+// Payloads too large for a NOTIFY are stored in backplane_payloads and sent by ID.
+type notification struct {
+	Topic     string `json:"topic"`
+	Seq       uint64 `json:"seq"`
+	Epoch     string `json:"epoch"`
+	Payload   string `json:"payload,omitempty"`
+	PayloadID int64  `json:"payload_id,omitempty"`
+}
+
+// notificationSize returns an upper bound of the size of the NOTIFY payload
+// Postgres builds for a message. Go escapes JSON strings at least as much as
+// json_build_object does, so the bound holds for any payload.
+func notificationSize(topic string, payload []byte) int {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(notification{
+		Topic:   topic,
+		Seq:     math.MaxUint64,
+		Epoch:   uuid.Nil.String(),
+		Payload: string(payload),
+	})
+	return buf.Len() + notifyFormatting
+}
+
+// PostgresBackplane is an implementation of backplane.Backplane using Postgres LISTEN/NOTIFY.
+// Sequence numbers are allocated in the backplane_sequences table in the same
+// statement as the NOTIFY, so every instance sees a topic in the same order.
+// A counter created again after its row was deleted gets a new epoch.
+type PostgresBackplane struct {
+	db            *sql.DB
+	listener      *pq.Listener
+	channel       string
+	done          chan struct{}
+	mu            sync.RWMutex
+	subscriptions []subscription
+}
+
+// NewPostgresBackplane creates a PostgresBackplane listening on channel.
+// dsn is used for the dedicated LISTEN connection; db is used to publish.
+func NewPostgresBackplane(db *sql.DB, dsn string, channel string) (*PostgresBackplane, error) {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Backplane listener error: %v", err)
+		}
+	})
+	if err := listener.Listen(channel); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to listen on backplane channel: %w", err)
+	}
+
+	b := &PostgresBackplane{
+		db:       db,
+		listener: listener,
+		channel:  channel,
+		done:     make(chan struct{}),
+	}
+	go b.receive()
+	go b.sweep()
+	return b, nil
+}
+
+// Publish allocates the next sequence number of the topic and notifies every instance.
+// A payload that would not fit in the NOTIFY once escaped is stored in a table
+// and fetched by every instance instead.
+func (b *PostgresBackplane) Publish(ctx context.Context, topic string, payload []byte) error {
+	if notificationSize(topic, payload) >= maxNotifyPayload {
+		return b.publishStored(ctx, topic, payload)
+	}
+
+	query := `
+		WITH next AS (
+			INSERT INTO backplane_sequences (topic, seq, epoch) VALUES ($1, 1, $4)
+			ON CONFLICT (topic) DO UPDATE SET seq = backplane_sequences.seq + 1, updated_at = NOW()
+			RETURNING seq, epoch
+		)
+		SELECT pg_notify($2, json_build_object('topic', $1::text, 'seq', next.seq, 'epoch', next.epoch, 'payload', $3::text)::text)
+		FROM next
+	`
+	// The epoch is only used when the statement creates the counter of the topic
+	if _, err := b.db.ExecContext(ctx, query, topic, b.channel, string(payload), uuid.NewString()); err != nil {
+		return fmt.Errorf("failed to publish to backplane: %w", err)
+	}
+	return nil
+}
+
+// publishStored publishes a payload through the backplane_payloads table.
+// Stored payloads are kept for a minute, long enough for every listening instance
+// to fetch them.
+func (b *PostgresBackplane) publishStored(ctx context.Context, topic string, payload []byte) error {
+	query := `
+		WITH next AS (
+			INSERT INTO backplane_sequences (topic, seq, epoch) VALUES ($1, 1, $4)
+			ON CONFLICT (topic) DO UPDATE SET seq = backplane_sequences.seq + 1, updated_at = NOW()
+			RETURNING seq, epoch
+		), stored AS (
+			INSERT INTO backplane_payloads (payload) VALUES ($3)
+			RETURNING id
+		)
+		SELECT pg_notify($2, json_build_object('topic', $1::text, 'seq', next.seq, 'epoch', next.epoch, 'payload_id', stored.id)::text)
+		FROM next, stored
+	`
+	if _, err := b.db.ExecContext(ctx, query, topic, b.channel, string(payload), uuid.NewString()); err != nil {
+		return fmt.Errorf("failed to publish to backplane: %w", err)
+	}
+	return nil
+}
+
+// Subscribe registers a handler for the topics starting with prefix
+func (b *PostgresBackplane) Subscribe(prefix string, handler backplane.Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscriptions = append(b.subscriptions, subscription{prefix: prefix, handler: handler})
+}
+
+// Forget deletes the counter of a topic
+func (b *PostgresBackplane) Forget(ctx context.Context, topic string) error {
+	if _, err := b.db.ExecContext(ctx, `DELETE FROM backplane_sequences WHERE topic = $1`, topic); err != nil {
+		return fmt.Errorf("failed to forget backplane topic: %w", err)
+	}
+	return nil
+}
+
+// Close stops listening for notifications
+func (b *PostgresBackplane) Close() error {
+	close(b.done)
+	return b.listener.Close()
+}
+
+// sweep periodically deletes the stored payloads older than a minute and the
+// counters not published to within sequenceRetention, until the backplane is closed
+func (b *PostgresBackplane) sweep() {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := b.db.Exec(`DELETE FROM backplane_payloads WHERE created_at < NOW() - INTERVAL '1 minute'`); err != nil {
+			log.Printf("Error sweeping backplane payloads: %v", err)
+		}
+		if _, err := b.db.Exec(`DELETE FROM backplane_sequences WHERE updated_at < NOW() - make_interval(secs => $1)`, sequenceRetention.Seconds()); err != nil {
+			log.Printf("Error sweeping backplane sequences: %v", err)
+		}
+	}
+}
+
+// receive dispatches notifications to the subscribers until the listener is closed
+func (b *PostgresBackplane) receive() {
+	for n := range b.listener.Notify {
+		if n == nil {
+			// The connection was re-established; notifications sent in between are lost
+			// and subscribers detect the gap from the sequence numbers.
+			log.Printf("Backplane listener reconnected")
+			continue
+		}
+
+		var msg notification
+		if err := json.Unmarshal([]byte(n.Extra), &msg); err != nil {
+			log.Printf("Error decoding backplane notification: %v", err)
+			continue
+		}
+		if msg.PayloadID != 0 {
+			// A payload that cannot be fetched is skipped like a lost notification
+			if err := b.db.QueryRow(`SELECT payload FROM backplane_payloads WHERE id = $1`, msg.PayloadID).Scan(&msg.Payload); err != nil {
+				log.Printf("Error fetching backplane payload %d: %v", msg.PayloadID, err)
+				continue
+			}
+		}
+		envelope := backplane.Envelope{
+			Topic:   msg.Topic,
+			Seq:     msg.Seq,
+			Epoch:   msg.Epoch,
+			Payload: []byte(msg.Payload),
+		}
+
+		b.mu.RLock()
+		subscriptions := b.subscriptions
+		b.mu.RUnlock()
+		for _, sub := range subscriptions {
+			if strings.HasPrefix(envelope.Topic, sub.prefix) {
+				sub.handler(envelope)
+			}
+		}
+	}
+}
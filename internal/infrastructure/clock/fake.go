@@ -0,0 +1,215 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+)
+
+// FakeClock is an implementation of clock.Clock whose time only moves when
+// Advance is called. Timers and tickers fire in order of their deadlines as
+// time passes them; like real tickers, a tick is dropped when the previous
+// one has not been received yet.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+	changed chan struct{} // closed and replaced whenever waiters change
+}
+
+// fakeWaiter is a pending timer or ticker of a FakeClock
+type fakeWaiter struct {
+	at     time.Time
+	period time.Duration // zero for one-shot timers
+	ch     chan time.Time
+	fn     func() // called instead of sending on ch, for AfterFunc
+}
+
+// fire delivers a tick of the waiter at the given time
+func (w *fakeWaiter) fire(at time.Time) {
+	if w.fn != nil {
+		go w.fn()
+		return
+	}
+	select {
+	case w.ch <- at:
+	default:
+	}
+}
+
+// NewFakeClock creates a new FakeClock set to now
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now, changed: make(chan struct{})}
+}
+
+// Now returns the current fake time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After sends the fake time once it has been advanced by d
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.addWaiter(&fakeWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// NewTicker returns a ticker that sends the fake time every d
+func (c *FakeClock) NewTicker(d time.Duration) clock.Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &fakeWaiter{at: c.now.Add(d), period: d, ch: make(chan time.Time, 1)}
+	c.addWaiter(w)
+	return &fakeTicker{clock: c, waiter: w}
+}
+
+// NewTimer returns a timer that sends the fake time once it has been advanced by d
+func (c *FakeClock) NewTimer(d time.Duration) clock.Timer {
+	t := &fakeTimer{clock: c, waiter: &fakeWaiter{ch: make(chan time.Time, 1)}}
+	t.Reset(d)
+	return t
+}
+
+// AfterFunc calls f in its own goroutine once the fake time has been advanced by d
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) clock.Timer {
+	t := &fakeTimer{clock: c, waiter: &fakeWaiter{fn: f}}
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the time forward by d, firing the timers and tickers due on the way
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	end := c.now.Add(d)
+	for {
+		sort.SliceStable(c.waiters, func(i, j int) bool {
+			return c.waiters[i].at.Before(c.waiters[j].at)
+		})
+		if len(c.waiters) == 0 || c.waiters[0].at.After(end) {
+			break
+		}
+
+		w := c.waiters[0]
+		c.now = w.at
+		w.fire(w.at)
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+		} else {
+			c.removeWaiter(w)
+		}
+	}
+	c.now = end
+}
+
+// BlockUntil waits until n timers and tickers are pending, so that a test can
+// advance the time only once the code under test has started waiting
+func (c *FakeClock) BlockUntil(n int) {
+	for {
+		c.mu.Lock()
+		pending, changed := len(c.waiters), c.changed
+		c.mu.Unlock()
+
+		if pending >= n {
+			return
+		}
+		<-changed
+	}
+}
+
+// addWaiter registers a waiter. Callers must hold c.mu.
+func (c *FakeClock) addWaiter(w *fakeWaiter) {
+	c.waiters = append(c.waiters, w)
+	c.notify()
+}
+
+// removeWaiter unregisters a waiter. Callers must hold c.mu.
+func (c *FakeClock) removeWaiter(w *fakeWaiter) {
+	for i, waiter := range c.waiters {
+		if waiter == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.notify()
+			return
+		}
+	}
+}
+
+// notify wakes up BlockUntil callers. Callers must hold c.mu.
+func (c *FakeClock) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// fakeTicker is a ticker of a FakeClock
+type fakeTicker struct {
+	clock  *FakeClock
+	waiter *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.waiter.ch
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	t.clock.removeWaiter(t.waiter)
+}
+
+// fakeTimer is a timer of a FakeClock
+type fakeTimer struct {
+	clock  *FakeClock
+	waiter *fakeWaiter
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.waiter.ch
+}
+
+func (t *fakeTimer) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	t.stop()
+}
+
+func (t *fakeTimer) Reset(d time.Duration) {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	t.stop()
+	if d <= 0 {
+		t.waiter.fire(t.clock.now)
+		return
+	}
+	t.waiter.at = t.clock.now.Add(d)
+	t.clock.addWaiter(t.waiter)
+}
+
+// stop unregisters the timer and discards its undelivered tick. Callers must hold clock.mu.
+func (t *fakeTimer) stop() {
+	t.clock.removeWaiter(t.waiter)
+	select {
+	case <-t.waiter.ch:
+	default:
+	}
+}
@@ -0,0 +1,147 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("進めた分だけ現在時刻が進むこと", func(t *testing.T) {
+		// arrange
+		c := clock.NewFakeClock(start)
+
+		// act
+		c.Advance(90 * time.Second)
+
+		// assert
+		if got := c.Now(); !got.Equal(start.Add(90 * time.Second)) {
+			t.Errorf("Expected %v, got: %v", start.Add(90*time.Second), got)
+		}
+	})
+
+	t.Run("Afterは期限まで進めたときだけ発火すること", func(t *testing.T) {
+		// arrange
+		c := clock.NewFakeClock(start)
+		ch := c.After(5 * time.Second)
+
+		// act
+		c.Advance(4 * time.Second)
+		firedEarly := len(ch) != 0
+		c.Advance(time.Second)
+
+		// assert
+		if firedEarly {
+			t.Error("Expected After not to fire before its deadline")
+		}
+		select {
+		case at := <-ch:
+			if !at.Equal(start.Add(5 * time.Second)) {
+				t.Errorf("Expected to fire at %v, got: %v", start.Add(5*time.Second), at)
+			}
+		default:
+			t.Error("Expected After to fire at its deadline")
+		}
+	})
+
+	t.Run("Tickerは間隔ごとに発火し停止後は発火しないこと", func(t *testing.T) {
+		// arrange
+		c := clock.NewFakeClock(start)
+		ticker := c.NewTicker(time.Second)
+
+		// act
+		var ticks []time.Time
+		for i := 0; i < 3; i++ {
+			c.Advance(time.Second)
+			ticks = append(ticks, <-ticker.C())
+		}
+		ticker.Stop()
+		c.Advance(time.Second)
+
+		// assert
+		for i, at := range ticks {
+			if want := start.Add(time.Duration(i+1) * time.Second); !at.Equal(want) {
+				t.Errorf("Expected tick %d at %v, got: %v", i, want, at)
+			}
+		}
+		if len(ticker.C()) != 0 {
+			t.Error("Expected no tick after stopping")
+		}
+	})
+
+	t.Run("Timerは停止すると発火せず再設定した期限で発火すること", func(t *testing.T) {
+		// arrange
+		c := clock.NewFakeClock(start)
+		timer := c.NewTimer(time.Second)
+
+		// act
+		timer.Stop()
+		c.Advance(time.Second)
+		firedAfterStop := len(timer.C()) != 0
+		timer.Reset(2 * time.Second)
+		c.Advance(2 * time.Second)
+
+		// assert
+		if firedAfterStop {
+			t.Error("Expected no tick after stopping")
+		}
+		select {
+		case at := <-timer.C():
+			if want := start.Add(3 * time.Second); !at.Equal(want) {
+				t.Errorf("Expected to fire at %v, got: %v", want, at)
+			}
+		default:
+			t.Error("Expected the timer to fire at its new deadline")
+		}
+	})
+
+	t.Run("AfterFuncは期限まで進めたときに関数を呼び停止後は呼ばないこと", func(t *testing.T) {
+		// arrange
+		c := clock.NewFakeClock(start)
+		called := make(chan string, 2)
+		c.AfterFunc(time.Second, func() { called <- "fired" })
+		stopped := c.AfterFunc(time.Second, func() { called <- "stopped" })
+
+		// act
+		stopped.Stop()
+		c.Advance(time.Second)
+
+		// assert
+		select {
+		case got := <-called:
+			if got != "fired" {
+				t.Errorf("Expected only the running timer to call its function, got: %s", got)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected the function to be called")
+		}
+		select {
+		case got := <-called:
+			t.Errorf("Expected the stopped timer not to call its function, got: %s", got)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("BlockUntilは待機中のタイマーが揃うまで待つこと", func(t *testing.T) {
+		// arrange
+		c := clock.NewFakeClock(start)
+		done := make(chan struct{})
+		go func() {
+			c.BlockUntil(1)
+			close(done)
+		}()
+
+		// act
+		c.After(time.Second)
+
+		// assert
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Expected BlockUntil to return once a timer is pending")
+		}
+	})
+}
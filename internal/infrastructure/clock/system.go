@@ -0,0 +1,71 @@
+package clock
+
+import (
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+)
+
+// SystemClock is an implementation of clock.Clock backed by the real time
+type SystemClock struct{}
+
+// NewSystemClock creates a new SystemClock
+func NewSystemClock() SystemClock {
+	return SystemClock{}
+}
+
+// Now returns the current time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After sends the current time once d has elapsed
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// NewTicker returns a ticker that sends the current time every d
+func (SystemClock) NewTicker(d time.Duration) clock.Ticker {
+	return systemTicker{ticker: time.NewTicker(d)}
+}
+
+// systemTicker adapts time.Ticker to clock.Ticker
+type systemTicker struct {
+	ticker *time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t systemTicker) Stop() {
+	t.ticker.Stop()
+}
+
+// NewTimer returns a timer that sends the current time once d has elapsed
+func (SystemClock) NewTimer(d time.Duration) clock.Timer {
+	return systemTimer{timer: time.NewTimer(d)}
+}
+
+// AfterFunc calls f in its own goroutine once d has elapsed
+func (SystemClock) AfterFunc(d time.Duration, f func()) clock.Timer {
+	return systemTimer{timer: time.AfterFunc(d, f)}
+}
+
+// systemTimer adapts time.Timer to clock.Timer.
+// Since Go 1.23, Stop and Reset discard a tick that has not been received.
+type systemTimer struct {
+	timer *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t systemTimer) Stop() {
+	t.timer.Stop()
+}
+
+func (t systemTimer) Reset(d time.Duration) {
+	t.timer.Reset(d)
+}
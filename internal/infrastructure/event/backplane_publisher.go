@@ -0,0 +1,134 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/backplane"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
+)
+
+// eventTopicPrefix prefixes the backplane topics of domain events
+const eventTopicPrefix = "event/"
+
+// eventFactories rebuilds the events relayed from other instances
+var eventFactories = map[string]func(roomID string, occurredAt time.Time) event.Event{
+	"GameStarted": func(roomID string, occurredAt time.Time) event.Event {
+		return event.NewGameStartedEvent(roomID, occurredAt)
+	},
+	"DiscussionStarted": func(roomID string, occurredAt time.Time) event.Event {
+		return event.NewDiscussionStartedEvent(roomID, occurredAt)
+	},
+	"DiscussionSkipped": func(roomID string, occurredAt time.Time) event.Event {
+		return event.NewDiscussionSkippedEvent(roomID, occurredAt)
+	},
+	"AnswerSubmitted": func(roomID string, occurredAt time.Time) event.Event {
+		return event.NewAnswerSubmittedEvent(roomID, occurredAt)
+	},
+	"GameFinished": func(roomID string, occurredAt time.Time) event.Event {
+		return event.NewGameFinishedEvent(roomID, occurredAt)
+	},
+	"TopicAutoSelected": func(roomID string, occurredAt time.Time) event.Event {
+		return event.NewTopicAutoSelectedEvent(roomID, occurredAt)
+	},
+	"AnswerTimedOut": func(roomID string, occurredAt time.Time) event.Event {
+		return event.NewAnswerTimedOutEvent(roomID, occurredAt)
+	},
+	"GameAutoFinished": func(roomID string, occurredAt time.Time) event.Event {
+		return event.NewGameAutoFinishedEvent(roomID, occurredAt)
+	},
+	"RoomDeleted": func(roomID string, occurredAt time.Time) event.Event {
+		return event.NewRoomDeletedEvent(roomID, occurredAt)
+	},
+}
+
+// relayedEvent is the backplane payload of a domain event
+type relayedEvent struct {
+	Origin      string    `json:"origin"`
+	Type        string    `json:"type"`
+	AggregateID string    `json:"aggregate_id"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// BackplaneEventPublisher is an implementation of event.Publisher that relays
+// events to the other API instances through a backplane.
+// Handlers registered with Subscribe run only where the event was published,
+// so their side effects happen once; SubscribeAll handlers run on every instance.
+type BackplaneEventPublisher struct {
+	instanceID string
+	backplane  backplane.Backplane
+	origin     *InMemoryEventPublisher
+	all        *InMemoryEventPublisher
+}
+
+// NewBackplaneEventPublisher creates a new BackplaneEventPublisher
+func NewBackplaneEventPublisher(bp backplane.Backplane) *BackplaneEventPublisher {
+	p := &BackplaneEventPublisher{
+		instanceID: uuid.NewString(),
+		backplane:  bp,
+		origin:     NewInMemoryEventPublisher(),
+		all:        NewInMemoryEventPublisher(),
+	}
+	bp.Subscribe(eventTopicPrefix, p.receive)
+	return p
+}
+
+// Publish runs the local handlers of the event and relays it to the other instances
+func (p *BackplaneEventPublisher) Publish(evt event.Event) {
+	p.origin.Publish(evt)
+	p.all.Publish(evt)
+
+	data, err := json.Marshal(relayedEvent{
+		Origin:      p.instanceID,
+		Type:        evt.EventType(),
+		AggregateID: evt.AggregateID(),
+		OccurredAt:  evt.OccurredAt(),
+	})
+	if err != nil {
+		log.Printf("Error marshaling event %s: %v", evt.EventType(), err)
+		return
+	}
+	if err := p.backplane.Publish(context.Background(), eventTopicPrefix+evt.EventType(), data); err != nil {
+		log.Printf("Error relaying event %s: %v", evt.EventType(), err)
+	}
+}
+
+// Subscribe subscribes a handler to events published on this instance
+func (p *BackplaneEventPublisher) Subscribe(eventType string, handler event.EventHandler) {
+	p.origin.Subscribe(eventType, handler)
+}
+
+// SubscribeAll subscribes a handler to events published on any instance
+func (p *BackplaneEventPublisher) SubscribeAll(eventType string, handler event.EventHandler) {
+	p.all.Subscribe(eventType, handler)
+}
+
+// Drain waits until the handlers of the events received so far have returned
+func (p *BackplaneEventPublisher) Drain(ctx context.Context) error {
+	if err := p.origin.Drain(ctx); err != nil {
+		return err
+	}
+	return p.all.Drain(ctx)
+}
+
+// receive dispatches an event relayed from another instance
+func (p *BackplaneEventPublisher) receive(envelope backplane.Envelope) {
+	var relayed relayedEvent
+	if err := json.Unmarshal(envelope.Payload, &relayed); err != nil {
+		log.Printf("Error decoding relayed event: %v", err)
+		return
+	}
+	if relayed.Origin == p.instanceID {
+		return
+	}
+
+	factory, ok := eventFactories[relayed.Type]
+	if !ok {
+		log.Printf("Unknown relayed event type: %s", relayed.Type)
+		return
+	}
+	p.all.Publish(factory(relayed.AggregateID, relayed.OccurredAt))
+}
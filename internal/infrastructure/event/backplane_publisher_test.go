@@ -0,0 +1,122 @@
+package event_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
+	infrastructureBackplane "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/backplane"
+	infrastructureEvent "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/event"
+)
+
+func TestBackplaneEventPublisher(t *testing.T) {
+	occurredAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// newInstances creates two publishers sharing a backplane, as two API instances do
+	newInstances := func() (*infrastructureEvent.BackplaneEventPublisher, *infrastructureEvent.BackplaneEventPublisher) {
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		return infrastructureEvent.NewBackplaneEventPublisher(bp), infrastructureEvent.NewBackplaneEventPublisher(bp)
+	}
+
+	// recorder collects the events its handler receives
+	type recorder struct {
+		mu     sync.Mutex
+		events []event.Event
+	}
+	record := func(r *recorder) event.EventHandler {
+		return func(evt event.Event) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.events = append(r.events, evt)
+		}
+	}
+	drain := func(t *testing.T, publishers ...*infrastructureEvent.BackplaneEventPublisher) {
+		t.Helper()
+		for _, p := range publishers {
+			if err := p.Drain(context.Background()); err != nil {
+				t.Fatalf("Failed to drain: %v", err)
+			}
+		}
+	}
+
+	t.Run("自インスタンスが発行したイベントは中継されても二重に処理されないこと", func(t *testing.T) {
+		// arrange
+		instanceA, _ := newInstances()
+		var all recorder
+		instanceA.SubscribeAll("GameStarted", record(&all))
+
+		// act
+		instanceA.Publish(event.NewGameStartedEvent("room-1", occurredAt))
+		drain(t, instanceA)
+
+		// assert
+		if len(all.events) != 1 {
+			t.Errorf("Expected the event to be handled once, got: %d", len(all.events))
+		}
+	})
+
+	t.Run("Subscribeのハンドラは発行したインスタンスでのみ、SubscribeAllのハンドラは全インスタンスで呼ばれること", func(t *testing.T) {
+		// arrange
+		instanceA, instanceB := newInstances()
+		var originA, originB, allB recorder
+		instanceA.Subscribe("GameFinished", record(&originA))
+		instanceB.Subscribe("GameFinished", record(&originB))
+		instanceB.SubscribeAll("GameFinished", record(&allB))
+
+		// act
+		instanceA.Publish(event.NewGameFinishedEvent("room-1", occurredAt))
+		drain(t, instanceA, instanceB)
+
+		// assert
+		if len(originA.events) != 1 {
+			t.Errorf("Expected the publishing instance to handle the event once, got: %d", len(originA.events))
+		}
+		if len(originB.events) != 0 {
+			t.Errorf("Expected Subscribe handlers of other instances not to run, got: %d", len(originB.events))
+		}
+		if len(allB.events) != 1 {
+			t.Errorf("Expected SubscribeAll handlers of other instances to run once, got: %d", len(allB.events))
+		}
+	})
+
+	t.Run("中継されたイベントが元と同じ型・ルーム・発生時刻で復元されること", func(t *testing.T) {
+		events := []event.Event{
+			event.NewGameStartedEvent("room-1", occurredAt),
+			event.NewDiscussionStartedEvent("room-1", occurredAt),
+			event.NewDiscussionSkippedEvent("room-1", occurredAt),
+			event.NewAnswerSubmittedEvent("room-1", occurredAt),
+			event.NewGameFinishedEvent("room-1", occurredAt),
+			event.NewTopicAutoSelectedEvent("room-1", occurredAt),
+			event.NewAnswerTimedOutEvent("room-1", occurredAt),
+			event.NewGameAutoFinishedEvent("room-1", occurredAt),
+			event.NewRoomDeletedEvent("room-1", occurredAt),
+		}
+		for _, want := range events {
+			t.Run(want.EventType(), func(t *testing.T) {
+				// arrange
+				instanceA, instanceB := newInstances()
+				var allB recorder
+				instanceB.SubscribeAll(want.EventType(), record(&allB))
+
+				// act
+				instanceA.Publish(want)
+				drain(t, instanceB)
+
+				// assert
+				if len(allB.events) != 1 {
+					t.Fatalf("Expected 1 relayed event, got: %d", len(allB.events))
+				}
+				got := allB.events[0]
+				if fmt.Sprintf("%T", got) != fmt.Sprintf("%T", want) {
+					t.Errorf("Expected %T, got: %T", want, got)
+				}
+				if got.AggregateID() != want.AggregateID() || !got.OccurredAt().Equal(want.OccurredAt()) {
+					t.Errorf("Expected %s at %v, got: %s at %v", want.AggregateID(), want.OccurredAt(), got.AggregateID(), got.OccurredAt())
+				}
+			})
+		}
+	})
+}
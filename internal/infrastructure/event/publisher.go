@@ -1,6 +1,7 @@
 package event
 
 import (
+	"context"
 	"log"
 	"sync"
 
@@ -11,6 +12,7 @@ import (
 type InMemoryEventPublisher struct {
 	handlers map[string][]event.EventHandler
 	mu       sync.RWMutex
+	inFlight sync.WaitGroup // handlers still running
 }
 
 // NewInMemoryEventPublisher creates a new InMemoryEventPublisher
@@ -32,7 +34,9 @@ func (p *InMemoryEventPublisher) Publish(evt event.Event) {
 
 	// Execute handlers asynchronously
 	for _, handler := range handlers {
+		p.inFlight.Add(1)
 		go func(h event.EventHandler, e event.Event) {
+			defer p.inFlight.Done()
 			defer func() {
 				if r := recover(); r != nil {
 					log.Printf("Event handler panic: %v", r)
@@ -50,3 +54,25 @@ func (p *InMemoryEventPublisher) Subscribe(eventType string, handler event.Event
 
 	p.handlers[eventType] = append(p.handlers[eventType], handler)
 }
+
+// SubscribeAll subscribes a handler to a specific event type.
+// With a single instance it is the same as Subscribe.
+func (p *InMemoryEventPublisher) SubscribeAll(eventType string, handler event.EventHandler) {
+	p.Subscribe(eventType, handler)
+}
+
+// Drain waits until the handlers of the events published so far have returned
+func (p *InMemoryEventPublisher) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		p.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
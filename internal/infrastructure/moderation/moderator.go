@@ -0,0 +1,132 @@
+package moderation
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
+)
+
+// maskRune replaces each character of a masked word
+const maskRune = '*'
+
+// Config represents moderation configuration
+type Config struct {
+	// NGWords lists words that must not appear in user-supplied text
+	NGWords []string
+	// Mask replaces NG words with asterisks instead of rejecting the input
+	Mask bool
+	// MaxLengths limits the number of characters per field. Zero means no limit.
+	MaxLengths map[moderation.Field]int
+}
+
+// NGWordModerator is an NG-word list based implementation of moderation.Moderator
+type NGWordModerator struct {
+	ngWords    [][]rune
+	mask       bool
+	maxLengths map[moderation.Field]int
+}
+
+// NewNGWordModerator creates a new NGWordModerator
+func NewNGWordModerator(cfg Config) *NGWordModerator {
+	ngWords := make([][]rune, 0, len(cfg.NGWords))
+	for _, word := range cfg.NGWords {
+		normalized := normalize(sanitize(word))
+		if len(normalized.runes) == 0 {
+			// Separators are dropped from the text before matching, so such a word could never match
+			log.Printf("Ignoring NG word %q: it consists only of spaces, punctuation or symbols", word)
+			continue
+		}
+		ngWords = append(ngWords, normalized.runes)
+	}
+
+	return &NGWordModerator{
+		ngWords:    ngWords,
+		mask:       cfg.Mask,
+		maxLengths: cfg.MaxLengths,
+	}
+}
+
+// Moderate sanitizes the value, enforces the field length limit and checks for NG words
+func (m *NGWordModerator) Moderate(field moderation.Field, value string) (string, error) {
+	sanitized := sanitize(value)
+
+	if maxLength := m.maxLengths[field]; maxLength > 0 && utf8.RuneCountInString(sanitized) > maxLength {
+		return "", fmt.Errorf("%s: %w (max %d characters)", field, moderation.ErrContentTooLong, maxLength)
+	}
+
+	matches := m.findNGWords(sanitized)
+	if len(matches) == 0 {
+		return sanitized, nil
+	}
+
+	if !m.mask {
+		return "", fmt.Errorf("%s: %w", field, moderation.ErrInappropriateContent)
+	}
+
+	return maskRanges(sanitized, matches), nil
+}
+
+// runeRange is an inclusive range of rune indexes in the original text
+type runeRange struct {
+	start int
+	end   int
+}
+
+// findNGWords returns the ranges of the original text covered by NG words.
+// A match split by separators must cover whole words, so that "ば・か" matches
+// but the end of one word and the start of the next do not.
+func (m *NGWordModerator) findNGWords(value string) []runeRange {
+	if len(m.ngWords) == 0 {
+		return nil
+	}
+
+	text := normalize(value)
+
+	var matches []runeRange
+	for _, word := range m.ngWords {
+		for i := 0; i+len(word) <= len(text.runes); i++ {
+			end := i + len(word)
+			if !equalRunes(text.runes[i:end], word) {
+				continue
+			}
+			if text.spansSeparator(i, end) && (!text.isWordBoundary(i) || !text.isWordBoundary(end)) {
+				continue
+			}
+			matches = append(matches, runeRange{
+				start: text.origin[i],
+				end:   text.origin[end-1],
+			})
+		}
+	}
+
+	return matches
+}
+
+// maskRanges replaces the characters in the given ranges with maskRune, keeping spaces
+func maskRanges(value string, ranges []runeRange) string {
+	runes := []rune(value)
+	for _, r := range ranges {
+		for i := r.start; i <= r.end && i < len(runes); i++ {
+			if !unicode.IsSpace(runes[i]) {
+				runes[i] = maskRune
+			}
+		}
+	}
+	return strings.TrimSpace(string(runes))
+}
+
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,155 @@
+package moderation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
+	infrastructureModeration "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/moderation"
+)
+
+func TestNGWordModeratorModerate(t *testing.T) {
+	newModerator := func(mask bool) *infrastructureModeration.NGWordModerator {
+		return infrastructureModeration.NewNGWordModerator(infrastructureModeration.Config{
+			NGWords: []string{"ばか", "BAD", "高慢"},
+			Mask:    mask,
+			MaxLengths: map[moderation.Field]int{
+				moderation.FieldUserName: 10,
+			},
+		})
+	}
+
+	rejected := []struct {
+		name  string
+		value string
+	}{
+		{name: "ひらがなのNGワードが拒否されること", value: "ばかです"},
+		{name: "カタカナのNGワードが拒否されること", value: "バカです"},
+		{name: "半角カタカナのNGワードが拒否されること", value: "ﾊﾞｶです"},
+		{name: "区切り文字を挟んだNGワードが拒否されること", value: "ば・か"},
+		{name: "全角英字のNGワードが大文字小文字を無視して拒否されること", value: "ｂａｄ boy"},
+		{name: "異体字のNGワードが拒否されること", value: "髙慢な人"},
+		{name: "1文字ずつ空白で区切ったNGワードが拒否されること", value: "b a d"},
+	}
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			m := newModerator(false)
+
+			// act
+			_, err := m.Moderate(moderation.FieldTopic, tt.value)
+
+			// assert
+			if !errors.Is(err, moderation.ErrInappropriateContent) {
+				t.Errorf("Expected ErrInappropriateContent for %q, got: %v", tt.value, err)
+			}
+			if moderation.ErrorCode(err) != moderation.CodeInappropriateContent {
+				t.Errorf("Expected code %s, got: %s", moderation.CodeInappropriateContent, moderation.ErrorCode(err))
+			}
+		})
+	}
+
+	accepted := []struct {
+		name  string
+		value string
+	}{
+		{name: "読点をまたいだ単語の末尾と先頭はNGワードとみなされないこと", value: "そば、かつ丼"},
+		{name: "空白をまたいだ単語の末尾と先頭はNGワードとみなされないこと", value: "tab add"},
+	}
+	for _, tt := range accepted {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			m := newModerator(false)
+
+			// act
+			got, err := m.Moderate(moderation.FieldTopic, tt.value)
+
+			// assert
+			if err != nil {
+				t.Fatalf("Expected no error for %q, got: %v", tt.value, err)
+			}
+			if got != tt.value {
+				t.Errorf("Expected %q, got: %q", tt.value, got)
+			}
+		})
+	}
+
+	t.Run("記号だけのNGワードは無視されること", func(t *testing.T) {
+		// arrange
+		m := infrastructureModeration.NewNGWordModerator(infrastructureModeration.Config{
+			NGWords: []string{"!!!"},
+		})
+
+		// act
+		got, err := m.Moderate(moderation.FieldTopic, "夏祭り!!!")
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if got != "夏祭り!!!" {
+			t.Errorf("Expected '夏祭り!!!', got: %q", got)
+		}
+	})
+
+	t.Run("NGワードを含まない場合はそのまま返されること", func(t *testing.T) {
+		// arrange
+		m := newModerator(false)
+
+		// act
+		got, err := m.Moderate(moderation.FieldTopic, "夏祭り")
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if got != "夏祭り" {
+			t.Errorf("Expected '夏祭り', got: %q", got)
+		}
+	})
+
+	t.Run("マスクモードの場合はNGワードがマスクされること", func(t *testing.T) {
+		// arrange
+		m := newModerator(true)
+
+		// act
+		got, err := m.Moderate(moderation.FieldTopic, "あいつはﾊﾞｶだ")
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if got != "あいつは***だ" {
+			t.Errorf("Expected 'あいつは***だ', got: %q", got)
+		}
+	})
+
+	t.Run("制御文字とゼロ幅文字が除去されること", func(t *testing.T) {
+		// arrange
+		m := newModerator(false)
+
+		// act
+		got, err := m.Moderate(moderation.FieldUserName, " たろ\u200bう\x07 ")
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if got != "たろう" {
+			t.Errorf("Expected 'たろう', got: %q", got)
+		}
+	})
+
+	t.Run("最大文字数を超える場合はエラーが返されること", func(t *testing.T) {
+		// arrange
+		m := newModerator(false)
+
+		// act
+		_, err := m.Moderate(moderation.FieldUserName, "あいうえおかきくけこさ")
+
+		// assert
+		if !errors.Is(err, moderation.ErrContentTooLong) {
+			t.Errorf("Expected ErrContentTooLong, got: %v", err)
+		}
+	})
+}
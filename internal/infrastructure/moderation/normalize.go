@@ -0,0 +1,134 @@
+package moderation
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	combiningVoicedMark     = '\u3099'
+	combiningSemiVoicedMark = '\u309A'
+	zeroWidthJoiner         = '\u200D'
+)
+
+// kanjiVariants folds common variant (itaiji) and old-style kanji to their standard forms
+var kanjiVariants = map[rune]rune{
+	'髙': '高',
+	'﨑': '崎',
+	'嵜': '崎',
+	'濵': '浜',
+	'濱': '浜',
+	'邊': '辺',
+	'邉': '辺',
+	'齋': '斎',
+	'齊': '斉',
+	'澤': '沢',
+	'櫻': '桜',
+	'國': '国',
+	'學': '学',
+	'體': '体',
+	'氣': '気',
+	'廣': '広',
+	'眞': '真',
+	'龍': '竜',
+	'惡': '悪',
+}
+
+// normalizedText is a folded rune stream where each rune remembers the
+// index of the original rune it was derived from, and whether separators
+// were dropped right before it
+type normalizedText struct {
+	runes     []rune
+	origin    []int
+	separated []bool
+}
+
+// isWordBoundary reports whether the original text was split by separators
+// (or starts or ends) right before the rune at index i
+func (t normalizedText) isWordBoundary(i int) bool {
+	return i == 0 || i == len(t.runes) || t.separated[i]
+}
+
+// spansSeparator reports whether separators were dropped between the runes in [start, end)
+func (t normalizedText) spansSeparator(start, end int) bool {
+	for i := start + 1; i < end; i++ {
+		if t.separated[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitize removes control and invisible formatting characters and trims surrounding spaces.
+// Zero width joiners are kept so that composed emoji stay intact.
+func sanitize(value string) string {
+	stripped := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		if unicode.Is(unicode.Cf, r) && r != zeroWidthJoiner {
+			return -1
+		}
+		return r
+	}, value)
+	return strings.TrimSpace(stripped)
+}
+
+// normalize folds width, case, katakana/hiragana and kanji variants so that
+// visually or phonetically equivalent spellings compare equal. Separators
+// (spaces, punctuation and symbols) are dropped so they cannot be used to
+// split a word.
+func normalize(value string) normalizedText {
+	var text normalizedText
+	separated := false
+
+	for i, r := range []rune(value) {
+		for _, folded := range norm.NFKC.String(string(r)) {
+			folded = foldRune(folded)
+
+			if folded == combiningVoicedMark || folded == combiningSemiVoicedMark {
+				if n := len(text.runes); n > 0 {
+					composed := []rune(norm.NFC.String(string([]rune{text.runes[n-1], folded})))
+					if len(composed) == 1 {
+						text.runes[n-1] = composed[0]
+					}
+				}
+				continue
+			}
+
+			if isSeparator(folded) {
+				separated = len(text.runes) > 0
+				continue
+			}
+
+			text.runes = append(text.runes, folded)
+			text.origin = append(text.origin, i)
+			text.separated = append(text.separated, separated)
+			separated = false
+		}
+	}
+
+	return text
+}
+
+// foldRune folds a single NFKC-normalized rune
+func foldRune(r rune) rune {
+	r = unicode.ToLower(r)
+
+	// Katakana -> Hiragana
+	if r >= 'ァ' && r <= 'ヶ' {
+		r -= 'ァ' - 'ぁ'
+	}
+
+	if standard, ok := kanjiVariants[r]; ok {
+		r = standard
+	}
+
+	return r
+}
+
+func isSeparator(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsControl(r) || unicode.Is(unicode.Cf, r)
+}
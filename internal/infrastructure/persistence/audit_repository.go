@@ -0,0 +1,97 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+)
+
+// AuditRepository implements the audit.Repository interface
+type AuditRepository struct {
+	db *sql.DB
+}
+
+// NewAuditRepository creates a new AuditRepository
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Save persists an audit log
+func (r *AuditRepository) Save(ctx context.Context, l *audit.AuditLog) error {
+	query := `
+		INSERT INTO audit_logs (id, room_id, actor_type, actor_id, action, before_status, after_status, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		l.ID().String(),
+		l.RoomID().String(),
+		l.Actor().Type().String(),
+		l.Actor().ID(),
+		l.Action().String(),
+		nullString(l.BeforeStatus()),
+		nullString(l.AfterStatus()),
+		l.OccurredAt(),
+	)
+
+	return err
+}
+
+// FindByRoomID retrieves all audit logs of a room in chronological order
+func (r *AuditRepository) FindByRoomID(ctx context.Context, roomID audit.RoomID) ([]*audit.AuditLog, error) {
+	query := `
+		SELECT id, room_id, actor_type, actor_id, action, before_status, after_status, occurred_at
+		FROM audit_logs
+		WHERE room_id = $1
+		ORDER BY occurred_at ASC
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, roomID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []*audit.AuditLog
+	for rows.Next() {
+		var (
+			id           string
+			roomIDStr    string
+			actorType    string
+			actorID      string
+			action       string
+			beforeStatus sql.NullString
+			afterStatus  sql.NullString
+			occurredAt   time.Time
+		)
+
+		if err := rows.Scan(&id, &roomIDStr, &actorType, &actorID, &action, &beforeStatus, &afterStatus, &occurredAt); err != nil {
+			return nil, err
+		}
+
+		logID, _ := audit.NewAuditLogIDFromString(id)
+		logRoomID, _ := audit.NewRoomIDFromString(roomIDStr)
+		logActorType, _ := audit.NewActorTypeFromString(actorType)
+
+		logs = append(logs, audit.NewAuditLog(
+			logID,
+			logRoomID,
+			audit.NewActor(logActorType, actorID),
+			audit.Action(action),
+			beforeStatus.String,
+			afterStatus.String,
+			occurredAt,
+		))
+	}
+
+	return logs, rows.Err()
+}
+
+// nullString converts an empty string to a SQL NULL
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
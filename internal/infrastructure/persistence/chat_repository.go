@@ -0,0 +1,122 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/chat"
+)
+
+// ChatRepository implements the chat.Repository interface
+type ChatRepository struct {
+	db *sql.DB
+}
+
+// NewChatRepository creates a new ChatRepository
+func NewChatRepository(db *sql.DB) *ChatRepository {
+	return &ChatRepository{db: db}
+}
+
+// Save persists a chat message
+func (r *ChatRepository) Save(ctx context.Context, m *chat.Message) error {
+	query := `
+		INSERT INTO chat_messages (id, room_id, user_id, body, created_at, deleted_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE
+		SET deleted_at = EXCLUDED.deleted_at
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		m.ID().String(),
+		m.RoomID().String(),
+		m.UserID().String(),
+		m.Body().String(),
+		m.CreatedAt(),
+		m.DeletedAt(),
+	)
+
+	return err
+}
+
+// FindByID retrieves a chat message by ID
+func (r *ChatRepository) FindByID(ctx context.Context, id chat.MessageID) (*chat.Message, error) {
+	query := `
+		SELECT id, room_id, user_id, body, created_at, deleted_at
+		FROM chat_messages
+		WHERE id = $1
+	`
+
+	m, err := scanChatMessage(conn(ctx, r.db).QueryRowContext(ctx, query, id.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, chat.ErrMessageNotFound
+		}
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// FindRecentByRoomID retrieves up to limit of the latest non-deleted messages in a room, oldest first
+func (r *ChatRepository) FindRecentByRoomID(ctx context.Context, roomID chat.RoomID, limit int) ([]*chat.Message, error) {
+	query := `
+		SELECT id, room_id, user_id, body, created_at, deleted_at
+		FROM (
+			SELECT id, room_id, user_id, body, created_at, deleted_at
+			FROM chat_messages
+			WHERE room_id = $1 AND deleted_at IS NULL
+			ORDER BY created_at DESC
+			LIMIT $2
+		) recent
+		ORDER BY created_at ASC
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, roomID.String(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []*chat.Message{}
+	for rows.Next() {
+		m, err := scanChatMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+
+	return messages, rows.Err()
+}
+
+// scanChatMessage reconstructs a chat message from a single result row
+func scanChatMessage(row rowScanner) (*chat.Message, error) {
+	var (
+		id        string
+		roomID    string
+		userID    string
+		body      string
+		createdAt time.Time
+		deletedAt sql.NullTime
+	)
+
+	if err := row.Scan(&id, &roomID, &userID, &body, &createdAt, &deletedAt); err != nil {
+		return nil, err
+	}
+
+	messageID, _ := chat.NewMessageIDFromString(id)
+	messageRoomID, _ := chat.NewRoomIDFromString(roomID)
+	messageUserID, _ := chat.NewUserIDFromString(userID)
+	messageBody, _ := chat.NewBody(body)
+
+	m := chat.NewMessage(messageID, messageRoomID, messageUserID, messageBody, createdAt)
+	if deletedAt.Valid {
+		m.SetDeletedAt(&deletedAt.Time)
+	}
+
+	return m, nil
+}
@@ -17,9 +17,9 @@ type Config struct {
 	SSLMode  string
 }
 
-// NewDB creates a new database connection
-func NewDB(cfg Config) (*sql.DB, error) {
-	dsn := fmt.Sprintf(
+// DSN returns the connection string for lib/pq
+func (cfg Config) DSN() string {
+	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host,
 		cfg.Port,
@@ -28,8 +28,11 @@ func NewDB(cfg Config) (*sql.DB, error) {
 		cfg.DBName,
 		cfg.SSLMode,
 	)
+}
 
-	db, err := sql.Open("postgres", dsn)
+// NewDB creates a new database connection
+func NewDB(cfg Config) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.DSN())
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
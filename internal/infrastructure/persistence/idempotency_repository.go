@@ -0,0 +1,138 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/idempotency"
+)
+
+// IdempotencyRepository implements the idempotency.Repository interface
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository creates a new IdempotencyRepository
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Create stores a new record, replacing an expired record with the same key
+func (r *IdempotencyRepository) Create(ctx context.Context, rec *idempotency.Record) error {
+	query := `
+		INSERT INTO idempotency_keys (key, fingerprint, completed, created_at, expires_at)
+		VALUES ($1, $2, FALSE, $3, $4)
+		ON CONFLICT (key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint,
+			completed = FALSE,
+			status_code = NULL,
+			content_type = NULL,
+			response_body = NULL,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		rec.Key().String(),
+		rec.Fingerprint().String(),
+		rec.CreatedAt(),
+		rec.ExpiresAt(),
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return idempotency.ErrKeyAlreadyExists
+	}
+
+	return nil
+}
+
+// Save updates an existing record
+func (r *IdempotencyRepository) Save(ctx context.Context, rec *idempotency.Record) error {
+	query := `
+		UPDATE idempotency_keys
+		SET completed = $2,
+			status_code = $3,
+			content_type = $4,
+			response_body = $5
+		WHERE key = $1
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		rec.Key().String(),
+		rec.IsCompleted(),
+		rec.StatusCode(),
+		rec.ContentType(),
+		rec.Body(),
+	)
+
+	return err
+}
+
+// FindByKey retrieves a record by key
+func (r *IdempotencyRepository) FindByKey(ctx context.Context, key idempotency.Key) (*idempotency.Record, error) {
+	query := `
+		SELECT key, fingerprint, completed, status_code, content_type, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE key = $1
+	`
+
+	var (
+		keyStr      string
+		fingerprint string
+		completed   bool
+		statusCode  sql.NullInt64
+		contentType sql.NullString
+		body        []byte
+		createdAt   time.Time
+		expiresAt   time.Time
+	)
+
+	err := r.db.QueryRowContext(ctx, query, key.String()).Scan(
+		&keyStr, &fingerprint, &completed, &statusCode, &contentType, &body, &createdAt, &expiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, idempotency.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	return idempotency.RestoreRecord(
+		idempotency.NewKeyFromString(keyStr),
+		idempotency.NewFingerprint(fingerprint),
+		completed,
+		int(statusCode.Int64),
+		contentType.String,
+		body,
+		createdAt,
+		expiresAt,
+	), nil
+}
+
+// Delete removes a record
+func (r *IdempotencyRepository) Delete(ctx context.Context, key idempotency.Key) error {
+	query := `DELETE FROM idempotency_keys WHERE key = $1`
+	_, err := r.db.ExecContext(ctx, query, key.String())
+	return err
+}
+
+// DeleteExpired removes all records that expired before now
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= $1`
+	_, err := r.db.ExecContext(ctx, query, now)
+	return err
+}
@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// LeaseRepository implements the lease.Repository interface
+type LeaseRepository struct {
+	db *sql.DB
+}
+
+// NewLeaseRepository creates a new LeaseRepository
+func NewLeaseRepository(db *sql.DB) *LeaseRepository {
+	return &LeaseRepository{db: db}
+}
+
+// Acquire takes an expired or free lease, or renews a lease held by owner.
+// Expiry is compared with the database clock so instances need not agree on time.
+func (r *LeaseRepository) Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	query := `
+		INSERT INTO leases (name, owner, expires_at)
+		VALUES ($1, $2, NOW() + $3 * INTERVAL '1 millisecond')
+		ON CONFLICT (name) DO UPDATE
+		SET owner = EXCLUDED.owner,
+			expires_at = EXCLUDED.expires_at
+		WHERE leases.owner = EXCLUDED.owner OR leases.expires_at <= NOW()
+		RETURNING owner
+	`
+
+	var holder string
+	err := r.db.QueryRowContext(ctx, query, name, owner, ttl.Milliseconds()).Scan(&holder)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Release deletes the lease if owner holds it
+func (r *LeaseRepository) Release(ctx context.Context, name, owner string) error {
+	query := `DELETE FROM leases WHERE name = $1 AND owner = $2`
+
+	_, err := r.db.ExecContext(ctx, query, name, owner)
+	return err
+}
@@ -0,0 +1,46 @@
+package persistence
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+)
+
+// InMemoryAuditRepository implements the audit.Repository interface with a MemoryStore
+type InMemoryAuditRepository struct {
+	store *MemoryStore
+}
+
+// NewInMemoryAuditRepository creates a new InMemoryAuditRepository
+func NewInMemoryAuditRepository(store *MemoryStore) *InMemoryAuditRepository {
+	return &InMemoryAuditRepository{store: store}
+}
+
+// Save persists an audit log
+func (r *InMemoryAuditRepository) Save(ctx context.Context, l *audit.AuditLog) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	put(ctx, r.store.auditLogs, l.ID().String(), *l)
+	return nil
+}
+
+// FindByRoomID retrieves all audit logs of a room in chronological order
+func (r *InMemoryAuditRepository) FindByRoomID(ctx context.Context, roomID audit.RoomID) ([]*audit.AuditLog, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var logs []*audit.AuditLog
+	for _, stored := range r.store.auditLogs {
+		if stored.RoomID().String() == roomID.String() {
+			found := stored
+			logs = append(logs, &found)
+		}
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		return logs[i].OccurredAt().Before(logs[j].OccurredAt())
+	})
+	return logs, nil
+}
@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/chat"
+)
+
+// InMemoryChatRepository implements the chat.Repository interface with a MemoryStore
+type InMemoryChatRepository struct {
+	store *MemoryStore
+}
+
+// NewInMemoryChatRepository creates a new InMemoryChatRepository
+func NewInMemoryChatRepository(store *MemoryStore) *InMemoryChatRepository {
+	return &InMemoryChatRepository{store: store}
+}
+
+// Save persists a chat message
+func (r *InMemoryChatRepository) Save(ctx context.Context, m *chat.Message) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	put(ctx, r.store.chatMessages, m.ID().String(), *m)
+	return nil
+}
+
+// FindByID retrieves a chat message by ID
+func (r *InMemoryChatRepository) FindByID(ctx context.Context, id chat.MessageID) (*chat.Message, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.chatMessages[id.String()]
+	if !ok {
+		return nil, chat.ErrMessageNotFound
+	}
+	return &stored, nil
+}
+
+// FindRecentByRoomID retrieves up to limit of the latest non-deleted messages in a room, oldest first
+func (r *InMemoryChatRepository) FindRecentByRoomID(ctx context.Context, roomID chat.RoomID, limit int) ([]*chat.Message, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	messages := []*chat.Message{}
+	for _, stored := range r.store.chatMessages {
+		if stored.RoomID().String() == roomID.String() && !stored.IsDeleted() {
+			found := stored
+			messages = append(messages, &found)
+		}
+	}
+
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].CreatedAt().Before(messages[j].CreatedAt())
+	})
+	if limit >= 0 && len(messages) > limit {
+		messages = messages[len(messages)-limit:]
+	}
+	return messages, nil
+}
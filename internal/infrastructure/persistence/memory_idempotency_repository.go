@@ -0,0 +1,89 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/idempotency"
+)
+
+// InMemoryIdempotencyRepository implements the idempotency.Repository interface with a MemoryStore
+type InMemoryIdempotencyRepository struct {
+	store *MemoryStore
+}
+
+// NewInMemoryIdempotencyRepository creates a new InMemoryIdempotencyRepository
+func NewInMemoryIdempotencyRepository(store *MemoryStore) *InMemoryIdempotencyRepository {
+	return &InMemoryIdempotencyRepository{store: store}
+}
+
+// Create stores a new record, replacing an expired record with the same key
+func (r *InMemoryIdempotencyRepository) Create(ctx context.Context, rec *idempotency.Record) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if stored, ok := r.store.idempotency[rec.Key().String()]; ok && !stored.IsExpired(rec.CreatedAt()) {
+		return idempotency.ErrKeyAlreadyExists
+	}
+	r.store.idempotency[rec.Key().String()] = *copyRecord(rec)
+	return nil
+}
+
+// Save updates an existing record
+func (r *InMemoryIdempotencyRepository) Save(ctx context.Context, rec *idempotency.Record) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.idempotency[rec.Key().String()]; ok {
+		r.store.idempotency[rec.Key().String()] = *copyRecord(rec)
+	}
+	return nil
+}
+
+// FindByKey retrieves a record by key
+func (r *InMemoryIdempotencyRepository) FindByKey(ctx context.Context, key idempotency.Key) (*idempotency.Record, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.idempotency[key.String()]
+	if !ok {
+		return nil, idempotency.ErrRecordNotFound
+	}
+	return copyRecord(&stored), nil
+}
+
+// Delete removes a record
+func (r *InMemoryIdempotencyRepository) Delete(ctx context.Context, key idempotency.Key) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.idempotency, key.String())
+	return nil
+}
+
+// DeleteExpired removes all records that expired before now
+func (r *InMemoryIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for key, stored := range r.store.idempotency {
+		if stored.IsExpired(now) {
+			delete(r.store.idempotency, key)
+		}
+	}
+	return nil
+}
+
+// copyRecord copies a record so that the stored response body is not shared
+func copyRecord(rec *idempotency.Record) *idempotency.Record {
+	return idempotency.RestoreRecord(
+		rec.Key(),
+		rec.Fingerprint(),
+		rec.IsCompleted(),
+		rec.StatusCode(),
+		rec.ContentType(),
+		append([]byte(nil), rec.Body()...),
+		rec.CreatedAt(),
+		rec.ExpiresAt(),
+	)
+}
@@ -0,0 +1,44 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+)
+
+// InMemoryLeaseRepository implements the lease.Repository interface with a MemoryStore.
+// Leases only coordinate the goroutines of a single instance.
+type InMemoryLeaseRepository struct {
+	store *MemoryStore
+	clock clock.Clock
+}
+
+// NewInMemoryLeaseRepository creates a new InMemoryLeaseRepository
+func NewInMemoryLeaseRepository(store *MemoryStore, clk clock.Clock) *InMemoryLeaseRepository {
+	return &InMemoryLeaseRepository{store: store, clock: clk}
+}
+
+// Acquire takes an expired or free lease, or renews a lease held by owner
+func (r *InMemoryLeaseRepository) Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := r.clock.Now()
+	if held, ok := r.store.leases[name]; ok && held.owner != owner && now.Before(held.expiresAt) {
+		return false, nil
+	}
+	r.store.leases[name] = memoryLease{owner: owner, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+// Release deletes the lease if owner holds it
+func (r *InMemoryLeaseRepository) Release(ctx context.Context, name, owner string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if held, ok := r.store.leases[name]; ok && held.owner == owner {
+		delete(r.store.leases, name)
+	}
+	return nil
+}
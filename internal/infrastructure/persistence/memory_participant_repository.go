@@ -0,0 +1,115 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+)
+
+// InMemoryParticipantRepository implements the participant.Repository interface with a MemoryStore
+type InMemoryParticipantRepository struct {
+	store *MemoryStore
+}
+
+// NewInMemoryParticipantRepository creates a new InMemoryParticipantRepository
+func NewInMemoryParticipantRepository(store *MemoryStore) *InMemoryParticipantRepository {
+	return &InMemoryParticipantRepository{store: store}
+}
+
+// Save persists a participant
+func (r *InMemoryParticipantRepository) Save(ctx context.Context, p *participant.Participant) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key, stored := p.ID().String(), *p
+	if existingKey, existing, ok := r.store.findParticipantLocked(p.RoomID().String(), p.UserID().String()); ok {
+		// A user joins a room once; only the role and the leader flag are updated, as ON CONFLICT does
+		key = existingKey
+		stored = *participant.NewParticipant(existing.ID(), existing.RoomID(), existing.UserID(), p.Role(), existing.JoinedAt())
+		if p.IsLeader() {
+			stored.SetAsLeader()
+		}
+	}
+	put(ctx, r.store.participants, key, stored)
+	return nil
+}
+
+// FindByID retrieves a participant by ID
+func (r *InMemoryParticipantRepository) FindByID(ctx context.Context, id participant.ParticipantID) (*participant.Participant, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.participants[id.String()]
+	if !ok {
+		return nil, errors.New("participant not found")
+	}
+	return &stored, nil
+}
+
+// FindByRoomID retrieves all participants in a room
+func (r *InMemoryParticipantRepository) FindByRoomID(ctx context.Context, roomID participant.RoomID) ([]*participant.Participant, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var participants []*participant.Participant
+	for _, stored := range r.store.participants {
+		if stored.RoomID().String() == roomID.String() {
+			found := stored
+			participants = append(participants, &found)
+		}
+	}
+
+	sort.Slice(participants, func(i, j int) bool {
+		return participants[i].JoinedAt().Before(participants[j].JoinedAt())
+	})
+	return participants, nil
+}
+
+// FindByRoomAndUser retrieves a specific participant by room and user
+func (r *InMemoryParticipantRepository) FindByRoomAndUser(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	_, stored, ok := r.store.findParticipantLocked(roomID.String(), userID.String())
+	if !ok {
+		return nil, errors.New("participant not found")
+	}
+	return &stored, nil
+}
+
+// Delete removes a participant with their chat messages
+func (r *InMemoryParticipantRepository) Delete(ctx context.Context, roomID participant.RoomID, userID participant.UserID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if key, _, ok := r.store.findParticipantLocked(roomID.String(), userID.String()); ok {
+		r.store.deleteParticipantLocked(ctx, key)
+	}
+	return nil
+}
+
+// findParticipantLocked looks up the participant of a user in a room. The caller holds the lock.
+func (s *MemoryStore) findParticipantLocked(roomID, userID string) (string, participant.Participant, bool) {
+	for key, p := range s.participants {
+		if p.RoomID().String() == roomID && p.UserID().String() == userID {
+			return key, p, true
+		}
+	}
+	return "", participant.Participant{}, false
+}
+
+// deleteParticipantLocked removes a participant and the chat messages they sent. The caller holds the lock.
+func (s *MemoryStore) deleteParticipantLocked(ctx context.Context, key string) {
+	p, ok := s.participants[key]
+	if !ok {
+		return
+	}
+	for messageKey, m := range s.chatMessages {
+		if m.RoomID().String() == p.RoomID().String() && m.UserID().String() == p.UserID().String() {
+			remove(ctx, s.chatMessages, messageKey)
+		}
+	}
+	remove(ctx, s.participants, key)
+}
@@ -0,0 +1,92 @@
+package persistence
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room_emoji"
+)
+
+// InMemoryRoomEmojiRepository implements the room_emoji.Repository interface with a MemoryStore
+type InMemoryRoomEmojiRepository struct {
+	store *MemoryStore
+}
+
+// NewInMemoryRoomEmojiRepository creates a new InMemoryRoomEmojiRepository
+func NewInMemoryRoomEmojiRepository(store *MemoryStore) *InMemoryRoomEmojiRepository {
+	return &InMemoryRoomEmojiRepository{store: store}
+}
+
+// Save persists a room emoji
+func (r *InMemoryRoomEmojiRepository) Save(ctx context.Context, e *room_emoji.RoomEmoji) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	put(ctx, r.store.roomEmojis, e.ID().String(), *e)
+	return nil
+}
+
+// FindByID retrieves a room emoji by ID
+func (r *InMemoryRoomEmojiRepository) FindByID(ctx context.Context, id room_emoji.RoomEmojiID) (*room_emoji.RoomEmoji, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.roomEmojis[id.String()]
+	if !ok {
+		return nil, room_emoji.ErrRoomEmojiNotFound
+	}
+	return &stored, nil
+}
+
+// FindByRoomID retrieves all emojis in a room
+func (r *InMemoryRoomEmojiRepository) FindByRoomID(ctx context.Context, roomID room_emoji.RoomID) ([]*room_emoji.RoomEmoji, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var emojis []*room_emoji.RoomEmoji
+	for _, stored := range r.store.roomEmojis {
+		if stored.RoomID().String() == roomID.String() {
+			found := stored
+			emojis = append(emojis, &found)
+		}
+	}
+	return emojis, nil
+}
+
+// CountByRoomID aggregates the emojis in a room, most frequent first
+func (r *InMemoryRoomEmojiRepository) CountByRoomID(ctx context.Context, roomID room_emoji.RoomID) ([]room_emoji.ReactionCount, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	indexes := make(map[string]int)
+	counts := []room_emoji.ReactionCount{}
+	for _, stored := range r.store.roomEmojis {
+		if stored.RoomID().String() != roomID.String() {
+			continue
+		}
+		value := stored.Emoji().String()
+		if i, ok := indexes[value]; ok {
+			counts[i].Count++
+			continue
+		}
+		indexes[value] = len(counts)
+		counts = append(counts, room_emoji.ReactionCount{Emoji: stored.Emoji(), Count: 1})
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Emoji.String() < counts[j].Emoji.String()
+	})
+	return counts, nil
+}
+
+// Delete removes a room emoji
+func (r *InMemoryRoomEmojiRepository) Delete(ctx context.Context, id room_emoji.RoomEmojiID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	remove(ctx, r.store.roomEmojis, id.String())
+	return nil
+}
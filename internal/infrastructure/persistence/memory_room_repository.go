@@ -0,0 +1,131 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+)
+
+// InMemoryRoomRepository implements the room.Repository interface with a MemoryStore
+type InMemoryRoomRepository struct {
+	store *MemoryStore
+}
+
+// NewInMemoryRoomRepository creates a new InMemoryRoomRepository
+func NewInMemoryRoomRepository(store *MemoryStore) *InMemoryRoomRepository {
+	return &InMemoryRoomRepository{store: store}
+}
+
+// Save persists a room and advances its version.
+// A room that has never been saved is inserted; otherwise the stored room is
+// replaced only if it is still at the version the room was read from.
+func (r *InMemoryRoomRepository) Save(ctx context.Context, rm *room.Room) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.rooms[rm.ID().String()]
+	if (ok && stored.Version() != rm.Version()) || (!ok && rm.Version() != 0) {
+		// Another writer created or saved the room since it was read
+		return room.ErrVersionConflict
+	}
+
+	saved := *rm
+	saved.SetVersion(rm.Version() + 1)
+	put(ctx, r.store.rooms, rm.ID().String(), saved)
+	rm.SetVersion(saved.Version())
+	return nil
+}
+
+// FindByID retrieves a room by ID
+func (r *InMemoryRoomRepository) FindByID(ctx context.Context, id room.RoomID) (*room.Room, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.rooms[id.String()]
+	if !ok {
+		return nil, errors.New("room not found")
+	}
+	return &stored, nil
+}
+
+// FindByCode retrieves a room by code
+func (r *InMemoryRoomRepository) FindByCode(ctx context.Context, code room.RoomCode) (*room.Room, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, stored := range r.store.rooms {
+		if stored.Code().String() == code.String() {
+			return &stored, nil
+		}
+	}
+	return nil, errors.New("room not found")
+}
+
+// Search retrieves rooms matching the criteria with their player counts, newest first
+func (r *InMemoryRoomRepository) Search(ctx context.Context, criteria room.SearchCriteria) ([]room.SearchResult, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	playerCounts := make(map[string]int)
+	for _, p := range r.store.participants {
+		if p.Role() != participant.RoleHost {
+			playerCounts[p.RoomID().String()]++
+		}
+	}
+
+	results := []room.SearchResult{}
+	for _, stored := range r.store.rooms {
+		players := playerCounts[stored.ID().String()]
+		switch {
+		case criteria.Status != nil && stored.Status() != *criteria.Status:
+			continue
+		case criteria.CreatedAfter != nil && stored.CreatedAt().Before(*criteria.CreatedAfter):
+			continue
+		case criteria.CreatedBefore != nil && stored.CreatedAt().After(*criteria.CreatedBefore):
+			continue
+		case criteria.PhaseEndsBefore != nil && !stored.PhaseDeadlinePassed(*criteria.PhaseEndsBefore):
+			continue
+		case criteria.MinPlayers != nil && players < *criteria.MinPlayers:
+			continue
+		case criteria.MaxPlayers != nil && players > *criteria.MaxPlayers:
+			continue
+		}
+		found := stored
+		results = append(results, room.SearchResult{Room: &found, PlayerCount: players})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Room.CreatedAt().After(results[j].Room.CreatedAt())
+	})
+	if criteria.Limit > 0 && len(results) > criteria.Limit {
+		results = results[:criteria.Limit]
+	}
+	return results, nil
+}
+
+// Delete removes a room with its participants and reactions
+func (r *InMemoryRoomRepository) Delete(ctx context.Context, id room.RoomID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.deleteRoomLocked(ctx, id.String())
+	return nil
+}
+
+// deleteRoomLocked removes a room and the rows that reference it. The caller holds the lock.
+func (s *MemoryStore) deleteRoomLocked(ctx context.Context, roomID string) {
+	for key, p := range s.participants {
+		if p.RoomID().String() == roomID {
+			s.deleteParticipantLocked(ctx, key)
+		}
+	}
+	for key, e := range s.roomEmojis {
+		if e.RoomID().String() == roomID {
+			remove(ctx, s.roomEmojis, key)
+		}
+	}
+	remove(ctx, s.rooms, roomID)
+}
@@ -0,0 +1,172 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/chat"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/idempotency"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room_emoji"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/theme"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
+)
+
+// memoryLease is the holder of a lease in a MemoryStore
+type memoryLease struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// MemoryStore holds the tables of the in-memory repositories.
+// It plays the role of *sql.DB for them: repositories sharing a store see each
+// other's rows, so deleting a room also deletes its participants as the
+// foreign keys of the database do.
+type MemoryStore struct {
+	mu           sync.Mutex
+	users        map[string]user.User
+	rooms        map[string]room.Room
+	themes       map[string]theme.Theme
+	participants map[string]participant.Participant
+	roomEmojis   map[string]room_emoji.RoomEmoji
+	auditLogs    map[string]audit.AuditLog
+	chatMessages map[string]chat.Message
+	idempotency  map[string]idempotency.Record
+	leases       map[string]memoryLease
+}
+
+// NewMemoryStore creates a new MemoryStore seeded with the sample themes
+func NewMemoryStore() *MemoryStore {
+	s := &MemoryStore{
+		users:        make(map[string]user.User),
+		rooms:        make(map[string]room.Room),
+		themes:       make(map[string]theme.Theme),
+		participants: make(map[string]participant.Participant),
+		roomEmojis:   make(map[string]room_emoji.RoomEmoji),
+		auditLogs:    make(map[string]audit.AuditLog),
+		chatMessages: make(map[string]chat.Message),
+		idempotency:  make(map[string]idempotency.Record),
+		leases:       make(map[string]memoryLease),
+	}
+	for _, t := range sampleThemes() {
+		s.themes[t.ID().String()] = *t
+	}
+	return s
+}
+
+// sampleThemes returns the themes inserted by the sample theme migrations
+func sampleThemes() []*theme.Theme {
+	samples := []struct {
+		title  string
+		hint   string
+		emojis []string
+	}{
+		{"夏祭り", "日本の伝統的なイベント", []string{"🏮", "👘", "🎆", "🍧", "🐟", "🥁"}},
+		{"コーヒー", "朝の目覚めに最適な飲み物", []string{"☕", "🫘", "🥛", "🌅", "🍰", "🔥"}},
+		{"富士山", "日本で最も高い山", []string{"🗻", "🏔️", "🌅", "🥾", "❄️", "🇯🇵"}},
+		{"ラーメン", "日本の人気麺料理", []string{"🍜", "🥢", "🍥", "🥚", "🐷", "🔥"}},
+		{"サッカー", "11人対11人のスポーツ", []string{"⚽", "🥅", "🏟️", "👟", "🏆", "🟨"}},
+		{"お寿司", "日本の伝統的な料理", []string{"🍣", "🐟", "🍚", "🥢", "🍵", "🦐"}},
+		{"桜", "春に咲く花", []string{"🌸", "🌳", "🍡", "🧺", "🌙", "🐦"}},
+		{"温泉", "日本の伝統的なリラクゼーション", []string{"♨️", "🛁", "🏔️", "🐒", "🧖", "🍶"}},
+		{"花火", "夏の夜の楽しみ", []string{"🎆", "🎇", "🌙", "👘", "💥", "🌊"}},
+		{"アニメ", "日本の人気文化", []string{"📺", "🎌", "🤖", "✨", "📚", "🎤"}},
+	}
+
+	themes := make([]*theme.Theme, 0, len(samples))
+	for _, sample := range samples {
+		title, _ := theme.NewThemeTitle(sample.title)
+		t := theme.NewTheme(theme.NewThemeID(), title, theme.NewHint(sample.hint))
+		t.SetEmojiPreset(sample.emojis)
+		themes = append(themes, t)
+	}
+	return themes
+}
+
+// memoryTxKey is the context key of the ambient in-memory transaction
+type memoryTxKey struct{}
+
+// memoryTx records how to undo the writes made in a unit of work
+type memoryTx struct {
+	undo []func()
+}
+
+// put stores value under key, recording how to restore the previous row
+// when the transaction of the context rolls back. The caller holds the store lock.
+func put[K comparable, V any](ctx context.Context, table map[K]V, key K, value V) {
+	prev, existed := table[key]
+	table[key] = value
+	recordUndo(ctx, func() {
+		if existed {
+			table[key] = prev
+		} else {
+			delete(table, key)
+		}
+	})
+}
+
+// remove deletes the row under key, recording how to restore it
+// when the transaction of the context rolls back. The caller holds the store lock.
+func remove[K comparable, V any](ctx context.Context, table map[K]V, key K) {
+	prev, existed := table[key]
+	if !existed {
+		return
+	}
+	delete(table, key)
+	recordUndo(ctx, func() {
+		table[key] = prev
+	})
+}
+
+// recordUndo adds undo to the transaction of the context, if any
+func recordUndo(ctx context.Context, undo func()) {
+	if tx, ok := ctx.Value(memoryTxKey{}).(*memoryTx); ok {
+		tx.undo = append(tx.undo, undo)
+	}
+}
+
+// InMemoryTxManager implements the transaction.Manager interface for a MemoryStore.
+// Writes are visible to other callers before commit; only atomicity is provided.
+type InMemoryTxManager struct {
+	store *MemoryStore
+}
+
+// NewInMemoryTxManager creates a new InMemoryTxManager
+func NewInMemoryTxManager(store *MemoryStore) *InMemoryTxManager {
+	return &InMemoryTxManager{store: store}
+}
+
+// Do runs fn in a transaction carried by the context.
+// The writes of fn are undone when it returns an error or panics.
+func (m *InMemoryTxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(memoryTxKey{}).(*memoryTx); ok {
+		return fn(ctx)
+	}
+
+	tx := &memoryTx{}
+	defer func() {
+		if p := recover(); p != nil {
+			m.rollback(tx)
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, memoryTxKey{}, tx)); err != nil {
+		m.rollback(tx)
+		return err
+	}
+	return nil
+}
+
+// rollback undoes the writes of tx, latest first
+func (m *InMemoryTxManager) rollback(tx *memoryTx) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i]()
+	}
+}
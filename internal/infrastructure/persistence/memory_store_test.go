@@ -0,0 +1,238 @@
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testNow := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newRoom := func() *room.Room {
+		themeID, _ := room.NewThemeIDFromString("550e8400-e29b-41d4-a716-446655440000")
+		host, _ := room.NewHostUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
+		return room.NewRoom(room.NewRoomID(), room.NewRoomCode(), themeID, host, testNow)
+	}
+
+	newParticipant := func(rm *room.Room, userID user.UserID) *participant.Participant {
+		roomID, _ := participant.NewRoomIDFromString(rm.ID().String())
+		participantUserID, _ := participant.NewUserIDFromString(userID.String())
+		return participant.NewParticipant(participant.NewParticipantID(), roomID, participantUserID, participant.RolePlayer, testNow)
+	}
+
+	t.Run("サンプルのテーマが登録されていること", func(t *testing.T) {
+		// arrange
+		themeRepo := persistence.NewInMemoryThemeRepository(persistence.NewMemoryStore())
+
+		// act
+		themes, err := themeRepo.FindAll(context.Background())
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(themes) != 10 {
+			t.Fatalf("Expected 10 themes, got: %d", len(themes))
+		}
+		for _, th := range themes {
+			if len(th.EmojiPreset()) == 0 {
+				t.Errorf("Expected theme %s to have emojis", th.Title().String())
+			}
+		}
+	})
+
+	t.Run("読み込んだ後に保存されたRoomを保存すると競合エラーが返されること", func(t *testing.T) {
+		// arrange
+		roomRepo := persistence.NewInMemoryRoomRepository(persistence.NewMemoryStore())
+		rm := newRoom()
+		if err := roomRepo.Save(context.Background(), rm); err != nil {
+			t.Fatalf("Expected the room to be created, got: %v", err)
+		}
+		first, _ := roomRepo.FindByID(context.Background(), rm.ID())
+		second, _ := roomRepo.FindByID(context.Background(), rm.ID())
+		if err := roomRepo.Save(context.Background(), first); err != nil {
+			t.Fatalf("Expected the first save to succeed, got: %v", err)
+		}
+
+		// act
+		err := roomRepo.Save(context.Background(), second)
+
+		// assert
+		if !errors.Is(err, room.ErrVersionConflict) {
+			t.Fatalf("Expected ErrVersionConflict, got: %v", err)
+		}
+		stored, _ := roomRepo.FindByID(context.Background(), rm.ID())
+		if stored.Version() != 2 {
+			t.Errorf("Expected version 2, got: %d", stored.Version())
+		}
+	})
+
+	t.Run("取得したRoomを変更しても保存するまで反映されないこと", func(t *testing.T) {
+		// arrange
+		roomRepo := persistence.NewInMemoryRoomRepository(persistence.NewMemoryStore())
+		rm := newRoom()
+		roomRepo.Save(context.Background(), rm)
+		found, _ := roomRepo.FindByID(context.Background(), rm.ID())
+
+		// act
+		found.Start(testNow)
+
+		// assert
+		stored, _ := roomRepo.FindByID(context.Background(), rm.ID())
+		if stored.Status() != room.StatusWaiting {
+			t.Errorf("Expected the stored room to be waiting, got: %s", stored.Status().String())
+		}
+	})
+
+	t.Run("作業が失敗するとそれまでに保存した内容がロールバックされること", func(t *testing.T) {
+		// arrange
+		store := persistence.NewMemoryStore()
+		txManager := persistence.NewInMemoryTxManager(store)
+		userRepo := persistence.NewInMemoryUserRepository(store)
+		roomRepo := persistence.NewInMemoryRoomRepository(store)
+		name, _ := user.NewUserName("Tx Test")
+		u := user.NewUser(user.NewUserID(), name, testNow)
+		rm := newRoom()
+		failure := errors.New("participant save error")
+
+		// act
+		err := txManager.Do(context.Background(), func(ctx context.Context) error {
+			if err := userRepo.Save(ctx, u); err != nil {
+				return err
+			}
+			if err := roomRepo.Save(ctx, rm); err != nil {
+				return err
+			}
+			return failure
+		})
+
+		// assert
+		if !errors.Is(err, failure) {
+			t.Fatalf("Expected the error of the work, got: %v", err)
+		}
+		if _, err := userRepo.FindByID(context.Background(), u.ID()); err == nil {
+			t.Error("Expected the user to be rolled back")
+		}
+		if _, err := roomRepo.FindByID(context.Background(), rm.ID()); err == nil {
+			t.Error("Expected the room to be rolled back")
+		}
+	})
+
+	t.Run("同じユーザーの参加を保存すると既存の参加者が更新されること", func(t *testing.T) {
+		// arrange
+		participantRepo := persistence.NewInMemoryParticipantRepository(persistence.NewMemoryStore())
+		rm := newRoom()
+		userID := user.NewUserID()
+		first := newParticipant(rm, userID)
+		participantRepo.Save(context.Background(), first)
+		again := newParticipant(rm, userID)
+		again.SetAsLeader()
+
+		// act
+		err := participantRepo.Save(context.Background(), again)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		participants, _ := participantRepo.FindByRoomID(context.Background(), first.RoomID())
+		if len(participants) != 1 {
+			t.Fatalf("Expected 1 participant, got: %d", len(participants))
+		}
+		if participants[0].ID().String() != first.ID().String() || !participants[0].IsLeader() {
+			t.Errorf("Expected the first participant to become the leader, got: %v", participants[0])
+		}
+	})
+
+	t.Run("Roomを削除すると参加者も削除されること", func(t *testing.T) {
+		// arrange
+		store := persistence.NewMemoryStore()
+		roomRepo := persistence.NewInMemoryRoomRepository(store)
+		participantRepo := persistence.NewInMemoryParticipantRepository(store)
+		rm := newRoom()
+		roomRepo.Save(context.Background(), rm)
+		p := newParticipant(rm, user.NewUserID())
+		participantRepo.Save(context.Background(), p)
+
+		// act
+		err := roomRepo.Delete(context.Background(), rm.ID())
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if _, err := participantRepo.FindByID(context.Background(), p.ID()); err == nil {
+			t.Error("Expected the participant to be deleted with the room")
+		}
+	})
+
+	t.Run("検索結果のプレイヤー数にホストが含まれないこと", func(t *testing.T) {
+		// arrange
+		store := persistence.NewMemoryStore()
+		roomRepo := persistence.NewInMemoryRoomRepository(store)
+		participantRepo := persistence.NewInMemoryParticipantRepository(store)
+		rm := newRoom()
+		roomRepo.Save(context.Background(), rm)
+		roomID, _ := participant.NewRoomIDFromString(rm.ID().String())
+		hostUserID, _ := participant.NewUserIDFromString(rm.HostUserID().String())
+		participantRepo.Save(context.Background(), participant.NewParticipant(participant.NewParticipantID(), roomID, hostUserID, participant.RoleHost, testNow))
+		participantRepo.Save(context.Background(), newParticipant(rm, user.NewUserID()))
+		minPlayers := 2
+
+		// act
+		all, err := roomRepo.Search(context.Background(), room.SearchCriteria{})
+		filtered, _ := roomRepo.Search(context.Background(), room.SearchCriteria{MinPlayers: &minPlayers})
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(all) != 1 || all[0].PlayerCount != 1 {
+			t.Errorf("Expected 1 room with 1 player, got: %v", all)
+		}
+		if len(filtered) != 0 {
+			t.Errorf("Expected the host not to count towards min players, got: %d rooms", len(filtered))
+		}
+	})
+
+	t.Run("同時に保存しても一方だけが成功すること", func(t *testing.T) {
+		// arrange
+		roomRepo := persistence.NewInMemoryRoomRepository(persistence.NewMemoryStore())
+		rm := newRoom()
+		roomRepo.Save(context.Background(), rm)
+		var wg sync.WaitGroup
+		errs := make([]error, 10)
+
+		// act
+		for i := range errs {
+			found, _ := roomRepo.FindByID(context.Background(), rm.ID())
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = roomRepo.Save(context.Background(), found)
+			}()
+		}
+		wg.Wait()
+
+		// assert
+		saved := 0
+		for _, err := range errs {
+			if err == nil {
+				saved++
+			} else if !errors.Is(err, room.ErrVersionConflict) {
+				t.Errorf("Expected ErrVersionConflict, got: %v", err)
+			}
+		}
+		if saved != 1 {
+			t.Errorf("Expected exactly 1 save to succeed, got: %d", saved)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/theme"
+)
+
+// InMemoryThemeRepository implements the theme.Repository interface with a MemoryStore
+type InMemoryThemeRepository struct {
+	store *MemoryStore
+}
+
+// NewInMemoryThemeRepository creates a new InMemoryThemeRepository
+func NewInMemoryThemeRepository(store *MemoryStore) *InMemoryThemeRepository {
+	return &InMemoryThemeRepository{store: store}
+}
+
+// Save persists a theme
+func (r *InMemoryThemeRepository) Save(ctx context.Context, t *theme.Theme) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	put(ctx, r.store.themes, t.ID().String(), *t)
+	return nil
+}
+
+// FindByID retrieves a theme by ID
+func (r *InMemoryThemeRepository) FindByID(ctx context.Context, id theme.ThemeID) (*theme.Theme, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.themes[id.String()]
+	if !ok {
+		return nil, errors.New("theme not found")
+	}
+	return &stored, nil
+}
+
+// FindAll retrieves all themes
+func (r *InMemoryThemeRepository) FindAll(ctx context.Context) ([]*theme.Theme, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var themes []*theme.Theme
+	for _, stored := range r.store.themes {
+		found := stored
+		themes = append(themes, &found)
+	}
+
+	sort.Slice(themes, func(i, j int) bool {
+		return themes[i].Title().String() < themes[j].Title().String()
+	})
+	return themes, nil
+}
+
+// Delete removes a theme
+func (r *InMemoryThemeRepository) Delete(ctx context.Context, id theme.ThemeID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	remove(ctx, r.store.themes, id.String())
+	return nil
+}
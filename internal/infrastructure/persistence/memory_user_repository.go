@@ -0,0 +1,64 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
+)
+
+// InMemoryUserRepository implements the user.Repository interface with a MemoryStore
+type InMemoryUserRepository struct {
+	store *MemoryStore
+}
+
+// NewInMemoryUserRepository creates a new InMemoryUserRepository
+func NewInMemoryUserRepository(store *MemoryStore) *InMemoryUserRepository {
+	return &InMemoryUserRepository{store: store}
+}
+
+// Save persists a user
+func (r *InMemoryUserRepository) Save(ctx context.Context, u *user.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := *u
+	if existing, ok := r.store.users[u.ID().String()]; ok {
+		// Only the name is updated, as ON CONFLICT does
+		stored = existing
+		stored.ChangeName(u.Name())
+	}
+	put(ctx, r.store.users, u.ID().String(), stored)
+	return nil
+}
+
+// FindByID retrieves a user by ID
+func (r *InMemoryUserRepository) FindByID(ctx context.Context, id user.UserID) (*user.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.users[id.String()]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return &stored, nil
+}
+
+// Delete removes a user with the rooms they host and their participations
+func (r *InMemoryUserRepository) Delete(ctx context.Context, id user.UserID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for key, rm := range r.store.rooms {
+		if rm.HostUserID().String() == id.String() {
+			r.store.deleteRoomLocked(ctx, key)
+		}
+	}
+	for key, p := range r.store.participants {
+		if p.UserID().String() == id.String() {
+			r.store.deleteParticipantLocked(ctx, key)
+		}
+	}
+	remove(ctx, r.store.users, id.String())
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 )
@@ -28,7 +29,7 @@ func (r *ParticipantRepository) Save(ctx context.Context, p *participant.Partici
 			is_leader = EXCLUDED.is_leader
 	`
 
-	_, err := r.db.ExecContext(
+	_, err := conn(ctx, r.db).ExecContext(
 		ctx,
 		query,
 		p.ID().String(),
@@ -62,7 +63,7 @@ func (r *ParticipantRepository) FindByRoomID(ctx context.Context, roomID partici
 		ORDER BY joined_at ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, roomID.String())
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, roomID.String())
 	if err != nil {
 		return nil, err
 	}
@@ -94,10 +95,10 @@ func (r *ParticipantRepository) FindByRoomAndUser(ctx context.Context, roomID pa
 		userIDStr string
 		role      string
 		isLeader  bool
-		joinedAt  interface{}
+		joinedAt  time.Time
 	)
 
-	err := r.db.QueryRowContext(ctx, query, roomID.String(), userID.String()).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, roomID.String(), userID.String()).Scan(
 		&id, &roomIDStr, &userIDStr, &role, &isLeader, &joinedAt,
 	)
 
@@ -113,7 +114,7 @@ func (r *ParticipantRepository) FindByRoomAndUser(ctx context.Context, roomID pa
 	participantUserID, _ := participant.NewUserIDFromString(userIDStr)
 	participantRole, _ := participant.NewParticipantRoleFromString(role)
 
-	p := participant.NewParticipant(participantID, participantRoomID, participantUserID, participantRole)
+	p := participant.NewParticipant(participantID, participantRoomID, participantUserID, participantRole, joinedAt)
 	if isLeader {
 		p.SetAsLeader()
 	}
@@ -129,10 +130,10 @@ func (r *ParticipantRepository) scanParticipant(ctx context.Context, query strin
 		userID   string
 		role     string
 		isLeader bool
-		joinedAt interface{}
+		joinedAt time.Time
 	)
 
-	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, arg).Scan(
 		&id, &roomID, &userID, &role, &isLeader, &joinedAt,
 	)
 
@@ -148,7 +149,7 @@ func (r *ParticipantRepository) scanParticipant(ctx context.Context, query strin
 	participantUserID, _ := participant.NewUserIDFromString(userID)
 	participantRole, _ := participant.NewParticipantRoleFromString(role)
 
-	p := participant.NewParticipant(participantID, participantRoomID, participantUserID, participantRole)
+	p := participant.NewParticipant(participantID, participantRoomID, participantUserID, participantRole, joinedAt)
 	if isLeader {
 		p.SetAsLeader()
 	}
@@ -164,7 +165,7 @@ func (r *ParticipantRepository) scanParticipantFromRows(rows *sql.Rows) (*partic
 		userID   string
 		role     string
 		isLeader bool
-		joinedAt interface{}
+		joinedAt time.Time
 	)
 
 	err := rows.Scan(&id, &roomID, &userID, &role, &isLeader, &joinedAt)
@@ -177,7 +178,7 @@ func (r *ParticipantRepository) scanParticipantFromRows(rows *sql.Rows) (*partic
 	participantUserID, _ := participant.NewUserIDFromString(userID)
 	participantRole, _ := participant.NewParticipantRoleFromString(role)
 
-	p := participant.NewParticipant(participantID, participantRoomID, participantUserID, participantRole)
+	p := participant.NewParticipant(participantID, participantRoomID, participantUserID, participantRole, joinedAt)
 	if isLeader {
 		p.SetAsLeader()
 	}
@@ -188,6 +189,6 @@ func (r *ParticipantRepository) scanParticipantFromRows(rows *sql.Rows) (*partic
 // Delete removes a participant
 func (r *ParticipantRepository) Delete(ctx context.Context, roomID participant.RoomID, userID participant.UserID) error {
 	query := `DELETE FROM participants WHERE room_id = $1 AND user_id = $2`
-	_, err := r.db.ExecContext(ctx, query, roomID.String(), userID.String())
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, roomID.String(), userID.String())
 	return err
 }
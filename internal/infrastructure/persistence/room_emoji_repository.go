@@ -0,0 +1,161 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room_emoji"
+)
+
+// RoomEmojiRepository implements the room_emoji.Repository interface
+type RoomEmojiRepository struct {
+	db *sql.DB
+}
+
+// NewRoomEmojiRepository creates a new RoomEmojiRepository
+func NewRoomEmojiRepository(db *sql.DB) *RoomEmojiRepository {
+	return &RoomEmojiRepository{db: db}
+}
+
+// Save persists a room emoji
+func (r *RoomEmojiRepository) Save(ctx context.Context, e *room_emoji.RoomEmoji) error {
+	query := `
+		INSERT INTO room_emojis (id, room_id, participant_id, emoji)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE
+		SET emoji = EXCLUDED.emoji
+	`
+
+	var participantID sql.NullString
+	if e.ParticipantID() != nil {
+		participantID = sql.NullString{String: e.ParticipantID().String(), Valid: true}
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		e.ID().String(),
+		e.RoomID().String(),
+		participantID,
+		e.Emoji().String(),
+	)
+
+	return err
+}
+
+// FindByID retrieves a room emoji by ID
+func (r *RoomEmojiRepository) FindByID(ctx context.Context, id room_emoji.RoomEmojiID) (*room_emoji.RoomEmoji, error) {
+	query := `
+		SELECT id, room_id, participant_id, emoji
+		FROM room_emojis
+		WHERE id = $1
+	`
+
+	e, err := scanRoomEmoji(conn(ctx, r.db).QueryRowContext(ctx, query, id.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, room_emoji.ErrRoomEmojiNotFound
+		}
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// FindByRoomID retrieves all emojis in a room
+func (r *RoomEmojiRepository) FindByRoomID(ctx context.Context, roomID room_emoji.RoomID) ([]*room_emoji.RoomEmoji, error) {
+	query := `
+		SELECT id, room_id, participant_id, emoji
+		FROM room_emojis
+		WHERE room_id = $1
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, roomID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var emojis []*room_emoji.RoomEmoji
+	for rows.Next() {
+		e, err := scanRoomEmoji(rows)
+		if err != nil {
+			return nil, err
+		}
+		emojis = append(emojis, e)
+	}
+
+	return emojis, rows.Err()
+}
+
+// CountByRoomID aggregates the emojis in a room, most frequent first
+func (r *RoomEmojiRepository) CountByRoomID(ctx context.Context, roomID room_emoji.RoomID) ([]room_emoji.ReactionCount, error) {
+	query := `
+		SELECT emoji, COUNT(*)
+		FROM room_emojis
+		WHERE room_id = $1
+		GROUP BY emoji
+		ORDER BY COUNT(*) DESC, emoji ASC
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, roomID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []room_emoji.ReactionCount{}
+	for rows.Next() {
+		var (
+			value string
+			count int
+		)
+		if err := rows.Scan(&value, &count); err != nil {
+			return nil, err
+		}
+		emoji, err := room_emoji.NewEmoji(value)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, room_emoji.ReactionCount{Emoji: emoji, Count: count})
+	}
+
+	return counts, rows.Err()
+}
+
+// Delete removes a room emoji
+func (r *RoomEmojiRepository) Delete(ctx context.Context, id room_emoji.RoomEmojiID) error {
+	query := `DELETE FROM room_emojis WHERE id = $1`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id.String())
+	return err
+}
+
+// scanRoomEmoji scans a room emoji from a row
+func scanRoomEmoji(row rowScanner) (*room_emoji.RoomEmoji, error) {
+	var (
+		id            string
+		roomID        string
+		participantID sql.NullString
+		emoji         string
+	)
+
+	if err := row.Scan(&id, &roomID, &participantID, &emoji); err != nil {
+		return nil, err
+	}
+
+	emojiID, _ := room_emoji.NewRoomEmojiIDFromString(id)
+	emojiRoomID, _ := room_emoji.NewRoomIDFromString(roomID)
+	emojiValue, err := room_emoji.NewEmoji(emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	var emojiParticipantID *room_emoji.ParticipantID
+	if participantID.Valid {
+		pid, _ := room_emoji.NewParticipantIDFromString(participantID.String)
+		emojiParticipantID = &pid
+	}
+
+	return room_emoji.NewRoomEmoji(emojiID, emojiRoomID, emojiParticipantID, emojiValue), nil
+}
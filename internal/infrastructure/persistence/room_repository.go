@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
@@ -20,34 +21,49 @@ func NewRoomRepository(db *sql.DB) *RoomRepository {
 	return &RoomRepository{db: db}
 }
 
-// Save persists a room
+// Save persists a room and advances its version.
+// A room that has never been saved is inserted; otherwise the stored room is
+// updated only if it is still at the version the room was read from.
 func (r *RoomRepository) Save(ctx context.Context, rm *room.Room) error {
-	query := `
+	insertQuery := `
 		INSERT INTO rooms (
 			id, code, theme_id, topic, answer, status, host_user_id,
 			created_at, started_at, original_emojis, displayed_emojis,
-			dummy_index, dummy_emoji, assignments
+			dummy_index, dummy_emoji, assignments,
+			discussion_starts_at, discussion_ends_at, discussion_paused_remaining_ms,
+			setting_topic_timeout_sec, answering_timeout_sec, checking_timeout_sec,
+			phase_ends_at, answer_is_correct, version
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
-		ON CONFLICT (id) DO UPDATE
-		SET topic = EXCLUDED.topic,
-			answer = EXCLUDED.answer,
-			status = EXCLUDED.status,
-			started_at = EXCLUDED.started_at,
-			original_emojis = EXCLUDED.original_emojis,
-			displayed_emojis = EXCLUDED.displayed_emojis,
-			dummy_index = EXCLUDED.dummy_index,
-			dummy_emoji = EXCLUDED.dummy_emoji,
-			assignments = EXCLUDED.assignments
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, 1)
+		ON CONFLICT (id) DO NOTHING
+	`
+	updateQuery := `
+		UPDATE rooms
+		SET topic = $2,
+			answer = $3,
+			status = $4,
+			started_at = $5,
+			original_emojis = $6,
+			displayed_emojis = $7,
+			dummy_index = $8,
+			dummy_emoji = $9,
+			assignments = $10,
+			discussion_starts_at = $11,
+			discussion_ends_at = $12,
+			discussion_paused_remaining_ms = $13,
+			setting_topic_timeout_sec = $14,
+			answering_timeout_sec = $15,
+			checking_timeout_sec = $16,
+			phase_ends_at = $17,
+			answer_is_correct = $18,
+			version = version + 1
+		WHERE id = $1 AND version = $19
 	`
 
 	// Convert VOs to primitive values
 	var topicStr, answerStr interface{}
 	if rm.Topic() != nil {
 		topicStr = rm.Topic().String()
-		fmt.Printf("[RoomRepository.Save] Topic is not nil: '%s'\n", topicStr)
-	} else {
-		fmt.Printf("[RoomRepository.Save] Topic is nil\n")
 	}
 	if rm.Answer() != nil {
 		answerStr = rm.Answer().String()
@@ -76,41 +92,87 @@ func (r *RoomRepository) Save(ctx context.Context, rm *room.Room) error {
 		assignments = rm.Assignments().Values()
 	}
 
-	fmt.Printf("[RoomRepository.Save] Executing SQL with params:\n")
-	fmt.Printf("  ID: %s\n", rm.ID().String())
-	fmt.Printf("  Code: %s\n", rm.Code().String())
-	fmt.Printf("  ThemeID: %s\n", rm.ThemeID().String())
-	fmt.Printf("  Topic: %v (type: %T)\n", topicStr, topicStr)
-	fmt.Printf("  Answer: %v (type: %T)\n", answerStr, answerStr)
-	fmt.Printf("  Status: %s\n", rm.Status().String())
-	fmt.Printf("  HostUserID: %s\n", rm.HostUserID().String())
-
-	_, err := r.db.ExecContext(
-		ctx,
-		query,
-		rm.ID().String(),
-		rm.Code().String(),
-		rm.ThemeID().String(),
-		topicStr,
-		answerStr,
-		rm.Status().String(),
-		rm.HostUserID().String(),
-		rm.CreatedAt(),
-		rm.StartedAt(),
-		pq.Array(originalEmojis),
-		pq.Array(displayedEmojis),
-		dummyIndex,
-		dummyEmoji,
-		pq.Array(assignments),
-	)
+	var discussionStartsAt, discussionEndsAt, discussionPausedRemaining interface{}
+	if deadline := rm.DiscussionDeadline(); deadline != nil {
+		discussionStartsAt = deadline.StartsAt()
+		discussionEndsAt = deadline.EndsAt()
+		if remaining := deadline.PausedRemaining(); remaining != nil {
+			discussionPausedRemaining = remaining.Milliseconds()
+		}
+	}
 
-	if err != nil {
-		fmt.Printf("[RoomRepository.Save] SQL execution failed: %v\n", err)
+	timeouts := rm.PhaseTimeouts()
+
+	var result sql.Result
+	var err error
+	if rm.Version() == 0 {
+		result, err = conn(ctx, r.db).ExecContext(
+			ctx,
+			insertQuery,
+			rm.ID().String(),
+			rm.Code().String(),
+			rm.ThemeID().String(),
+			topicStr,
+			answerStr,
+			rm.Status().String(),
+			rm.HostUserID().String(),
+			rm.CreatedAt(),
+			rm.StartedAt(),
+			pq.Array(originalEmojis),
+			pq.Array(displayedEmojis),
+			dummyIndex,
+			dummyEmoji,
+			pq.Array(assignments),
+			discussionStartsAt,
+			discussionEndsAt,
+			discussionPausedRemaining,
+			int(timeouts.SettingTopic()/time.Second),
+			int(timeouts.Answering()/time.Second),
+			int(timeouts.Checking()/time.Second),
+			rm.PhaseDeadline(),
+			rm.AnswerCorrect(),
+		)
 	} else {
-		fmt.Printf("[RoomRepository.Save] SQL execution succeeded\n")
+		result, err = conn(ctx, r.db).ExecContext(
+			ctx,
+			updateQuery,
+			rm.ID().String(),
+			topicStr,
+			answerStr,
+			rm.Status().String(),
+			rm.StartedAt(),
+			pq.Array(originalEmojis),
+			pq.Array(displayedEmojis),
+			dummyIndex,
+			dummyEmoji,
+			pq.Array(assignments),
+			discussionStartsAt,
+			discussionEndsAt,
+			discussionPausedRemaining,
+			int(timeouts.SettingTopic()/time.Second),
+			int(timeouts.Answering()/time.Second),
+			int(timeouts.Checking()/time.Second),
+			rm.PhaseDeadline(),
+			rm.AnswerCorrect(),
+			rm.Version(),
+		)
 	}
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		// Another writer created or saved the room since it was read
+		return room.ErrVersionConflict
+	}
+
+	rm.SetVersion(rm.Version() + 1)
+	return nil
 }
 
 // FindByID retrieves a room by ID
@@ -118,7 +180,10 @@ func (r *RoomRepository) FindByID(ctx context.Context, id room.RoomID) (*room.Ro
 	query := `
 		SELECT id, code, theme_id, topic, answer, status, host_user_id,
 			created_at, started_at, original_emojis, displayed_emojis,
-			dummy_index, dummy_emoji, assignments
+			dummy_index, dummy_emoji, assignments,
+			discussion_starts_at, discussion_ends_at, discussion_paused_remaining_ms,
+			setting_topic_timeout_sec, answering_timeout_sec, checking_timeout_sec,
+			phase_ends_at, answer_is_correct, version
 		FROM rooms
 		WHERE id = $1
 	`
@@ -131,7 +196,10 @@ func (r *RoomRepository) FindByCode(ctx context.Context, code room.RoomCode) (*r
 	query := `
 		SELECT id, code, theme_id, topic, answer, status, host_user_id,
 			created_at, started_at, original_emojis, displayed_emojis,
-			dummy_index, dummy_emoji, assignments
+			dummy_index, dummy_emoji, assignments,
+			discussion_starts_at, discussion_ends_at, discussion_paused_remaining_ms,
+			setting_topic_timeout_sec, answering_timeout_sec, checking_timeout_sec,
+			phase_ends_at, answer_is_correct, version
 		FROM rooms
 		WHERE code = $1
 	`
@@ -139,8 +207,90 @@ func (r *RoomRepository) FindByCode(ctx context.Context, code room.RoomCode) (*r
 	return r.scanRoom(ctx, query, code.String())
 }
 
+// Search retrieves rooms matching the criteria with their player counts, newest first
+func (r *RoomRepository) Search(ctx context.Context, criteria room.SearchCriteria) ([]room.SearchResult, error) {
+	playerCount := `(SELECT COUNT(*) FROM participants p WHERE p.room_id = rooms.id AND p.role <> 'host')`
+
+	query := `
+		SELECT id, code, theme_id, topic, answer, status, host_user_id,
+			created_at, started_at, original_emojis, displayed_emojis,
+			dummy_index, dummy_emoji, assignments,
+			discussion_starts_at, discussion_ends_at, discussion_paused_remaining_ms,
+			setting_topic_timeout_sec, answering_timeout_sec, checking_timeout_sec,
+			phase_ends_at, answer_is_correct, version, ` + playerCount + `
+		FROM rooms
+		WHERE 1 = 1
+	`
+
+	var args []interface{}
+	addArg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if criteria.Status != nil {
+		query += " AND status = " + addArg(criteria.Status.String())
+	}
+	if criteria.CreatedAfter != nil {
+		query += " AND created_at >= " + addArg(*criteria.CreatedAfter)
+	}
+	if criteria.CreatedBefore != nil {
+		query += " AND created_at <= " + addArg(*criteria.CreatedBefore)
+	}
+	if criteria.PhaseEndsBefore != nil {
+		query += " AND phase_ends_at <= " + addArg(*criteria.PhaseEndsBefore)
+	}
+	if criteria.MinPlayers != nil {
+		query += " AND " + playerCount + " >= " + addArg(*criteria.MinPlayers)
+	}
+	if criteria.MaxPlayers != nil {
+		query += " AND " + playerCount + " <= " + addArg(*criteria.MaxPlayers)
+	}
+	query += " ORDER BY created_at DESC"
+	if criteria.Limit > 0 {
+		query += " LIMIT " + addArg(criteria.Limit)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []room.SearchResult{}
+	for rows.Next() {
+		var players int
+		rm, err := scanRoomRow(rows, &players)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, room.SearchResult{Room: rm, PlayerCount: players})
+	}
+
+	return results, rows.Err()
+}
+
+// rowScanner abstracts *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 // scanRoom scans a room from a query result
 func (r *RoomRepository) scanRoom(ctx context.Context, query string, arg interface{}) (*room.Room, error) {
+	rm, err := scanRoomRow(conn(ctx, r.db).QueryRowContext(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("room not found")
+		}
+		return nil, err
+	}
+
+	return rm, nil
+}
+
+// scanRoomRow reconstructs a room from a single result row.
+// Columns selected after the room's are scanned into extra.
+func scanRoomRow(row rowScanner, extra ...interface{}) (*room.Room, error) {
 	var (
 		id              string
 		code            string
@@ -149,41 +299,45 @@ func (r *RoomRepository) scanRoom(ctx context.Context, query string, arg interfa
 		answer          sql.NullString
 		status          string
 		hostUserID      string
-		createdAt       interface{}
-		startedAt       interface{}
+		createdAt       time.Time
+		startedAt       sql.NullTime
 		originalEmojis  []string
 		displayedEmojis []string
 		dummyIndex      sql.NullInt64
 		dummyEmoji      sql.NullString
 		assignments     []string
+		discussionStart sql.NullTime
+		discussionEnd   sql.NullTime
+		discussionPause sql.NullInt64
+		settingTopicSec int
+		answeringSec    int
+		checkingSec     int
+		phaseEndsAt     sql.NullTime
+		answerCorrect   sql.NullBool
+		version         int
 	)
 
-	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+	dest := []interface{}{
 		&id, &code, &themeID, &topic, &answer, &status, &hostUserID,
 		&createdAt, &startedAt,
 		pq.Array(&originalEmojis), pq.Array(&displayedEmojis),
 		&dummyIndex, &dummyEmoji, pq.Array(&assignments),
-	)
+		&discussionStart, &discussionEnd, &discussionPause,
+		&settingTopicSec, &answeringSec, &checkingSec,
+		&phaseEndsAt, &answerCorrect, &version,
+	}
+	err := row.Scan(append(dest, extra...)...)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("room not found")
-		}
 		return nil, err
 	}
 
-	fmt.Printf("[RoomRepository.scanRoom] Scanned from DB:\n")
-	fmt.Printf("  ID: %s\n", id)
-	fmt.Printf("  Topic Valid: %v, Topic Value: '%s'\n", topic.Valid, topic.String)
-	fmt.Printf("  Answer Valid: %v, Answer Value: '%s'\n", answer.Valid, answer.String)
-	fmt.Printf("  Status: %s\n", status)
-
 	roomID, _ := room.NewRoomIDFromString(id)
 	roomCode, _ := room.NewRoomCodeFromString(code)
 	roomThemeID, _ := room.NewThemeIDFromString(themeID)
 	roomHostUserID, _ := room.NewHostUserIDFromString(hostUserID)
 
-	rm := room.NewRoom(roomID, roomCode, roomThemeID, roomHostUserID)
+	rm := room.NewRoom(roomID, roomCode, roomThemeID, roomHostUserID, createdAt)
 
 	if topic.Valid {
 		if t, err := room.NewTopic(topic.String); err == nil {
@@ -196,6 +350,12 @@ func (r *RoomRepository) scanRoom(ctx context.Context, query string, arg interfa
 		}
 	}
 
+	var startedAtPtr *time.Time
+	if startedAt.Valid {
+		startedAtPtr = &startedAt.Time
+	}
+	rm.SetTimestamps(createdAt, startedAtPtr)
+
 	roomStatus, _ := room.NewRoomStatusFromString(status)
 	rm.SetStatus(roomStatus) // Use SetStatus instead of ChangeStatus to bypass validation when reconstructing from DB
 
@@ -211,12 +371,36 @@ func (r *RoomRepository) scanRoom(ctx context.Context, query string, arg interfa
 		rm.SetAssignments(room.NewAssignments(assignments))
 	}
 
+	if discussionStart.Valid && discussionEnd.Valid {
+		var pausedRemaining *time.Duration
+		if discussionPause.Valid {
+			remaining := time.Duration(discussionPause.Int64) * time.Millisecond
+			pausedRemaining = &remaining
+		}
+		deadline := room.ReconstructDiscussionDeadline(discussionStart.Time, discussionEnd.Time, pausedRemaining)
+		rm.SetDiscussionDeadline(&deadline)
+	}
+
+	timeouts, _ := room.NewPhaseTimeouts(
+		time.Duration(settingTopicSec)*time.Second,
+		time.Duration(answeringSec)*time.Second,
+		time.Duration(checkingSec)*time.Second,
+	)
+	rm.SetPhaseTimeouts(timeouts)
+	if phaseEndsAt.Valid {
+		rm.SetPhaseDeadline(&phaseEndsAt.Time)
+	}
+	if answerCorrect.Valid {
+		rm.SetAnswerCorrect(&answerCorrect.Bool)
+	}
+	rm.SetVersion(version)
+
 	return rm, nil
 }
 
 // Delete removes a room
 func (r *RoomRepository) Delete(ctx context.Context, id room.RoomID) error {
 	query := `DELETE FROM rooms WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id.String())
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id.String())
 	return err
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/lib/pq"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/theme"
 )
 
@@ -21,19 +22,21 @@ func NewThemeRepository(db *sql.DB) *ThemeRepository {
 // Save persists a theme
 func (r *ThemeRepository) Save(ctx context.Context, t *theme.Theme) error {
 	query := `
-		INSERT INTO themes (id, title, hint)
-		VALUES ($1, $2, $3)
+		INSERT INTO themes (id, title, hint, emojis)
+		VALUES ($1, $2, $3, $4)
 		ON CONFLICT (id) DO UPDATE
 		SET title = EXCLUDED.title,
-			hint = EXCLUDED.hint
+			hint = EXCLUDED.hint,
+			emojis = EXCLUDED.emojis
 	`
 
-	_, err := r.db.ExecContext(
+	_, err := conn(ctx, r.db).ExecContext(
 		ctx,
 		query,
 		t.ID().String(),
 		t.Title().String(),
 		t.Hint().String(),
+		pq.Array(t.EmojiPreset()),
 	)
 
 	return err
@@ -42,7 +45,7 @@ func (r *ThemeRepository) Save(ctx context.Context, t *theme.Theme) error {
 // FindByID retrieves a theme by ID
 func (r *ThemeRepository) FindByID(ctx context.Context, id theme.ThemeID) (*theme.Theme, error) {
 	query := `
-		SELECT id, title, hint
+		SELECT id, title, hint, emojis
 		FROM themes
 		WHERE id = $1
 	`
@@ -51,9 +54,10 @@ func (r *ThemeRepository) FindByID(ctx context.Context, id theme.ThemeID) (*them
 		themeID string
 		title   string
 		hint    sql.NullString
+		emojis  []string
 	)
 
-	err := r.db.QueryRowContext(ctx, query, id.String()).Scan(&themeID, &title, &hint)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id.String()).Scan(&themeID, &title, &hint, pq.Array(&emojis))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("theme not found")
@@ -70,18 +74,20 @@ func (r *ThemeRepository) FindByID(ctx context.Context, id theme.ThemeID) (*them
 	}
 	hintVO := theme.NewHint(hintStr)
 
-	return theme.NewTheme(tid, themeTitle, hintVO), nil
+	t := theme.NewTheme(tid, themeTitle, hintVO)
+	t.SetEmojiPreset(emojis)
+	return t, nil
 }
 
 // FindAll retrieves all themes
 func (r *ThemeRepository) FindAll(ctx context.Context) ([]*theme.Theme, error) {
 	query := `
-		SELECT id, title, hint
+		SELECT id, title, hint, emojis
 		FROM themes
 		ORDER BY title ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -93,9 +99,10 @@ func (r *ThemeRepository) FindAll(ctx context.Context) ([]*theme.Theme, error) {
 			themeID string
 			title   string
 			hint    sql.NullString
+			emojis  []string
 		)
 
-		if err := rows.Scan(&themeID, &title, &hint); err != nil {
+		if err := rows.Scan(&themeID, &title, &hint, pq.Array(&emojis)); err != nil {
 			return nil, err
 		}
 
@@ -108,7 +115,9 @@ func (r *ThemeRepository) FindAll(ctx context.Context) ([]*theme.Theme, error) {
 		}
 		hintVO := theme.NewHint(hintStr)
 
-		themes = append(themes, theme.NewTheme(tid, themeTitle, hintVO))
+		t := theme.NewTheme(tid, themeTitle, hintVO)
+		t.SetEmojiPreset(emojis)
+		themes = append(themes, t)
 	}
 
 	return themes, rows.Err()
@@ -117,6 +126,6 @@ func (r *ThemeRepository) FindAll(ctx context.Context) ([]*theme.Theme, error) {
 // Delete removes a theme
 func (r *ThemeRepository) Delete(ctx context.Context, id theme.ThemeID) error {
 	query := `DELETE FROM themes WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id.String())
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id.String())
 	return err
 }
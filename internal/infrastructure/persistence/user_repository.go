@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
 )
@@ -27,7 +28,7 @@ func (r *UserRepository) Save(ctx context.Context, u *user.User) error {
 		SET name = EXCLUDED.name
 	`
 
-	_, err := r.db.ExecContext(
+	_, err := conn(ctx, r.db).ExecContext(
 		ctx,
 		query,
 		u.ID().String(),
@@ -49,10 +50,10 @@ func (r *UserRepository) FindByID(ctx context.Context, id user.UserID) (*user.Us
 	var (
 		userID    string
 		name      string
-		createdAt interface{}
+		createdAt time.Time
 	)
 
-	err := r.db.QueryRowContext(ctx, query, id.String()).Scan(&userID, &name, &createdAt)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id.String()).Scan(&userID, &name, &createdAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("user not found")
@@ -70,12 +71,12 @@ func (r *UserRepository) FindByID(ctx context.Context, id user.UserID) (*user.Us
 		return nil, err
 	}
 
-	return user.NewUser(uid, userName), nil
+	return user.NewUser(uid, userName, createdAt), nil
 }
 
 // Delete removes a user
 func (r *UserRepository) Delete(ctx context.Context, id user.UserID) error {
 	query := `DELETE FROM users WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id.String())
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id.String())
 	return err
 }
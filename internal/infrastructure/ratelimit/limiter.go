@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"golang.org/x/time/rate"
+)
+
+// idleTimeout is how long an unused key is kept before its bucket is discarded
+const idleTimeout = 10 * time.Minute
+
+// KeyedLimiter implements the domain ratelimit.Limiter with a token bucket per key
+type KeyedLimiter struct {
+	mu       sync.Mutex
+	limit    rate.Limit
+	burst    int
+	limiters map[string]*entry
+	clock    clock.Clock
+	lastGC   time.Time
+}
+
+type entry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewKeyedLimiter creates a new KeyedLimiter allowing perSecond events per key with the given burst
+func NewKeyedLimiter(perSecond float64, burst int, clk clock.Clock) *KeyedLimiter {
+	return &KeyedLimiter{
+		limit:    rate.Limit(perSecond),
+		burst:    burst,
+		limiters: make(map[string]*entry),
+		clock:    clk,
+		lastGC:   clk.Now(),
+	}
+}
+
+// Allow reports whether the action is allowed now, consuming a token if so
+func (l *KeyedLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	l.collectGarbage(now)
+
+	e, ok := l.limiters[key]
+	if !ok {
+		e = &entry{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.limiters[key] = e
+	}
+	e.lastSeen = now
+
+	return e.limiter.AllowN(now, 1)
+}
+
+// collectGarbage drops buckets of keys that have been idle for a while. Callers must hold l.mu.
+func (l *KeyedLimiter) collectGarbage(now time.Time) {
+	if now.Sub(l.lastGC) < idleTimeout {
+		return
+	}
+	for key, e := range l.limiters {
+		if now.Sub(e.lastSeen) > idleTimeout {
+			delete(l.limiters, key)
+		}
+	}
+	l.lastGC = now
+}
@@ -0,0 +1,62 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/ratelimit"
+)
+
+func TestKeyedLimiterAllow(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("バーストを超えると拒否されること", func(t *testing.T) {
+		// arrange
+		l := ratelimit.NewKeyedLimiter(0.001, 3, infrastructureClock.NewFakeClock(start))
+
+		// act
+		results := []bool{l.Allow("a"), l.Allow("a"), l.Allow("a"), l.Allow("a")}
+
+		// assert
+		for i, allowed := range results[:3] {
+			if !allowed {
+				t.Errorf("Expected request %d to be allowed", i)
+			}
+		}
+		if results[3] {
+			t.Error("Expected request beyond burst to be rejected")
+		}
+	})
+
+	t.Run("キーごとに独立して制限されること", func(t *testing.T) {
+		// arrange
+		l := ratelimit.NewKeyedLimiter(0.001, 1, infrastructureClock.NewFakeClock(start))
+		l.Allow("a")
+
+		// act
+		allowed := l.Allow("b")
+
+		// assert
+		if !allowed {
+			t.Error("Expected another key to be allowed")
+		}
+	})
+
+	t.Run("時間が経つとトークンが補充されること", func(t *testing.T) {
+		// arrange
+		c := infrastructureClock.NewFakeClock(start)
+		l := ratelimit.NewKeyedLimiter(1, 1, c)
+		l.Allow("a")
+
+		// act
+		rejected := !l.Allow("a")
+		c.Advance(time.Second)
+		allowed := l.Allow("a")
+
+		// assert
+		if !rejected || !allowed {
+			t.Errorf("Expected a rejection before and an allowance after a second, got: %v %v", rejected, allowed)
+		}
+	})
+}
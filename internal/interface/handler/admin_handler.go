@@ -0,0 +1,344 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	adminUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/admin"
+)
+
+// AdminHandler handles operator HTTP requests under /admin
+type AdminHandler struct {
+	listRoomsUseCase       *adminUseCase.ListRoomsUseCase
+	fetchRoomDetailUseCase *adminUseCase.FetchRoomDetailUseCase
+	forceTransitionUseCase *adminUseCase.ForceTransitionUseCase
+	deleteRoomUseCase      *adminUseCase.DeleteRoomUseCase
+	fetchAuditLogsUseCase  *adminUseCase.FetchAuditLogsUseCase
+}
+
+// NewAdminHandler creates a new AdminHandler
+func NewAdminHandler(
+	listRoomsUseCase *adminUseCase.ListRoomsUseCase,
+	fetchRoomDetailUseCase *adminUseCase.FetchRoomDetailUseCase,
+	forceTransitionUseCase *adminUseCase.ForceTransitionUseCase,
+	deleteRoomUseCase *adminUseCase.DeleteRoomUseCase,
+	fetchAuditLogsUseCase *adminUseCase.FetchAuditLogsUseCase,
+) *AdminHandler {
+	return &AdminHandler{
+		listRoomsUseCase:       listRoomsUseCase,
+		fetchRoomDetailUseCase: fetchRoomDetailUseCase,
+		forceTransitionUseCase: forceTransitionUseCase,
+		deleteRoomUseCase:      deleteRoomUseCase,
+		fetchAuditLogsUseCase:  fetchAuditLogsUseCase,
+	}
+}
+
+// AdminRoomSummaryResponse represents a room in the admin list response
+type AdminRoomSummaryResponse struct {
+	RoomID      string     `json:"room_id"`
+	RoomCode    string     `json:"room_code"`
+	Status      string     `json:"status"`
+	HostUserID  string     `json:"host_user_id"`
+	PlayerCount int        `json:"player_count"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+}
+
+// ListRoomsResponse represents the response for listing rooms
+type ListRoomsResponse struct {
+	Rooms []AdminRoomSummaryResponse `json:"rooms"`
+}
+
+// ListRooms handles GET /admin/rooms
+func (h *AdminHandler) ListRooms(c echo.Context) error {
+	input := adminUseCase.ListRoomsInput{
+		Status: c.QueryParam("status"),
+	}
+
+	var err error
+	if input.MinAge, err = durationParam(c, "min_age"); err != nil {
+		return badRequest(c, "invalid min_age")
+	}
+	if input.MaxAge, err = durationParam(c, "max_age"); err != nil {
+		return badRequest(c, "invalid max_age")
+	}
+	if input.MinPlayers, err = intParam(c, "min_players"); err != nil {
+		return badRequest(c, "invalid min_players")
+	}
+	if input.MaxPlayers, err = intParam(c, "max_players"); err != nil {
+		return badRequest(c, "invalid max_players")
+	}
+	limit, err := intParam(c, "limit")
+	if err != nil {
+		return badRequest(c, "invalid limit")
+	}
+	if limit != nil {
+		input.Limit = *limit
+	}
+
+	output, err := h.listRoomsUseCase.Execute(c.Request().Context(), input)
+	if err != nil {
+		return c.JSON(adminErrorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	response := ListRoomsResponse{Rooms: []AdminRoomSummaryResponse{}}
+	for _, r := range output.Rooms {
+		response.Rooms = append(response.Rooms, AdminRoomSummaryResponse{
+			RoomID:      r.RoomID,
+			RoomCode:    r.RoomCode,
+			Status:      r.Status,
+			HostUserID:  r.HostUserID,
+			PlayerCount: r.PlayerCount,
+			CreatedAt:   r.CreatedAt,
+			StartedAt:   r.StartedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// AdminParticipantResponse represents a participant in the room detail response
+type AdminParticipantResponse struct {
+	UserID   string    `json:"user_id"`
+	UserName string    `json:"user_name"`
+	Role     string    `json:"role"`
+	IsLeader bool      `json:"is_leader"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// RoomDetailResponse represents the full internal state of a room
+type RoomDetailResponse struct {
+	RoomID          string                     `json:"room_id"`
+	RoomCode        string                     `json:"room_code"`
+	Status          string                     `json:"status"`
+	HostUserID      string                     `json:"host_user_id"`
+	ThemeID         string                     `json:"theme_id"`
+	Theme           string                     `json:"theme"`
+	Topic           string                     `json:"topic"`
+	Answer          string                     `json:"answer"`
+	OriginalEmojis  []string                   `json:"original_emojis"`
+	DisplayedEmojis []string                   `json:"displayed_emojis"`
+	DummyIndex      *int                       `json:"dummy_index"`
+	DummyEmoji      string                     `json:"dummy_emoji"`
+	Assignments     []string                   `json:"assignments"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	StartedAt       *time.Time                 `json:"started_at,omitempty"`
+	Participants    []AdminParticipantResponse `json:"participants"`
+}
+
+// newRoomDetailResponse converts use case output to the response format
+func newRoomDetailResponse(output *adminUseCase.FetchRoomDetailOutput) RoomDetailResponse {
+	rm := output.Room
+	response := RoomDetailResponse{
+		RoomID:          rm.ID().String(),
+		RoomCode:        rm.Code().String(),
+		Status:          rm.Status().String(),
+		HostUserID:      rm.HostUserID().String(),
+		ThemeID:         rm.ThemeID().String(),
+		Theme:           output.ThemeTitle,
+		OriginalEmojis:  []string{},
+		DisplayedEmojis: []string{},
+		Assignments:     []string{},
+		CreatedAt:       rm.CreatedAt(),
+		StartedAt:       rm.StartedAt(),
+		Participants:    []AdminParticipantResponse{},
+	}
+
+	if rm.Topic() != nil {
+		response.Topic = rm.Topic().String()
+	}
+	if rm.Answer() != nil {
+		response.Answer = rm.Answer().String()
+	}
+	if rm.OriginalEmojis() != nil {
+		response.OriginalEmojis = rm.OriginalEmojis().Values()
+	}
+	if rm.DisplayedEmojis() != nil {
+		response.DisplayedEmojis = rm.DisplayedEmojis().Values()
+	}
+	if rm.DummyIndex() != nil {
+		val := rm.DummyIndex().Value()
+		response.DummyIndex = &val
+	}
+	if rm.DummyEmoji() != nil {
+		response.DummyEmoji = rm.DummyEmoji().String()
+	}
+	if rm.Assignments() != nil {
+		response.Assignments = rm.Assignments().Values()
+	}
+
+	for _, p := range output.Participants {
+		response.Participants = append(response.Participants, AdminParticipantResponse{
+			UserID:   p.UserID,
+			UserName: p.UserName,
+			Role:     p.Role,
+			IsLeader: p.IsLeader,
+			JoinedAt: p.JoinedAt,
+		})
+	}
+
+	return response
+}
+
+// FetchRoomDetail handles GET /admin/rooms/:room_id
+func (h *AdminHandler) FetchRoomDetail(c echo.Context) error {
+	input := adminUseCase.FetchRoomDetailInput{
+		RoomID: c.Param("room_id"),
+	}
+
+	output, err := h.fetchRoomDetailUseCase.Execute(c.Request().Context(), input)
+	if err != nil {
+		return c.JSON(adminErrorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, newRoomDetailResponse(output))
+}
+
+// ForceTransitionRequest represents the request body for forcing a room status
+type ForceTransitionRequest struct {
+	Status string `json:"status"`
+}
+
+// ForceTransition handles POST /admin/rooms/:room_id/transition
+func (h *AdminHandler) ForceTransition(c echo.Context) error {
+	var req ForceTransitionRequest
+	if err := c.Bind(&req); err != nil {
+		return badRequest(c, "invalid request body")
+	}
+
+	return h.forceTransition(c, req.Status)
+}
+
+// ForceFinish handles POST /admin/rooms/:room_id/finish
+func (h *AdminHandler) ForceFinish(c echo.Context) error {
+	return h.forceTransition(c, room.StatusFinished.String())
+}
+
+func (h *AdminHandler) forceTransition(c echo.Context, status string) error {
+	input := adminUseCase.ForceTransitionInput{
+		RoomID: c.Param("room_id"),
+		Status: status,
+	}
+
+	if err := h.forceTransitionUseCase.Execute(c.Request().Context(), input); err != nil {
+		return c.JSON(adminErrorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"status": status,
+	})
+}
+
+// DeleteRoom handles DELETE /admin/rooms/:room_id
+func (h *AdminHandler) DeleteRoom(c echo.Context) error {
+	input := adminUseCase.DeleteRoomInput{
+		RoomID: c.Param("room_id"),
+	}
+
+	if err := h.deleteRoomUseCase.Execute(c.Request().Context(), input); err != nil {
+		return c.JSON(adminErrorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"status": "deleted",
+	})
+}
+
+// AuditLogResponse represents a single audit log entry in the response
+type AuditLogResponse struct {
+	ActorType    string    `json:"actor_type"`
+	ActorID      string    `json:"actor_id"`
+	Action       string    `json:"action"`
+	BeforeStatus string    `json:"before_status,omitempty"`
+	AfterStatus  string    `json:"after_status,omitempty"`
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+// FetchAuditLogsResponse represents the response for fetching audit logs
+type FetchAuditLogsResponse struct {
+	AuditLogs []AuditLogResponse `json:"audit_logs"`
+}
+
+// newFetchAuditLogsResponse converts use case output to the response format
+func newFetchAuditLogsResponse(output *adminUseCase.FetchAuditLogsOutput) FetchAuditLogsResponse {
+	response := FetchAuditLogsResponse{AuditLogs: []AuditLogResponse{}}
+	for _, l := range output.AuditLogs {
+		response.AuditLogs = append(response.AuditLogs, AuditLogResponse{
+			ActorType:    l.ActorType,
+			ActorID:      l.ActorID,
+			Action:       l.Action,
+			BeforeStatus: l.BeforeStatus,
+			AfterStatus:  l.AfterStatus,
+			OccurredAt:   l.OccurredAt,
+		})
+	}
+	return response
+}
+
+// FetchAuditLogs handles GET /admin/rooms/:room_id/audit-logs
+func (h *AdminHandler) FetchAuditLogs(c echo.Context) error {
+	input := adminUseCase.FetchAuditLogsInput{
+		RoomID: c.Param("room_id"),
+	}
+
+	output, err := h.fetchAuditLogsUseCase.Execute(c.Request().Context(), input)
+	if err != nil {
+		return c.JSON(adminErrorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, newFetchAuditLogsResponse(output))
+}
+
+// adminErrorStatus maps admin use case errors to HTTP status codes
+func adminErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, adminUseCase.ErrRoomNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, adminUseCase.ErrAlreadyInTargetStatus), errors.Is(err, room.ErrVersionConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func badRequest(c echo.Context, message string) error {
+	return c.JSON(http.StatusBadRequest, map[string]string{
+		"error": message,
+	})
+}
+
+// durationParam parses an optional duration query parameter such as "30m"
+func durationParam(c echo.Context, name string) (time.Duration, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// intParam parses an optional integer query parameter
+func intParam(c echo.Context, name string) (*int, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
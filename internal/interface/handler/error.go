@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
+)
+
+// errorResponse builds an error response body, adding a machine-readable
+// code for errors that clients are expected to handle specifically
+func errorResponse(err error) map[string]string {
+	response := map[string]string{
+		"error": err.Error(),
+	}
+	if code := moderation.ErrorCode(err); code != "" {
+		response["code"] = code
+	}
+	return response
+}
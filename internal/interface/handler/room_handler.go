@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
 	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
 )
 
@@ -15,6 +18,7 @@ type RoomHandler struct {
 	submitAnswerUseCase   *roomUseCase.SubmitAnswerUseCase
 	skipDiscussionUseCase *roomUseCase.SkipDiscussionUseCase
 	finishGameUseCase     *roomUseCase.FinishGameUseCase
+	fetchReactionsUseCase *roomUseCase.FetchReactionCountsUseCase
 }
 
 // NewRoomHandler creates a new RoomHandler
@@ -25,6 +29,7 @@ func NewRoomHandler(
 	submitAnswerUseCase *roomUseCase.SubmitAnswerUseCase,
 	skipDiscussionUseCase *roomUseCase.SkipDiscussionUseCase,
 	finishGameUseCase *roomUseCase.FinishGameUseCase,
+	fetchReactionsUseCase *roomUseCase.FetchReactionCountsUseCase,
 ) *RoomHandler {
 	return &RoomHandler{
 		createRoomUseCase:     createRoomUseCase,
@@ -33,6 +38,7 @@ func NewRoomHandler(
 		submitAnswerUseCase:   submitAnswerUseCase,
 		skipDiscussionUseCase: skipDiscussionUseCase,
 		finishGameUseCase:     finishGameUseCase,
+		fetchReactionsUseCase: fetchReactionsUseCase,
 	}
 }
 
@@ -45,9 +51,37 @@ type CreateRoomResponse struct {
 	Hint     string `json:"hint"`
 }
 
+// CreateRoomRequest represents the optional request body for creating a room
+type CreateRoomRequest struct {
+	PhaseTimeouts PhaseTimeoutsRequest `json:"phase_timeouts"`
+}
+
+// PhaseTimeoutsRequest represents the per-phase deadlines in seconds; 0 or omitted means no deadline
+type PhaseTimeoutsRequest struct {
+	SettingTopicSeconds int `json:"setting_topic_seconds"`
+	AnsweringSeconds    int `json:"answering_seconds"`
+	CheckingSeconds     int `json:"checking_seconds"`
+}
+
 // CreateRoom handles POST /api/rooms
 func (h *RoomHandler) CreateRoom(c echo.Context) error {
-	output, err := h.createRoomUseCase.Execute(c.Request().Context())
+	var req CreateRoomRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	input := roomUseCase.CreateRoomInput{
+		SettingTopicTimeout: time.Duration(req.PhaseTimeouts.SettingTopicSeconds) * time.Second,
+		AnsweringTimeout:    time.Duration(req.PhaseTimeouts.AnsweringSeconds) * time.Second,
+		CheckingTimeout:     time.Duration(req.PhaseTimeouts.CheckingSeconds) * time.Second,
+	}
+
+	output, err := h.createRoomUseCase.Execute(c.Request().Context(), input)
+	if errors.Is(err, room.ErrInvalidPhaseTimeout) {
+		return c.JSON(http.StatusBadRequest, errorResponse(err))
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": err.Error(),
@@ -87,7 +121,7 @@ func (h *RoomHandler) StartGame(c echo.Context) error {
 	}
 
 	if err := h.startGameUseCase.Execute(c.Request().Context(), input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
+		return c.JSON(roomErrorStatus(err), map[string]string{
 			"error": err.Error(),
 		})
 	}
@@ -131,9 +165,7 @@ func (h *RoomHandler) SetTopic(c echo.Context) error {
 	}
 
 	if err := h.setTopicUseCase.Execute(c.Request().Context(), input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": err.Error(),
-		})
+		return c.JSON(roomErrorStatus(err), errorResponse(err))
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
@@ -165,9 +197,7 @@ func (h *RoomHandler) SubmitAnswer(c echo.Context) error {
 	}
 
 	if err := h.submitAnswerUseCase.Execute(c.Request().Context(), input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": err.Error(),
-		})
+		return c.JSON(roomErrorStatus(err), errorResponse(err))
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
@@ -197,7 +227,7 @@ func (h *RoomHandler) SkipDiscussion(c echo.Context) error {
 	}
 
 	if err := h.skipDiscussionUseCase.Execute(c.Request().Context(), input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
+		return c.JSON(roomErrorStatus(err), map[string]string{
 			"error": err.Error(),
 		})
 	}
@@ -229,7 +259,7 @@ func (h *RoomHandler) FinishGame(c echo.Context) error {
 	}
 
 	if err := h.finishGameUseCase.Execute(c.Request().Context(), input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
+		return c.JSON(roomErrorStatus(err), map[string]string{
 			"error": err.Error(),
 		})
 	}
@@ -238,3 +268,51 @@ func (h *RoomHandler) FinishGame(c echo.Context) error {
 		"status": "game_finished",
 	})
 }
+
+// ReactionCountResponse represents how many times an emoji was sent
+type ReactionCountResponse struct {
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+}
+
+// FetchReactionsResponse represents the response for fetching reaction counts
+type FetchReactionsResponse struct {
+	Reactions []ReactionCountResponse `json:"reactions"`
+	Total     int                     `json:"total"`
+}
+
+// FetchReactions handles GET /api/rooms/:room_id/reactions
+func (h *RoomHandler) FetchReactions(c echo.Context) error {
+	input := roomUseCase.FetchReactionCountsInput{
+		RoomID: c.Param("room_id"),
+	}
+
+	output, err := h.fetchReactionsUseCase.Execute(c.Request().Context(), input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	response := FetchReactionsResponse{
+		Reactions: []ReactionCountResponse{},
+		Total:     output.Total,
+	}
+	for _, r := range output.Reactions {
+		response.Reactions = append(response.Reactions, ReactionCountResponse{
+			Emoji: r.Emoji,
+			Count: r.Count,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// roomErrorStatus maps errors of the use cases changing a room to HTTP status codes.
+// A version conflict remains only when the room kept changing across every retry.
+func roomErrorStatus(err error) int {
+	if errors.Is(err, room.ErrVersionConflict) {
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
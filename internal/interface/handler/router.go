@@ -4,6 +4,8 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/shooooooma415/guess-title-game-api/config"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/idempotency"
 	customMiddleware "github.com/shooooooma415/guess-title-game-api/internal/interface/middleware"
 	"github.com/shooooooma415/guess-title-game-api/internal/interface/websocket"
 )
@@ -13,7 +15,10 @@ func NewRouter(
 	cfg *config.Config,
 	userHandler *UserHandler,
 	roomHandler *RoomHandler,
+	adminHandler *AdminHandler,
 	wsHandler *websocket.Handler,
+	idempotencyRepo idempotency.Repository,
+	clk clock.Clock,
 ) *echo.Echo {
 	e := echo.New()
 
@@ -27,9 +32,10 @@ func NewRouter(
 
 	// WebSocket endpoint
 	e.GET("/ws", wsHandler.HandleWebSocket)
+	e.GET("/ws/schema", wsHandler.HandleSchema)
 
 	// API routes
-	api := e.Group("/api")
+	api := e.Group("/api", customMiddleware.Idempotency(idempotencyRepo, cfg.Idempotency.TTL, clk))
 	{
 		// User routes
 		api.POST("/user", userHandler.JoinRoom)
@@ -41,6 +47,25 @@ func NewRouter(
 		api.POST("/rooms/:room_id/answer", roomHandler.SubmitAnswer)
 		api.POST("/rooms/:room_id/skip-discussion", roomHandler.SkipDiscussion)
 		api.POST("/rooms/:room_id/finish", roomHandler.FinishGame)
+		api.GET("/rooms/:room_id/reactions", roomHandler.FetchReactions)
+		api.GET("/rooms/:room_id/events", wsHandler.HandleEventStream)
+	}
+
+	// Admin routes (disabled unless an admin key is configured)
+	if cfg.Admin.APIKey != "" {
+		admin := e.Group("/admin",
+			customMiddleware.AdminAuth(cfg.Admin.APIKey),
+			customMiddleware.Idempotency(idempotencyRepo, cfg.Idempotency.TTL, clk),
+		)
+		{
+			admin.GET("/rooms", adminHandler.ListRooms)
+			admin.GET("/rooms/:room_id", adminHandler.FetchRoomDetail)
+			admin.POST("/rooms/:room_id/transition", adminHandler.ForceTransition)
+			admin.POST("/rooms/:room_id/finish", adminHandler.ForceFinish)
+			admin.DELETE("/rooms/:room_id", adminHandler.DeleteRoom)
+			admin.GET("/rooms/:room_id/audit-logs", adminHandler.FetchAuditLogs)
+			admin.GET("/ws/stats", wsHandler.HandleStats)
+		}
 	}
 
 	return e
@@ -48,9 +48,7 @@ func (h *UserHandler) JoinRoom(c echo.Context) error {
 
 	output, err := h.joinRoomUseCase.Execute(c.Request().Context(), input)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": err.Error(),
-		})
+		return c.JSON(http.StatusBadRequest, errorResponse(err))
 	}
 
 	response := JoinRoomResponse{
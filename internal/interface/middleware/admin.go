@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderAdminKey is the request header carrying the admin API key
+const HeaderAdminKey = "X-Admin-Key"
+
+// AdminAuth returns a middleware that only lets requests with the configured admin key through
+func AdminAuth(apiKey string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			provided := c.Request().Header.Get(HeaderAdminKey)
+			if apiKey == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(apiKey)) != 1 {
+				return c.JSON(http.StatusUnauthorized, map[string]string{
+					"error": "invalid admin key",
+				})
+			}
+			return next(c)
+		}
+	}
+}
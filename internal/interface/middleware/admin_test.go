@@ -0,0 +1,51 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shooooooma415/guess-title-game-api/internal/interface/middleware"
+)
+
+func TestAdminAuth(t *testing.T) {
+	do := func(apiKey, providedKey string) *httptest.ResponseRecorder {
+		e := echo.New()
+		e.GET("/admin/rooms", func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		}, middleware.AdminAuth(apiKey))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/rooms", nil)
+		if providedKey != "" {
+			req.Header.Set(middleware.HeaderAdminKey, providedKey)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name        string
+		apiKey      string
+		providedKey string
+		want        int
+	}{
+		{name: "正しいキーの場合は通過すること", apiKey: "secret", providedKey: "secret", want: http.StatusOK},
+		{name: "キーが異なる場合は401が返されること", apiKey: "secret", providedKey: "wrong", want: http.StatusUnauthorized},
+		{name: "キーがない場合は401が返されること", apiKey: "secret", providedKey: "", want: http.StatusUnauthorized},
+		{name: "キーが未設定の場合は常に401が返されること", apiKey: "", providedKey: "", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			rec := do(tt.apiKey, tt.providedKey)
+
+			// assert
+			if rec.Code != tt.want {
+				t.Errorf("Expected status %d, got: %d", tt.want, rec.Code)
+			}
+		})
+	}
+}
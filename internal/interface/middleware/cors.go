@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"net/http"
+
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/shooooooma415/guess-title-game-api/config"
@@ -9,8 +11,18 @@ import (
 // CORSConfig returns the CORS middleware configuration
 func CORSConfig(cfg *config.Config) echo.MiddlewareFunc {
 	return middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: cfg.CORS.AllowOrigins,
-		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.DELETE, echo.OPTIONS},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept},
+		AllowOriginFunc: func(origin string) (bool, error) {
+			return cfg.Origin.IsAllowed(origin), nil
+		},
+		AllowMethods:  []string{echo.GET, echo.POST, echo.PUT, echo.DELETE, echo.OPTIONS},
+		AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, HeaderIdempotencyKey},
+		ExposeHeaders: []string{HeaderIdempotencyReplayed},
 	})
 }
+
+// CheckOrigin returns an origin checker for WebSocket upgrades using the same policy as CORS
+func CheckOrigin(cfg *config.Config) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		return cfg.Origin.IsAllowed(r.Header.Get(echo.HeaderOrigin))
+	}
+}
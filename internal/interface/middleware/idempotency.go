@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/idempotency"
+)
+
+const (
+	// HeaderIdempotencyKey is the request header carrying the client-supplied key
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotencyReplayed is set on responses replayed from a stored record
+	HeaderIdempotencyReplayed = "Idempotency-Replayed"
+
+	// maxIdempotentBodySize limits the request body read for fingerprinting
+	maxIdempotentBodySize = 1 << 20
+)
+
+// Idempotency returns a middleware that replays the first response for
+// mutating requests retried with the same Idempotency-Key header.
+// Requests without the header are passed through unchanged.
+func Idempotency(repo idempotency.Repository, ttl time.Duration, clk clock.Clock) echo.MiddlewareFunc {
+	var (
+		cleanupMu   sync.Mutex
+		lastCleanup time.Time
+	)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			clientKey := req.Header.Get(HeaderIdempotencyKey)
+			if clientKey == "" || !isMutatingMethod(req.Method) {
+				return next(c)
+			}
+
+			key, err := idempotency.NewKey(clientKey, req.Method, req.URL.Path)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error": err.Error(),
+				})
+			}
+
+			body, err := io.ReadAll(io.LimitReader(req.Body, maxIdempotentBodySize+1))
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error": "invalid request body",
+				})
+			}
+			if len(body) > maxIdempotentBodySize {
+				return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{
+					"error": "request body is too large",
+				})
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := req.Context()
+			now := clk.Now().UTC()
+			record := idempotency.NewRecord(key, fingerprint(req, body), now, ttl)
+
+			if err := repo.Create(ctx, record); err != nil {
+				if !errors.Is(err, idempotency.ErrKeyAlreadyExists) {
+					return err
+				}
+				return replay(c, repo, record)
+			}
+
+			// Capture the response so it can be replayed for retries
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			if err := next(c); err != nil {
+				release(ctx, repo, key)
+				return err
+			}
+
+			status := c.Response().Status
+			if status >= http.StatusInternalServerError {
+				// Server errors are not stored so the client can retry
+				release(ctx, repo, key)
+				return nil
+			}
+
+			record.Complete(status, c.Response().Header().Get(echo.HeaderContentType), recorder.body.Bytes())
+			if err := repo.Save(ctx, record); err != nil {
+				log.Printf("Error saving idempotency record: %v", err)
+			}
+
+			cleanupMu.Lock()
+			if now.Sub(lastCleanup) >= ttl {
+				lastCleanup = now
+				go func() {
+					if err := repo.DeleteExpired(context.Background(), now); err != nil {
+						log.Printf("Error deleting expired idempotency records: %v", err)
+					}
+				}()
+			}
+			cleanupMu.Unlock()
+
+			return nil
+		}
+	}
+}
+
+// replay responds to a retried request using the stored record
+func replay(c echo.Context, repo idempotency.Repository, incoming *idempotency.Record) error {
+	stored, err := repo.FindByKey(c.Request().Context(), incoming.Key())
+	if errors.Is(err, idempotency.ErrRecordNotFound) {
+		// The request holding the key failed and released it in the meantime; the client retries
+		return inProgress(c)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !stored.Fingerprint().Equals(incoming.Fingerprint()) {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "idempotency key has already been used with a different request",
+		})
+	}
+
+	if !stored.IsCompleted() {
+		return inProgress(c)
+	}
+
+	c.Response().Header().Set(HeaderIdempotencyReplayed, "true")
+	return c.Blob(stored.StatusCode(), stored.ContentType(), stored.Body())
+}
+
+// inProgress responds to a retry of a request that has not completed
+func inProgress(c echo.Context) error {
+	return c.JSON(http.StatusConflict, map[string]string{
+		"error": "a request with this idempotency key is still being processed",
+	})
+}
+
+// release removes an in-progress record so the request can be retried
+func release(ctx context.Context, repo idempotency.Repository, key idempotency.Key) {
+	if err := repo.Delete(ctx, key); err != nil {
+		log.Printf("Error releasing idempotency key: %v", err)
+	}
+}
+
+// fingerprint hashes the parts of the request that must match for a replay
+func fingerprint(req *http.Request, body []byte) idempotency.Fingerprint {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(req.URL.Path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return idempotency.NewFingerprint(hex.EncodeToString(hash.Sum(nil)))
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// responseRecorder copies the response body while writing it to the client
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
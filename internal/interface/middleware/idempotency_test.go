@@ -0,0 +1,228 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/idempotency"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/interface/middleware"
+)
+
+// Fake Idempotency Repository
+type fakeIdempotencyRepository struct {
+	mu      sync.Mutex
+	records map[string]*idempotency.Record
+
+	beforeFind  func() // called before a record is looked up
+	afterDelete func() // called once a record is deleted
+}
+
+func newFakeIdempotencyRepository() *fakeIdempotencyRepository {
+	return &fakeIdempotencyRepository{records: make(map[string]*idempotency.Record)}
+}
+
+func (r *fakeIdempotencyRepository) Create(ctx context.Context, rec *idempotency.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.records[rec.Key().String()]; ok && !existing.IsExpired(rec.CreatedAt()) {
+		return idempotency.ErrKeyAlreadyExists
+	}
+	r.records[rec.Key().String()] = rec
+	return nil
+}
+
+func (r *fakeIdempotencyRepository) Save(ctx context.Context, rec *idempotency.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records[rec.Key().String()] = rec
+	return nil
+}
+
+func (r *fakeIdempotencyRepository) FindByKey(ctx context.Context, key idempotency.Key) (*idempotency.Record, error) {
+	if r.beforeFind != nil {
+		r.beforeFind()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, ok := r.records[key.String()]
+	if !ok {
+		return nil, idempotency.ErrRecordNotFound
+	}
+	return rec, nil
+}
+
+func (r *fakeIdempotencyRepository) Delete(ctx context.Context, key idempotency.Key) error {
+	r.mu.Lock()
+	delete(r.records, key.String())
+	r.mu.Unlock()
+	if r.afterDelete != nil {
+		r.afterDelete()
+	}
+	return nil
+}
+
+func (r *fakeIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	return nil
+}
+
+func TestIdempotency(t *testing.T) {
+	type fixture struct {
+		e       *echo.Echo
+		repo    *fakeIdempotencyRepository
+		clock   *infrastructureClock.FakeClock
+		calls   int
+		handled chan struct{} // receives once the handler has been entered, when set
+		proceed chan struct{} // blocks the handler until closed, when set
+	}
+
+	newFixture := func(t *testing.T, status int) *fixture {
+		t.Helper()
+
+		f := &fixture{
+			e:     echo.New(),
+			repo:  newFakeIdempotencyRepository(),
+			clock: infrastructureClock.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)),
+		}
+		f.e.POST("/api/rooms", func(c echo.Context) error {
+			f.calls++
+			if f.proceed != nil {
+				f.handled <- struct{}{}
+				<-f.proceed
+			}
+			return c.JSON(status, map[string]int{"call": f.calls})
+		}, middleware.Idempotency(f.repo, time.Hour, f.clock))
+		return f
+	}
+
+	do := func(f *fixture, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/rooms", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(middleware.HeaderIdempotencyKey, key)
+		}
+		rec := httptest.NewRecorder()
+		f.e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("同じキーで再送された場合は最初のレスポンスが再生されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, http.StatusOK)
+
+		// act
+		first := do(f, "key-1", `{"a":1}`)
+		second := do(f, "key-1", `{"a":1}`)
+
+		// assert
+		if f.calls != 1 {
+			t.Errorf("Expected handler to be called once, got: %d", f.calls)
+		}
+		if first.Body.String() != second.Body.String() {
+			t.Errorf("Expected replayed body %q, got: %q", first.Body.String(), second.Body.String())
+		}
+		if second.Header().Get(middleware.HeaderIdempotencyReplayed) != "true" {
+			t.Error("Expected replayed header to be set")
+		}
+	})
+
+	t.Run("保持期間を過ぎたキーで再送された場合はハンドラーが再実行されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, http.StatusOK)
+		do(f, "key-1", `{"a":1}`)
+
+		// act
+		f.clock.Advance(time.Hour)
+		second := do(f, "key-1", `{"a":1}`)
+
+		// assert
+		if f.calls != 2 {
+			t.Errorf("Expected handler to be called twice, got: %d", f.calls)
+		}
+		if second.Header().Get(middleware.HeaderIdempotencyReplayed) != "" {
+			t.Error("Expected the expired response not to be replayed")
+		}
+	})
+
+	t.Run("同じキーで異なるボディの場合は409が返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, http.StatusOK)
+
+		// act
+		do(f, "key-1", `{"a":1}`)
+		second := do(f, "key-1", `{"a":2}`)
+
+		// assert
+		if second.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got: %d", second.Code)
+		}
+		if f.calls != 1 {
+			t.Errorf("Expected handler to be called once, got: %d", f.calls)
+		}
+	})
+
+	t.Run("キーがない場合は毎回ハンドラーが実行されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, http.StatusOK)
+
+		// act
+		do(f, "", `{}`)
+		do(f, "", `{}`)
+
+		// assert
+		if f.calls != 2 {
+			t.Errorf("Expected handler to be called twice, got: %d", f.calls)
+		}
+	})
+
+	t.Run("サーバーエラーの場合はレスポンスが保存されず再実行されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, http.StatusInternalServerError)
+
+		// act
+		do(f, "key-1", `{}`)
+		do(f, "key-1", `{}`)
+
+		// assert
+		if f.calls != 2 {
+			t.Errorf("Expected handler to be called twice, got: %d", f.calls)
+		}
+	})
+
+	t.Run("処理中のキーがサーバーエラーで解放された直後の再送には409が返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, http.StatusInternalServerError)
+		f.handled = make(chan struct{}, 1)
+		f.proceed = make(chan struct{})
+		released := make(chan struct{})
+		f.repo.afterDelete = func() { close(released) }
+		// The retry looks the key up only once the first request has failed and released it
+		f.repo.beforeFind = func() {
+			close(f.proceed)
+			<-released
+		}
+		first := make(chan *httptest.ResponseRecorder, 1)
+		go func() { first <- do(f, "key-1", `{}`) }()
+		<-f.handled
+
+		// act
+		second := do(f, "key-1", `{}`)
+
+		// assert
+		if second.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got: %d", second.Code)
+		}
+		if code := (<-first).Code; code != http.StatusInternalServerError {
+			t.Errorf("Expected the first request to fail with 500, got: %d", code)
+		}
+		if f.calls != 1 {
+			t.Errorf("Expected handler to be called once, got: %d", f.calls)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/chat"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
+	chatUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/chat"
+)
+
+// handleChatSend handles CHAT_SEND message
+func (h *Handler) handleChatSend(client *Client, data *ChatSendPayload) *commandError {
+	output, err := h.sendChatUseCase.Execute(context.Background(), chatUseCase.SendMessageInput{
+		RoomID: client.roomID,
+		UserID: client.userID,
+		Body:   data.Body,
+	})
+	if err != nil {
+		log.Printf("Error sending chat message: %v", err)
+		return &commandError{code: chatErrorCode(err), message: err.Error()}
+	}
+
+	h.hub.Broadcast(client.roomID, Message{
+		Type:    MessageTypeChatMessage,
+		Payload: toChatMessagePayload(output.Message),
+	})
+
+	return nil
+}
+
+// handleChatDelete handles CHAT_DELETE message
+func (h *Handler) handleChatDelete(client *Client, data *ChatDeletePayload) *commandError {
+	err := h.deleteChatUseCase.Execute(context.Background(), chatUseCase.DeleteMessageInput{
+		RoomID:    client.roomID,
+		UserID:    client.userID,
+		MessageID: data.MessageID,
+	})
+	if err != nil {
+		log.Printf("Error deleting chat message: %v", err)
+		return &commandError{code: chatErrorCode(err), message: err.Error()}
+	}
+
+	h.hub.Broadcast(client.roomID, Message{
+		Type:    MessageTypeChatDeleted,
+		Payload: ChatDeletedPayload{MessageID: data.MessageID},
+	})
+
+	return nil
+}
+
+// sendChatHistory sends the recent chat messages of the room to a specific client
+func (h *Handler) sendChatHistory(client *Client) {
+	output, err := h.fetchChatHistoryUseCase.Execute(context.Background(), chatUseCase.FetchHistoryInput{
+		RoomID: client.roomID,
+	})
+	if err != nil {
+		log.Printf("Error fetching chat history: %v", err)
+		return
+	}
+
+	messages := make([]ChatMessagePayload, 0, len(output.Messages))
+	for _, m := range output.Messages {
+		messages = append(messages, toChatMessagePayload(m))
+	}
+
+	h.sendMessage(client, Message{
+		Type:    MessageTypeChatHistory,
+		Payload: ChatHistoryPayload{Messages: messages},
+	})
+}
+
+func toChatMessagePayload(m chatUseCase.MessageInfo) ChatMessagePayload {
+	return ChatMessagePayload{
+		MessageID: m.MessageID,
+		UserID:    m.UserID,
+		UserName:  m.UserName,
+		Body:      m.Body,
+		CreatedAt: m.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// chatErrorCode returns the client-facing error code for a chat error
+func chatErrorCode(err error) string {
+	if code := moderation.ErrorCode(err); code != "" {
+		return code
+	}
+	switch {
+	case errors.Is(err, chat.ErrRateLimited):
+		return "RATE_LIMITED"
+	case errors.Is(err, chat.ErrOnlyHostCanDelete):
+		return "FORBIDDEN"
+	case errors.Is(err, chat.ErrMessageNotFound), errors.Is(err, chat.ErrNotInRoom):
+		return "CHAT_MESSAGE_NOT_FOUND"
+	default:
+		return "CHAT_ERROR"
+	}
+}
@@ -0,0 +1,154 @@
+package websocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Encoding is the wire format of the messages of a connection
+type Encoding string
+
+const (
+	// EncodingJSON sends messages as JSON text frames
+	EncodingJSON Encoding = "json"
+	// EncodingMsgPack sends messages as MessagePack binary frames with the same structure as the JSON
+	EncodingMsgPack Encoding = "msgpack"
+)
+
+// ProtocolVersionMsgPack is the subprotocol of ProtocolVersion using MessagePack frames
+const ProtocolVersionMsgPack = ProtocolVersion + "+msgpack"
+
+// subprotocolEncodings maps the supported subprotocols to their encoding, in order of preference
+var subprotocolEncodings = []struct {
+	subprotocol string
+	encoding    Encoding
+}{
+	{ProtocolVersionMsgPack, EncodingMsgPack},
+	{ProtocolVersion, EncodingJSON},
+}
+
+// Subprotocols returns the subprotocols the server accepts, in order of preference
+func Subprotocols() []string {
+	subprotocols := make([]string, 0, len(subprotocolEncodings))
+	for _, entry := range subprotocolEncodings {
+		subprotocols = append(subprotocols, entry.subprotocol)
+	}
+	return subprotocols
+}
+
+// encodingOf returns the encoding of a negotiated subprotocol.
+// Connections without a subprotocol use JSON.
+func encodingOf(subprotocol string) Encoding {
+	for _, entry := range subprotocolEncodings {
+		if entry.subprotocol == subprotocol {
+			return entry.encoding
+		}
+	}
+	return EncodingJSON
+}
+
+// outbound is a message queued for a connection
+type outbound struct {
+	data     []byte                     // the encoded message, written as is to SSE streams
+	prepared *websocket.PreparedMessage // shared by the WebSocket connections with the same encoding
+	position StreamPosition             // seq and epoch of the message, the SSE event ID; Seq is 0 when it has none
+}
+
+// frame is a message to deliver, encoded at most once per encoding.
+// It is only used from the goroutine of the shard that owns its room.
+type frame struct {
+	json    *outbound
+	msgpack *outbound
+}
+
+// newFrame creates a frame from an encoded JSON message and its stream position
+func newFrame(data []byte, position StreamPosition) *frame {
+	f := &frame{json: newOutbound(websocket.TextMessage, data)}
+	f.json.position = position
+	return f
+}
+
+// newOutbound prepares an encoded message. The WebSocket frame, compressed or not,
+// is built on first write and then reused for every connection it is written to.
+func newOutbound(frameType int, data []byte) *outbound {
+	prepared, err := websocket.NewPreparedMessage(frameType, data)
+	if err != nil {
+		// Preparing only fails when compressing, which is deferred until the first write
+		log.Printf("Error preparing message: %v", err)
+	}
+	return &outbound{data: data, prepared: prepared}
+}
+
+// encode returns the message in the given encoding
+func (f *frame) encode(encoding Encoding) (*outbound, error) {
+	if encoding != EncodingMsgPack {
+		return f.json, nil
+	}
+	if f.msgpack == nil {
+		data, err := jsonToMsgPack(f.json.data)
+		if err != nil {
+			return nil, err
+		}
+		f.msgpack = newOutbound(websocket.BinaryMessage, data)
+		f.msgpack.position = f.json.position
+	}
+	return f.msgpack, nil
+}
+
+// jsonToMsgPack transcodes a JSON document to MessagePack.
+// Integers stay integers and map keys are sorted so the output is deterministic.
+func jsonToMsgPack(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := msgpack.NewEncoder(&buf)
+	encoder.SetSortMapKeys(true)
+	if err := encoder.Encode(normalizeNumbers(value)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// normalizeNumbers replaces json.Number values with int64 or float64
+func normalizeNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeNumbers(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// msgPackToJSON transcodes a MessagePack client frame to JSON so that it is
+// decoded and validated like a text frame
+func msgPackToJSON(data []byte) ([]byte, error) {
+	var value interface{}
+	if err := msgpack.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	return json.Marshal(value)
+}
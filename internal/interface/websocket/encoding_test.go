@@ -0,0 +1,161 @@
+package websocket
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	infrastructureBackplane "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/backplane"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestJSONToMsgPack(t *testing.T) {
+	t.Run("JSONと同じ構造で整数は整数のまま変換されること", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"type":"STATE_UPDATE","seq":3,"payload":{"nextState":"discussing","data":{"dummyIndex":2,"displayedEmojis":["🍎","🍌"]}}}`)
+
+		// act
+		encoded, err := jsonToMsgPack(data)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		var decoded struct {
+			Type    string `msgpack:"type"`
+			Seq     uint64 `msgpack:"seq"`
+			Payload struct {
+				Data struct {
+					DummyIndex      int      `msgpack:"dummyIndex"`
+					DisplayedEmojis []string `msgpack:"displayedEmojis"`
+				} `msgpack:"data"`
+			} `msgpack:"payload"`
+		}
+		if err := msgpack.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Expected valid MessagePack, got: %v", err)
+		}
+		if decoded.Type != "STATE_UPDATE" || decoded.Seq != 3 || decoded.Payload.Data.DummyIndex != 2 || len(decoded.Payload.Data.DisplayedEmojis) != 2 {
+			t.Errorf("Unexpected message: %+v", decoded)
+		}
+		if len(encoded) >= len(data) {
+			t.Errorf("Expected MessagePack to be smaller than JSON, got: %d >= %d bytes", len(encoded), len(data))
+		}
+	})
+}
+
+func TestHubEncodings(t *testing.T) {
+	t.Run("同じエンコーディングのクライアントには1回だけエンコードした同じフレームが届くこと", func(t *testing.T) {
+		// arrange
+		hub := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, infrastructureBackplane.NewInMemoryBackplane(), infrastructureClock.NewSystemClock())
+		go hub.Run()
+		first := &Client{send: make(chan *outbound, 10), roomID: "room-1", encoding: EncodingMsgPack}
+		second := &Client{send: make(chan *outbound, 10), roomID: "room-1", encoding: EncodingMsgPack}
+		text := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+		hub.Register(first, nil)
+		hub.Register(second, nil)
+		hub.Register(text, nil)
+
+		// act
+		hub.Broadcast("room-1", Message{Type: MessageTypeTimerTick, Payload: TimerTickPayload{Time: "04:59"}})
+		a, b, c := <-first.send, <-second.send, <-text.send
+
+		// assert
+		if a != b || a.prepared == nil {
+			t.Error("Expected MessagePack clients to share the prepared frame")
+		}
+		if !strings.HasPrefix(string(c.data), "{") {
+			t.Errorf("Expected JSON for the default encoding, got: %q", c.data)
+		}
+	})
+}
+
+func TestHandleWebSocketEncoding(t *testing.T) {
+	newServer := func(t *testing.T) (*Hub, string) {
+		t.Helper()
+
+		cfg := Config{
+			ReplayBufferSize:  10,
+			ReplayRetention:   time.Minute,
+			PingInterval:      time.Minute,
+			PongWait:          2 * time.Minute,
+			WriteWait:         time.Second,
+			MaxMessageSize:    1024,
+			EnableCompression: true,
+		}
+		hub := NewHub(cfg, infrastructureBackplane.NewInMemoryBackplane(), infrastructureClock.NewSystemClock())
+		go hub.Run()
+		h := NewHandler(hub, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cfg)
+
+		e := echo.New()
+		e.GET("/ws", h.HandleWebSocket)
+		server := httptest.NewServer(e)
+		t.Cleanup(server.Close)
+		return hub, "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?room_id=room-1"
+	}
+
+	t.Run("MessagePackを指定するとバイナリフレームで送受信できること", func(t *testing.T) {
+		// arrange
+		_, url := newServer(t)
+		dialer := websocket.Dialer{
+			Subprotocols:      []string{ProtocolVersionMsgPack},
+			EnableCompression: true,
+		}
+		conn, _, err := dialer.Dial(url, nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		defer conn.Close()
+		ping, _ := msgpack.Marshal(map[string]string{"type": "PING", "request_id": "req-1"})
+
+		// act
+		err = conn.WriteMessage(websocket.BinaryMessage, ping)
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		frameType, data, readErr := conn.ReadMessage()
+
+		// assert
+		if err != nil || readErr != nil {
+			t.Fatalf("Expected a reply, got: %v %v", err, readErr)
+		}
+		if conn.Subprotocol() != ProtocolVersionMsgPack {
+			t.Errorf("Expected %s to be negotiated, got: %q", ProtocolVersionMsgPack, conn.Subprotocol())
+		}
+		var reply struct {
+			Type      string `msgpack:"type"`
+			RequestID string `msgpack:"request_id"`
+		}
+		if frameType != websocket.BinaryMessage || msgpack.Unmarshal(data, &reply) != nil {
+			t.Fatalf("Expected a MessagePack binary frame, got type %d: %q", frameType, data)
+		}
+		if reply.Type != "PONG" || reply.RequestID != "req-1" {
+			t.Errorf("Expected PONG for req-1, got: %+v", reply)
+		}
+	})
+
+	t.Run("サブプロトコルを指定しない場合はJSONのテキストフレームが届くこと", func(t *testing.T) {
+		// arrange
+		hub, url := newServer(t)
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		defer conn.Close()
+		hub.Register(&Client{send: make(chan *outbound, 10), roomID: "room-1"}, nil) // wait for the connection to join
+
+		// act
+		hub.Broadcast("room-1", Message{Type: MessageTypeTimerTick, Payload: TimerTickPayload{Time: "04:59"}})
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		frameType, data, err := conn.ReadMessage()
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected a message, got: %v", err)
+		}
+		if frameType != websocket.TextMessage || !strings.Contains(string(data), `"TIMER_TICK"`) {
+			t.Errorf("Expected a JSON text frame, got type %d: %q", frameType, data)
+		}
+	})
+}
@@ -22,6 +22,17 @@ func (h *Handler) SetupEventHandlers(eventPublisher event.Publisher) {
 		h.handleGameStartedEvent(gameStartedEvt)
 	})
 
+	// Subscribe to DiscussionStartedEvent
+	eventPublisher.Subscribe("DiscussionStarted", func(evt event.Event) {
+		discussionStartedEvt, ok := evt.(*event.DiscussionStartedEvent)
+		if !ok {
+			log.Printf("Invalid event type for DiscussionStarted")
+			return
+		}
+
+		h.handleDiscussionStartedEvent(discussionStartedEvt)
+	})
+
 	// Subscribe to DiscussionSkippedEvent
 	eventPublisher.Subscribe("DiscussionSkipped", func(evt event.Event) {
 		discussionSkippedEvt, ok := evt.(*event.DiscussionSkippedEvent)
@@ -54,6 +65,32 @@ func (h *Handler) SetupEventHandlers(eventPublisher event.Publisher) {
 
 		h.handleGameFinishedEvent(gameFinishedEvt)
 	})
+
+	// Subscribe to RoomDeletedEvent
+	eventPublisher.Subscribe("RoomDeleted", func(evt event.Event) {
+		roomDeletedEvt, ok := evt.(*event.RoomDeletedEvent)
+		if !ok {
+			log.Printf("Invalid event type for RoomDeleted")
+			return
+		}
+
+		h.handleRoomDeletedEvent(roomDeletedEvt)
+	})
+
+	// Subscribe to the fallbacks applied when a phase deadline passes
+	h.subscribePhaseTimeouts(eventPublisher)
+
+	// Every instance follows the countdown, so each stops its own copy when the room leaves
+	// discussing, including when an operator forces it back to setting_topic
+	subscribeAll := eventPublisher.Subscribe
+	if fanOut, ok := eventPublisher.(event.FanOutSubscriber); ok {
+		subscribeAll = fanOut.SubscribeAll
+	}
+	for _, eventType := range []string{"GameStarted", "DiscussionSkipped", "AnswerSubmitted", "GameFinished", "RoomDeleted"} {
+		subscribeAll(eventType, func(evt event.Event) {
+			h.timer.stopLocal(evt.AggregateID())
+		})
+	}
 }
 
 // handleGameStartedEvent handles GameStartedEvent and broadcasts STATE_UPDATE
@@ -75,24 +112,23 @@ func (h *Handler) handleGameStartedEvent(evt *event.GameStartedEvent) {
 		Type: MessageTypeStateUpdate,
 		Payload: StateUpdatePayload{
 			NextState: foundRoom.Status().String(), // "setting_topic"
-			Data:      &StateUpdateDataPayload{},
+			Data: &StateUpdateDataPayload{
+				PhaseDeadline: phaseDeadlinePayload(foundRoom, h.timer.clock.Now()),
+			},
 		},
 	})
 }
 
-// handleDiscussionSkippedEvent handles DiscussionSkippedEvent and broadcasts STATE_UPDATE
-func (h *Handler) handleDiscussionSkippedEvent(evt *event.DiscussionSkippedEvent) {
+// handleDiscussionStartedEvent handles DiscussionStartedEvent, broadcasts STATE_UPDATE and starts the timer
+func (h *Handler) handleDiscussionStartedEvent(evt *event.DiscussionStartedEvent) {
 	ctx := context.Background()
 
-	// Stop timer
-	h.timer.StopTimer(evt.RoomID)
-
 	// Fetch room for broadcasting
 	roomOutput, err := h.fetchRoomUseCase.Execute(ctx, roomUseCase.FetchRoomInput{
 		RoomID: evt.RoomID,
 	})
 	if err != nil {
-		log.Printf("Error fetching room for DiscussionSkippedEvent: %v", err)
+		log.Printf("Error fetching room for DiscussionStartedEvent: %v", err)
 		return
 	}
 	foundRoom := roomOutput.Room
@@ -129,6 +165,73 @@ func (h *Handler) handleDiscussionSkippedEvent(evt *event.DiscussionSkippedEvent
 		assignmentsSlice = foundRoom.Assignments().Values()
 	}
 
+	// Broadcast STATE_UPDATE with discussing status
+	h.hub.Broadcast(evt.RoomID, Message{
+		Type: MessageTypeStateUpdate,
+		Payload: StateUpdatePayload{
+			NextState: foundRoom.Status().String(), // "discussing"
+			Data: &StateUpdateDataPayload{
+				Topic:           topicStr,
+				DisplayedEmojis: displayedEmojisSlice,
+				OriginalEmojis:  originalEmojisSlice,
+				DummyIndex:      dummyIdxPtr,
+				DummyEmoji:      dummyEmojiStr,
+				Deadline:        deadlinePayload(foundRoom, h.timer.clock.Now()),
+				PhaseDeadline:   phaseDeadlinePayload(foundRoom, h.timer.clock.Now()),
+			},
+		},
+	})
+
+	// Tell each player their emoji privately
+	h.sendAssignments(evt.RoomID, assignmentsSlice)
+
+	// Start the countdown from the deadline persisted with the room
+	if deadline := foundRoom.DiscussionDeadline(); deadline != nil {
+		h.timer.StartTimer(evt.RoomID, *deadline)
+	}
+}
+
+// handleDiscussionSkippedEvent handles DiscussionSkippedEvent and broadcasts STATE_UPDATE
+func (h *Handler) handleDiscussionSkippedEvent(evt *event.DiscussionSkippedEvent) {
+	ctx := context.Background()
+
+	// Fetch room for broadcasting
+	roomOutput, err := h.fetchRoomUseCase.Execute(ctx, roomUseCase.FetchRoomInput{
+		RoomID: evt.RoomID,
+	})
+	if err != nil {
+		log.Printf("Error fetching room for DiscussionSkippedEvent: %v", err)
+		return
+	}
+	foundRoom := roomOutput.Room
+
+	// Build state data payload
+	topicStr := ""
+	if foundRoom.Topic() != nil {
+		topicStr = foundRoom.Topic().String()
+	}
+
+	var dummyIdxPtr *int
+	if foundRoom.DummyIndex() != nil {
+		val := foundRoom.DummyIndex().Value()
+		dummyIdxPtr = &val
+	}
+
+	dummyEmojiStr := ""
+	if foundRoom.DummyEmoji() != nil {
+		dummyEmojiStr = foundRoom.DummyEmoji().String()
+	}
+
+	displayedEmojisSlice := []string{}
+	if foundRoom.DisplayedEmojis() != nil {
+		displayedEmojisSlice = foundRoom.DisplayedEmojis().Values()
+	}
+
+	originalEmojisSlice := []string{}
+	if foundRoom.OriginalEmojis() != nil {
+		originalEmojisSlice = foundRoom.OriginalEmojis().Values()
+	}
+
 	// Broadcast STATE_UPDATE with answering status
 	h.hub.Broadcast(evt.RoomID, Message{
 		Type: MessageTypeStateUpdate,
@@ -140,7 +243,7 @@ func (h *Handler) handleDiscussionSkippedEvent(evt *event.DiscussionSkippedEvent
 				OriginalEmojis:  originalEmojisSlice,
 				DummyIndex:      dummyIdxPtr,
 				DummyEmoji:      dummyEmojiStr,
-				Assignments:     assignmentsSlice,
+				PhaseDeadline:   phaseDeadlinePayload(foundRoom, h.timer.clock.Now()),
 			},
 		},
 	})
@@ -197,11 +300,6 @@ func (h *Handler) handleAnswerSubmittedEvent(evt *event.AnswerSubmittedEvent) {
 		originalEmojisSlice = foundRoom.OriginalEmojis().Values()
 	}
 
-	assignmentsSlice := []string{}
-	if foundRoom.Assignments() != nil {
-		assignmentsSlice = foundRoom.Assignments().Values()
-	}
-
 	answerStr := ""
 	if foundRoom.Answer() != nil {
 		answerStr = foundRoom.Answer().String()
@@ -216,11 +314,12 @@ func (h *Handler) handleAnswerSubmittedEvent(evt *event.AnswerSubmittedEvent) {
 				Theme:           themeStr,
 				Topic:           topicStr,
 				Answer:          answerStr,
+				AnswerCorrect:   foundRoom.AnswerCorrect(),
 				DisplayedEmojis: displayedEmojisSlice,
 				OriginalEmojis:  originalEmojisSlice,
 				DummyIndex:      dummyIdxPtr,
 				DummyEmoji:      dummyEmojiStr,
-				Assignments:     assignmentsSlice,
+				PhaseDeadline:   phaseDeadlinePayload(foundRoom, h.timer.clock.Now()),
 			},
 		},
 	})
@@ -279,11 +378,6 @@ func (h *Handler) handleGameFinishedEvent(evt *event.GameFinishedEvent) {
 		originalEmojisSlice = foundRoom.OriginalEmojis().Values()
 	}
 
-	assignmentsSlice := []string{}
-	if foundRoom.Assignments() != nil {
-		assignmentsSlice = foundRoom.Assignments().Values()
-	}
-
 	answerStr := ""
 	if foundRoom.Answer() != nil {
 		answerStr = foundRoom.Answer().String()
@@ -298,14 +392,27 @@ func (h *Handler) handleGameFinishedEvent(evt *event.GameFinishedEvent) {
 				Theme:           themeStr,
 				Topic:           topicStr,
 				Answer:          answerStr,
+				AnswerCorrect:   foundRoom.AnswerCorrect(),
 				DisplayedEmojis: displayedEmojisSlice,
 				OriginalEmojis:  originalEmojisSlice,
 				DummyIndex:      dummyIdxPtr,
 				DummyEmoji:      dummyEmojiStr,
-				Assignments:     assignmentsSlice,
+				PhaseDeadline:   phaseDeadlinePayload(foundRoom, h.timer.clock.Now()),
 			},
 		},
 	})
 
 	log.Printf("Game finished event broadcasted for room %s with answer: %s, theme: %s", evt.RoomID, answerStr, themeStr)
 }
+
+// handleRoomDeletedEvent handles RoomDeletedEvent, broadcasts ROOM_CLOSED and
+// drops the backplane counters of the room
+func (h *Handler) handleRoomDeletedEvent(evt *event.RoomDeletedEvent) {
+	h.hub.Broadcast(evt.RoomID, Message{
+		Type: MessageTypeRoomClosed,
+		Payload: RoomClosedPayload{
+			Reason: "deleted",
+		},
+	})
+	h.hub.ForgetRoom(evt.RoomID)
+}
@@ -0,0 +1,78 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	infrastructureBackplane "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/backplane"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	infrastructureEvent "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/event"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
+)
+
+func TestSetupEventHandlers(t *testing.T) {
+	type fixture struct {
+		clock          *infrastructureClock.FakeClock
+		timer          *Timer
+		eventPublisher *infrastructureEvent.InMemoryEventPublisher
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		hub := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, bp, infrastructureClock.NewSystemClock())
+		go hub.Run()
+
+		f := &fixture{
+			clock:          infrastructureClock.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)),
+			eventPublisher: infrastructureEvent.NewInMemoryEventPublisher(),
+		}
+		f.timer = NewTimer(hub, bp, newMemoryLeases(), f.clock, time.Minute)
+		go f.timer.Run()
+
+		h := &Handler{
+			hub:              hub,
+			timer:            f.timer,
+			fetchRoomUseCase: roomUseCase.NewFetchRoomUseCase(persistence.NewInMemoryRoomRepository(persistence.NewMemoryStore())),
+		}
+		h.SetupEventHandlers(f.eventPublisher)
+		return f
+	}
+
+	// waitStopped waits until the timer no longer schedules the countdown of a room
+	waitStopped := func(t *testing.T, timer *Timer, roomID string) bool {
+		t.Helper()
+
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			timer.mu.Lock()
+			_, running := timer.timers[roomID]
+			timer.mu.Unlock()
+			if !running {
+				return true
+			}
+			time.Sleep(time.Millisecond)
+		}
+		return false
+	}
+
+	t.Run("議論中からお題設定に強制遷移するとカウントダウンが停止すること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		roomID := room.NewRoomID().String()
+		f.timer.StartTimer(roomID, room.NewDiscussionDeadline(f.clock.Now().Add(room.DiscussionStartDelay), room.DiscussionDuration))
+		waitOwned(t, f.timer, roomID)
+
+		// act
+		f.eventPublisher.Publish(event.NewGameStartedEvent(roomID, f.clock.Now()))
+
+		// assert
+		if !waitStopped(t, f.timer, roomID) {
+			t.Error("Expected the countdown to be stopped")
+		}
+	})
+}
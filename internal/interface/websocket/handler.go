@@ -3,143 +3,74 @@ package websocket
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/theme"
+	chatUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/chat"
 	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// Allow connections from localhost and local network
-		origin := r.Header.Get("Origin")
-		allowedOrigins := []string{
-			"http://localhost:3000",
-			"http://localhost:3001",
-			"http://172.16.30.111:3000",
-			"http://172.16.30.111:3001",
-		}
-
-		if origin == "" {
-			return true
-		}
-
-		for _, allowed := range allowedOrigins {
-			if origin == allowed {
-				return true
-			}
-		}
-
-		return false
-	},
-}
-
-// Client represents a WebSocket client
-type Client struct {
-	conn   *websocket.Conn
-	send   chan []byte
-	roomID string
-	userID string
-}
-
-// Hub maintains active clients and broadcasts messages
-type Hub struct {
-	clients    map[string]map[*Client]bool // roomID -> clients
-	broadcast  chan BroadcastMessage
-	register   chan *Client
-	unregister chan *Client
-	mu         sync.RWMutex
-}
-
-// BroadcastMessage represents a message to broadcast
-type BroadcastMessage struct {
-	RoomID  string
-	Message []byte
-}
-
-// NewHub creates a new Hub
-func NewHub() *Hub {
-	return &Hub{
-		clients:    make(map[string]map[*Client]bool),
-		broadcast:  make(chan BroadcastMessage),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
-	}
-}
-
-// Run starts the hub
-func (h *Hub) Run() {
-	for {
-		select {
-		case client := <-h.register:
-			h.mu.Lock()
-			if h.clients[client.roomID] == nil {
-				h.clients[client.roomID] = make(map[*Client]bool)
-			}
-			h.clients[client.roomID][client] = true
-			h.mu.Unlock()
-
-		case client := <-h.unregister:
-			h.mu.Lock()
-			if clients, ok := h.clients[client.roomID]; ok {
-				if _, ok := clients[client]; ok {
-					delete(clients, client)
-					close(client.send)
-					if len(clients) == 0 {
-						delete(h.clients, client.roomID)
-					}
-				}
-			}
-			h.mu.Unlock()
-
-		case message := <-h.broadcast:
-			h.mu.RLock()
-			clients := h.clients[message.RoomID]
-			h.mu.RUnlock()
-
-			for client := range clients {
-				select {
-				case client.send <- message.Message:
-				default:
-					close(client.send)
-					h.mu.Lock()
-					delete(h.clients[message.RoomID], client)
-					h.mu.Unlock()
-				}
-			}
-		}
-	}
-}
-
-// Broadcast sends a message to all clients in a room
-func (h *Hub) Broadcast(roomID string, message Message) {
-	data, err := json.Marshal(message)
-	if err != nil {
-		log.Printf("Error marshaling message: %v", err)
-		return
-	}
-
-	h.broadcast <- BroadcastMessage{
-		RoomID:  roomID,
-		Message: data,
-	}
+// Config represents WebSocket configuration
+type Config struct {
+	// CheckOrigin reports whether the upgrade request origin is allowed
+	CheckOrigin func(r *http.Request) bool
+	// ReplayBufferSize is the number of broadcast messages kept per room for resuming clients
+	ReplayBufferSize int
+	// ReplayRetention is how long the replay buffer of a room without clients is kept
+	ReplayRetention time.Duration
+	// PingInterval is how often the server pings each connection
+	PingInterval time.Duration
+	// PongWait is how long a connection may stay silent before it is considered dead
+	PongWait time.Duration
+	// WriteWait is the time allowed to write a message to a connection
+	WriteWait time.Duration
+	// PresenceGracePeriod is how long a user stays online after their last connection closes
+	PresenceGracePeriod time.Duration
+	// MaxMessageSize is the largest frame in bytes accepted from a client
+	MaxMessageSize int64
+	// EnableCompression negotiates permessage-deflate with clients that support it
+	EnableCompression bool
+	// HubShards is the number of goroutines rooms are partitioned across (defaults to the CPU count)
+	HubShards int
+	// HubQueueSize is the number of deliveries each hub shard queues before dropping messages
+	HubQueueSize int
+	// TimerLeaseTTL is how long a room countdown stays owned by an instance without a heartbeat
+	TimerLeaseTTL time.Duration
 }
 
 // Handler handles WebSocket connections
 type Handler struct {
-	hub                       *Hub
-	timer                     *Timer
-	fetchRoomUseCase          *roomUseCase.FetchRoomUseCase
-	fetchParticipantsUseCase  *roomUseCase.FetchRoomParticipantsUseCase
-	startDiscussionUseCase    *roomUseCase.StartDiscussionUseCase
-	submitFinalAnswerUseCase  *roomUseCase.SubmitFinalAnswerUseCase
-	themeRepo                 theme.Repository
+	upgrader                 websocket.Upgrader
+	sendBufferSize           int
+	pingInterval             time.Duration
+	pongWait                 time.Duration
+	writeWait                time.Duration
+	maxMessageSize           int64
+	writers                  sync.WaitGroup // connections still writing to their client
+	hub                      *Hub
+	timer                    *Timer
+	fetchRoomUseCase         *roomUseCase.FetchRoomUseCase
+	fetchDiscussingUseCase   *roomUseCase.FetchDiscussingRoomsUseCase
+	fetchParticipantsUseCase *roomUseCase.FetchRoomParticipantsUseCase
+	startDiscussionUseCase   *roomUseCase.StartDiscussionUseCase
+	submitFinalAnswerUseCase *roomUseCase.SubmitFinalAnswerUseCase
+	themeRepo                theme.Repository
+	participantRepo          participant.Repository
+	sendChatUseCase          *chatUseCase.SendMessageUseCase
+	fetchChatHistoryUseCase  *chatUseCase.FetchHistoryUseCase
+	deleteChatUseCase        *chatUseCase.DeleteMessageUseCase
+	sendReactionUseCase      *roomUseCase.SendReactionUseCase
 }
 
 // NewHandler creates a new WebSocket handler
@@ -147,20 +78,56 @@ func NewHandler(
 	hub *Hub,
 	timer *Timer,
 	fetchRoomUseCase *roomUseCase.FetchRoomUseCase,
+	fetchDiscussingUseCase *roomUseCase.FetchDiscussingRoomsUseCase,
 	fetchParticipantsUseCase *roomUseCase.FetchRoomParticipantsUseCase,
 	startDiscussionUseCase *roomUseCase.StartDiscussionUseCase,
 	submitFinalAnswerUseCase *roomUseCase.SubmitFinalAnswerUseCase,
 	themeRepo theme.Repository,
+	participantRepo participant.Repository,
+	sendChatUseCase *chatUseCase.SendMessageUseCase,
+	fetchChatHistoryUseCase *chatUseCase.FetchHistoryUseCase,
+	deleteChatUseCase *chatUseCase.DeleteMessageUseCase,
+	sendReactionUseCase *roomUseCase.SendReactionUseCase,
+	cfg Config,
 ) *Handler {
-	return &Handler{
-		hub:                       hub,
-		timer:                     timer,
-		fetchRoomUseCase:          fetchRoomUseCase,
-		fetchParticipantsUseCase:  fetchParticipantsUseCase,
-		startDiscussionUseCase:    startDiscussionUseCase,
-		submitFinalAnswerUseCase:  submitFinalAnswerUseCase,
-		themeRepo:                 themeRepo,
-	}
+	// Pings must be sent often enough to arrive before the peer's read deadline
+	pingInterval := cfg.PingInterval
+	if pingInterval <= 0 || pingInterval >= cfg.PongWait {
+		pingInterval = cfg.PongWait * 9 / 10
+	}
+
+	h := &Handler{
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:    1024,
+			WriteBufferSize:   1024,
+			CheckOrigin:       cfg.CheckOrigin,
+			Subprotocols:      Subprotocols(),
+			EnableCompression: cfg.EnableCompression,
+		},
+		sendBufferSize:           max(256, cfg.ReplayBufferSize),
+		pingInterval:             pingInterval,
+		pongWait:                 cfg.PongWait,
+		writeWait:                cfg.WriteWait,
+		maxMessageSize:           cfg.MaxMessageSize,
+		hub:                      hub,
+		timer:                    timer,
+		fetchRoomUseCase:         fetchRoomUseCase,
+		fetchDiscussingUseCase:   fetchDiscussingUseCase,
+		fetchParticipantsUseCase: fetchParticipantsUseCase,
+		startDiscussionUseCase:   startDiscussionUseCase,
+		submitFinalAnswerUseCase: submitFinalAnswerUseCase,
+		themeRepo:                themeRepo,
+		participantRepo:          participantRepo,
+		sendChatUseCase:          sendChatUseCase,
+		fetchChatHistoryUseCase:  fetchChatHistoryUseCase,
+		deleteChatUseCase:        deleteChatUseCase,
+		sendReactionUseCase:      sendReactionUseCase,
+	}
+
+	// Let the room know when a participant goes offline
+	hub.OnPresenceChange(h.broadcastParticipantUpdate)
+
+	return h
 }
 
 // HandleWebSocket handles WebSocket connections
@@ -169,129 +136,274 @@ func (h *Handler) HandleWebSocket(c echo.Context) error {
 	if roomID == "" {
 		return echo.NewHTTPError(400, "room_id is required")
 	}
+	if h.hub.closing.Load() {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, restartCloseReason)
+	}
+
+	// last_seq and epoch are sent by reconnecting clients to receive the messages they missed
+	var from *StreamPosition
+	if value := c.QueryParam("last_seq"); value != "" {
+		seq, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return echo.NewHTTPError(400, "last_seq must be a non-negative integer")
+		}
+		from = &StreamPosition{Epoch: c.QueryParam("epoch"), Seq: seq}
+	}
 
-	conn, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
+	// Clients that offer subprotocols must speak a version this server supports;
+	// clients that offer none are treated as the current version with JSON frames
+	if offered := websocket.Subprotocols(c.Request()); len(offered) > 0 && !slices.ContainsFunc(offered, func(p string) bool {
+		return slices.Contains(Subprotocols(), p)
+	}) {
+		return echo.NewHTTPError(400, "unsupported protocol version, supported: "+strings.Join(Subprotocols(), ", "))
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Response(), c.Request(), nil)
 	if err != nil {
 		log.Printf("WebSocket upgrade error: %v", err)
 		return err
 	}
 
 	client := &Client{
-		conn:   conn,
-		send:   make(chan []byte, 256),
-		roomID: roomID,
+		conn:     conn,
+		send:     make(chan *outbound, h.sendBufferSize),
+		encoding: encodingOf(conn.Subprotocol()),
+		roomID:   roomID,
 	}
 
-	h.hub.register <- client
+	client.resumed = h.hub.Register(client, from)
 
+	h.writers.Add(1)
 	go h.writePump(client)
 	go h.readPump(client)
 
 	return nil
 }
 
+// RestoreTimers resumes the countdowns of the rooms in discussing from their
+// persisted deadlines. It returns the number of countdowns resumed.
+func (h *Handler) RestoreTimers(ctx context.Context) (int, error) {
+	output, err := h.fetchDiscussingUseCase.Execute(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	restored := 0
+	for _, rm := range output.Rooms {
+		if h.timer.Restore(rm.ID().String(), *rm.DiscussionDeadline()) {
+			restored++
+		}
+	}
+	return restored, nil
+}
+
+// Shutdown disconnects every WebSocket and SSE client with a close message
+// asking it to reconnect, and waits until the messages have been written
+func (h *Handler) Shutdown(ctx context.Context) error {
+	if err := h.hub.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		h.writers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // readPump reads messages from the WebSocket connection
 func (h *Handler) readPump(client *Client) {
 	defer func() {
-		h.hub.unregister <- client
+		h.hub.Unregister(client)
 		client.conn.Close()
+		if client.userID != "" {
+			h.hub.MarkOffline(client.roomID, client.userID)
+		}
 	}()
 
+	// Oversized frames close the connection with 1009 (message too big)
+	client.conn.SetReadLimit(h.maxMessageSize)
+
+	// The connection is considered dead unless a pong or message arrives before the deadline
+	client.conn.SetReadDeadline(time.Now().Add(h.pongWait))
+	client.conn.SetPongHandler(func(string) error {
+		return client.conn.SetReadDeadline(time.Now().Add(h.pongWait))
+	})
+
 	for {
-		_, message, err := client.conn.ReadMessage()
+		messageType, message, err := client.conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("WebSocket error: %v", err)
 			}
 			break
 		}
+		client.conn.SetReadDeadline(time.Now().Add(h.pongWait))
+
+		if messageType == websocket.BinaryMessage {
+			// Binary frames are MessagePack with the same structure as the JSON messages
+			if message, err = msgPackToJSON(message); err != nil {
+				h.sendError(client, "", decodeErrorCode(err), err.Error())
+				continue
+			}
+		}
 
 		h.handleMessage(client, message)
 	}
 }
 
-// writePump writes messages to the WebSocket connection
+// writePump writes messages and periodic pings to the WebSocket connection
 func (h *Handler) writePump(client *Client) {
+	ticker := time.NewTicker(h.pingInterval)
 	defer func() {
+		ticker.Stop()
 		client.conn.Close()
+		h.writers.Done()
 	}()
 
-	for message := range client.send {
-		if err := client.conn.WriteMessage(websocket.TextMessage, message); err != nil {
-			log.Printf("WebSocket write error: %v", err)
-			return
+	for {
+		select {
+		case message, ok := <-client.send:
+			client.conn.SetWriteDeadline(time.Now().Add(h.writeWait))
+			if !ok {
+				// The hub closed the channel
+				closeMessage := []byte{}
+				if client.closeCode != 0 {
+					closeMessage = websocket.FormatCloseMessage(client.closeCode, client.closeReason)
+				}
+				client.conn.WriteMessage(websocket.CloseMessage, closeMessage)
+				return
+			}
+			// Connections with the same encoding and compression share one frame
+			if err := client.conn.WritePreparedMessage(message.prepared); err != nil {
+				log.Printf("WebSocket write error: %v", err)
+				return
+			}
+
+		case <-ticker.C:
+			client.conn.SetWriteDeadline(time.Now().Add(h.writeWait))
+			if err := client.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
 		}
 	}
 }
 
-// handleMessage processes incoming WebSocket messages
+// handleMessage processes incoming WebSocket messages.
+// Commands sent with a request_id are answered with an ACK or an ERROR echoing it.
 func (h *Handler) handleMessage(client *Client, data []byte) {
-	var msg Message
-	if err := json.Unmarshal(data, &msg); err != nil {
-		log.Printf("Error unmarshaling message: %v", err)
-		h.sendError(client, "INVALID_MESSAGE", "Invalid message format")
+	command, err := decodeMessage(data)
+	if err != nil {
+		log.Printf("Error decoding message: %v", err)
+		h.sendError(client, command.RequestID, decodeErrorCode(err), err.Error())
+		return
+	}
+
+	log.Printf("[WS RECEIVED] Type: %s, RoomID: %s, UserID: %s", command.Type, client.roomID, client.userID)
+
+	if command.Type == MessageTypePing {
+		// Application-level heartbeat for clients that cannot see protocol pings
+		h.sendMessage(client, Message{Type: MessageTypePong, RequestID: command.RequestID})
 		return
 	}
 
-	log.Printf("[WS RECEIVED] Type: %s, RoomID: %s, UserID: %s", msg.Type, client.roomID, client.userID)
+	if err := h.dispatch(client, command); err != nil {
+		h.sendError(client, command.RequestID, err.code, err.message)
+		return
+	}
 
-	switch msg.Type {
+	if command.RequestID != "" {
+		h.sendMessage(client, Message{
+			Type:      MessageTypeAck,
+			RequestID: command.RequestID,
+			Payload:   AckPayload{Type: command.Type},
+		})
+	}
+}
+
+// commandError is the failure of a client command, reported to the sender as ERROR
+type commandError struct {
+	code    string
+	message string
+}
+
+// dispatch runs the handler of a command
+func (h *Handler) dispatch(client *Client, command Command) *commandError {
+	switch command.Type {
 	case MessageTypeClientConnected:
-		h.handleClientConnected(client, msg.Payload)
+		return h.handleClientConnected(client, command.Payload.(*ClientConnectedPayload))
 
 	case MessageTypeFetchParticipants:
-		h.handleFetchParticipants(client)
+		return h.handleFetchParticipants(client)
 
 	case MessageTypeSubmitTopic:
-		h.handleSubmitTopic(client, msg.Payload)
+		return h.handleSubmitTopic(client, command.Payload.(*SubmitTopicPayload))
 
 	case MessageTypeAnswering:
-		h.handleAnswering(client, msg.Payload)
+		return h.handleAnswering(client, command.Payload.(*AnsweringPayload))
+
+	case MessageTypeChatSend:
+		return h.handleChatSend(client, command.Payload.(*ChatSendPayload))
 
-	case "PING":
-		// Heartbeat message - just ignore, no response needed
-		// Client is checking if connection is alive
+	case MessageTypeChatDelete:
+		return h.handleChatDelete(client, command.Payload.(*ChatDeletePayload))
+
+	case MessageTypeSendReaction:
+		return h.handleSendReaction(client, command.Payload.(*SendReactionPayload))
 
 	default:
-		log.Printf("Unknown message type: %s", msg.Type)
-		h.sendError(client, "UNKNOWN_MESSAGE_TYPE", "Unknown message type: "+string(msg.Type))
+		return &commandError{code: "UNKNOWN_MESSAGE_TYPE", message: "Unknown message type: " + string(command.Type)}
 	}
 }
 
 // handleClientConnected handles CLIENT_CONNECTED message
-func (h *Handler) handleClientConnected(client *Client, payload interface{}) {
-	payloadBytes, _ := json.Marshal(payload)
-	var data ClientConnectedPayload
-	if err := json.Unmarshal(payloadBytes, &data); err != nil {
-		log.Printf("Error unmarshaling CLIENT_CONNECTED payload: %v", err)
-		h.sendError(client, "INVALID_PAYLOAD", "Invalid CLIENT_CONNECTED payload")
-		return
+func (h *Handler) handleClientConnected(client *Client, data *ClientConnectedPayload) *commandError {
+	// The private ASSIGNMENT is routed by this identity, so only participants of the room may claim it
+	identity, err := h.findIdentity(client.roomID, data.UserID)
+	if err != nil {
+		return &commandError{code: "NOT_A_PARTICIPANT", message: err.Error()}
 	}
 
+	if client.userID != data.UserID {
+		if client.userID != "" {
+			h.hub.MarkOffline(client.roomID, client.userID)
+		}
+		h.hub.MarkOnline(client.roomID, data.UserID)
+	}
 	client.userID = data.UserID
+	h.hub.Identify(client, identity)
 
-	// Send initial room state to the connected client
-	h.sendInitialRoomState(client)
+	// Send initial room state to the connected client unless it has
+	// already caught up by replaying the messages it missed
+	if client.resumed {
+		client.resumed = false
+	} else {
+		h.sendInitialRoomState(client)
+		h.sendChatHistory(client)
+	}
 
 	// Broadcast participant update
 	h.broadcastParticipantUpdate(client.roomID)
+
+	return nil
 }
 
 // handleFetchParticipants handles FETCH_PARTICIPANTS message
-func (h *Handler) handleFetchParticipants(client *Client) {
+func (h *Handler) handleFetchParticipants(client *Client) *commandError {
 	h.broadcastParticipantUpdate(client.roomID)
+
+	return nil
 }
 
 // handleSubmitTopic handles SUBMIT_TOPIC message
-func (h *Handler) handleSubmitTopic(client *Client, payload interface{}) {
+func (h *Handler) handleSubmitTopic(client *Client, data *SubmitTopicPayload) *commandError {
 	log.Printf("[WS SUBMIT_TOPIC] Received from client in room: %s", client.roomID)
-	payloadBytes, _ := json.Marshal(payload)
-	var data SubmitTopicPayload
-	if err := json.Unmarshal(payloadBytes, &data); err != nil {
-		log.Printf("Error unmarshaling SUBMIT_TOPIC payload: %v", err)
-		h.sendError(client, "INVALID_PAYLOAD", "Invalid SUBMIT_TOPIC payload")
-		return
-	}
 	log.Printf("[WS SUBMIT_TOPIC] Parsed payload - DisplayedEmojis: %d, OriginalEmojis: %d, DummyIndex: %d", len(data.DisplayedEmojis), len(data.OriginalEmojis), data.DummyIndex)
 
 	ctx := context.Background()
@@ -299,6 +411,7 @@ func (h *Handler) handleSubmitTopic(client *Client, payload interface{}) {
 	// Execute use case to start discussion
 	input := roomUseCase.StartDiscussionInput{
 		RoomID:          client.roomID,
+		UserID:          client.userID,
 		OriginalEmojis:  data.OriginalEmojis,
 		DisplayedEmojis: data.DisplayedEmojis,
 		DummyIndex:      data.DummyIndex,
@@ -307,8 +420,7 @@ func (h *Handler) handleSubmitTopic(client *Client, payload interface{}) {
 
 	if err := h.startDiscussionUseCase.Execute(ctx, input); err != nil {
 		log.Printf("Error starting discussion: %v", err)
-		h.sendError(client, "START_DISCUSSION_ERROR", err.Error())
-		return
+		return &commandError{code: "START_DISCUSSION_ERROR", message: err.Error()}
 	}
 
 	// Fetch room for broadcasting
@@ -317,7 +429,7 @@ func (h *Handler) handleSubmitTopic(client *Client, payload interface{}) {
 	})
 	if err != nil {
 		log.Printf("Error fetching room: %v", err)
-		return
+		return nil
 	}
 	foundRoom := roomOutput.Room
 
@@ -364,30 +476,32 @@ func (h *Handler) handleSubmitTopic(client *Client, payload interface{}) {
 				OriginalEmojis:  originalEmojisSlice,
 				DummyIndex:      dummyIdxPtr,
 				DummyEmoji:      dummyEmojiStr,
-				Assignments:     assignmentsSlice,
+				Deadline:        deadlinePayload(foundRoom, h.timer.clock.Now()),
+				PhaseDeadline:   phaseDeadlinePayload(foundRoom, h.timer.clock.Now()),
 			},
 		},
 	})
 
-	// Start timer after 5 seconds delay
-	h.timer.StartTimer(client.roomID)
+	// Tell each player their emoji privately
+	h.sendAssignments(client.roomID, assignmentsSlice)
+
+	// Start the countdown from the deadline persisted with the room
+	if deadline := foundRoom.DiscussionDeadline(); deadline != nil {
+		h.timer.StartTimer(client.roomID, *deadline)
+	}
+
+	return nil
 }
 
 // handleAnswering handles ANSWERING message
-func (h *Handler) handleAnswering(client *Client, payload interface{}) {
-	payloadBytes, _ := json.Marshal(payload)
-	var data AnsweringPayload
-	if err := json.Unmarshal(payloadBytes, &data); err != nil {
-		log.Printf("Error unmarshaling ANSWERING payload: %v", err)
-		h.sendError(client, "INVALID_PAYLOAD", "Invalid ANSWERING payload")
-		return
-	}
+func (h *Handler) handleAnswering(client *Client, data *AnsweringPayload) *commandError {
 
 	ctx := context.Background()
 
 	// Execute use case to submit final answer
 	input := roomUseCase.SubmitFinalAnswerInput{
 		RoomID:          client.roomID,
+		UserID:          client.userID,
 		Answer:          data.Answer,
 		OriginalEmojis:  data.OriginalEmojis,
 		DisplayedEmojis: data.DisplayedEmojis,
@@ -397,8 +511,11 @@ func (h *Handler) handleAnswering(client *Client, payload interface{}) {
 
 	if err := h.submitFinalAnswerUseCase.Execute(ctx, input); err != nil {
 		log.Printf("Error submitting final answer: %v", err)
-		h.sendError(client, "SUBMIT_ANSWER_ERROR", err.Error())
-		return
+		code := moderation.ErrorCode(err)
+		if code == "" {
+			code = "SUBMIT_ANSWER_ERROR"
+		}
+		return &commandError{code: code, message: err.Error()}
 	}
 
 	// Fetch room for broadcasting
@@ -407,7 +524,7 @@ func (h *Handler) handleAnswering(client *Client, payload interface{}) {
 	})
 	if err != nil {
 		log.Printf("Error fetching room: %v", err)
-		return
+		return nil
 	}
 	foundRoom := roomOutput.Room
 
@@ -438,11 +555,6 @@ func (h *Handler) handleAnswering(client *Client, payload interface{}) {
 		originalEmojisSlice = foundRoom.OriginalEmojis().Values()
 	}
 
-	assignmentsSlice := []string{}
-	if foundRoom.Assignments() != nil {
-		assignmentsSlice = foundRoom.Assignments().Values()
-	}
-
 	h.hub.Broadcast(client.roomID, Message{
 		Type: MessageTypeStateUpdate,
 		Payload: StateUpdatePayload{
@@ -453,35 +565,32 @@ func (h *Handler) handleAnswering(client *Client, payload interface{}) {
 				OriginalEmojis:  originalEmojisSlice,
 				DummyIndex:      dummyIdxPtr,
 				DummyEmoji:      dummyEmojiStr,
-				Assignments:     assignmentsSlice,
+				PhaseDeadline:   phaseDeadlinePayload(foundRoom, h.timer.clock.Now()),
 			},
 		},
 	})
 
 	// Stop timer when transitioning to checking phase
 	h.timer.StopTimer(client.roomID)
+
+	return nil
 }
 
-// sendError sends an error message to a specific client
-func (h *Handler) sendError(client *Client, code string, message string) {
-	errorMsg := Message{
-		Type: MessageTypeError,
+// sendError sends an error message to a specific client, correlated with requestID if set
+func (h *Handler) sendError(client *Client, requestID string, code string, message string) {
+	h.sendMessage(client, Message{
+		Type:      MessageTypeError,
+		RequestID: requestID,
 		Payload: ErrorPayload{
 			Code:    code,
 			Message: message,
 		},
-	}
-	data, err := json.Marshal(errorMsg)
-	if err != nil {
-		log.Printf("Error marshaling error message: %v", err)
-		return
-	}
+	})
+}
 
-	select {
-	case client.send <- data:
-	default:
-		log.Printf("Failed to send error message to client")
-	}
+// sendMessage sends a message to a specific client
+func (h *Handler) sendMessage(client *Client, msg Message) {
+	h.hub.SendToClient(client, msg)
 }
 
 // broadcastParticipantUpdate broadcasts participant list to all clients in a room
@@ -507,6 +616,7 @@ func (h *Handler) broadcastParticipantUpdate(roomID string) {
 			UserName: p.UserName,
 			Role:     p.Role,
 			IsLeader: p.IsLeader,
+			Presence: presence(h.hub.IsOnline(roomID, p.UserID)),
 		})
 	}
 
@@ -522,13 +632,17 @@ func (h *Handler) broadcastParticipantUpdate(roomID string) {
 func (h *Handler) sendInitialRoomState(client *Client) {
 	ctx := context.Background()
 
+	// Take the position before reading the room so that every later
+	// broadcast is newer than the snapshot
+	position := h.hub.Position(client.roomID)
+
 	// Fetch room using UseCase
 	roomOutput, err := h.fetchRoomUseCase.Execute(ctx, roomUseCase.FetchRoomInput{
 		RoomID: client.roomID,
 	})
 	if err != nil {
 		log.Printf("Error fetching room for initial state: %v", err)
-		h.sendError(client, "FETCH_ROOM_ERROR", "Failed to fetch room state")
+		h.sendError(client, "", "FETCH_ROOM_ERROR", "Failed to fetch room state")
 		return
 	}
 
@@ -561,14 +675,11 @@ func (h *Handler) sendInitialRoomState(client *Client) {
 		originalEmojisSlice = foundRoom.OriginalEmojis().Values()
 	}
 
-	assignmentsSlice := []string{}
-	if foundRoom.Assignments() != nil {
-		assignmentsSlice = foundRoom.Assignments().Values()
-	}
-
 	// Create state update message with current room status
 	stateMsg := Message{
-		Type: MessageTypeStateUpdate,
+		Type:  MessageTypeStateUpdate,
+		Seq:   position.Seq,
+		Epoch: position.Epoch,
 		Payload: StateUpdatePayload{
 			NextState: foundRoom.Status().String(),
 			Data: &StateUpdateDataPayload{
@@ -577,21 +688,82 @@ func (h *Handler) sendInitialRoomState(client *Client) {
 				OriginalEmojis:  originalEmojisSlice,
 				DummyIndex:      dummyIdxPtr,
 				DummyEmoji:      dummyEmojiStr,
-				Assignments:     assignmentsSlice,
+				Deadline:        deadlinePayload(foundRoom, h.timer.clock.Now()),
+				PhaseDeadline:   phaseDeadlinePayload(foundRoom, h.timer.clock.Now()),
 			},
 		},
 	}
 
 	// Send state to the specific client
-	data, err := json.Marshal(stateMsg)
+	h.sendMessage(client, stateMsg)
+
+	// The room-wide state never carries assignments, so repeat the client's own privately
+	if foundRoom.Assignments() != nil {
+		for _, assignment := range parseAssignments(client.roomID, foundRoom.Assignments().Values()) {
+			if assignment.UserID == client.userID {
+				h.sendMessage(client, Message{Type: MessageTypeAssignment, Payload: assignment})
+			}
+		}
+	}
+}
+
+// presence converts an online flag to the PARTICIPANT_UPDATE presence value
+func presence(online bool) string {
+	if online {
+		return PresenceOnline
+	}
+	return PresenceOffline
+}
+
+// findIdentity looks up the role of a user claiming to be a participant of a room
+func (h *Handler) findIdentity(roomID, userID string) (ClientIdentity, error) {
+	participantRoomID, err := participant.NewRoomIDFromString(roomID)
 	if err != nil {
-		log.Printf("Error marshaling initial state message: %v", err)
-		return
+		return ClientIdentity{}, err
+	}
+	participantUserID, err := participant.NewUserIDFromString(userID)
+	if err != nil {
+		return ClientIdentity{}, err
 	}
 
-	select {
-	case client.send <- data:
-	default:
-		log.Printf("Failed to send initial state to client")
+	found, err := h.participantRepo.FindByRoomAndUser(context.Background(), participantRoomID, participantUserID)
+	if err != nil {
+		return ClientIdentity{}, errors.New("user is not a participant of this room")
+	}
+
+	return ClientIdentity{UserID: userID, Role: found.Role().String()}, nil
+}
+
+// sendAssignments privately tells each player which emoji they were assigned
+func (h *Handler) sendAssignments(roomID string, assignments []string) {
+	for _, assignment := range parseAssignments(roomID, assignments) {
+		h.hub.SendToUser(roomID, assignment.UserID, Message{
+			Type:    MessageTypeAssignment,
+			Payload: assignment,
+		})
+	}
+}
+
+// parseAssignments decodes the assignments stored with a room, skipping malformed ones
+func parseAssignments(roomID string, assignments []string) []AssignmentPayload {
+	parsed := make([]AssignmentPayload, 0, len(assignments))
+	for _, raw := range assignments {
+		var assignment AssignmentPayload
+		if err := json.Unmarshal([]byte(raw), &assignment); err != nil || assignment.UserID == "" {
+			log.Printf("Skipping malformed assignment in room %s: %s", roomID, raw)
+			continue
+		}
+		parsed = append(parsed, assignment)
 	}
+	return parsed
+}
+
+// HandleStats handles GET /admin/ws/stats
+func (h *Handler) HandleStats(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.hub.Stats())
+}
+
+// HandleSchema handles GET /ws/schema
+func (h *Handler) HandleSchema(c echo.Context) error {
+	return c.JSON(http.StatusOK, BuildSchema(h.maxMessageSize))
 }
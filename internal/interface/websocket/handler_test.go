@@ -0,0 +1,105 @@
+package websocket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	infrastructureBackplane "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/backplane"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
+)
+
+func TestHandleClientConnected(t *testing.T) {
+	const (
+		roomID      = "11111111-1111-1111-1111-111111111111"
+		otherRoomID = "22222222-2222-2222-2222-222222222222"
+		userID      = "33333333-3333-3333-3333-333333333333"
+	)
+
+	type fixture struct {
+		hub             *Hub
+		participantRepo participant.Repository
+		userRepo        *persistence.InMemoryUserRepository
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		hub := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, infrastructureBackplane.NewInMemoryBackplane(), infrastructureClock.NewSystemClock())
+		go hub.Run()
+		store := persistence.NewMemoryStore()
+		return &fixture{
+			hub:             hub,
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+			userRepo:        persistence.NewInMemoryUserRepository(store),
+		}
+	}
+
+	newHandler := func(f *fixture) *Handler {
+		return &Handler{
+			hub:                      f.hub,
+			participantRepo:          f.participantRepo,
+			fetchParticipantsUseCase: roomUseCase.NewFetchRoomParticipantsUseCase(f.participantRepo, f.userRepo),
+		}
+	}
+
+	addParticipant := func(t *testing.T, f *fixture, roomID string, role participant.ParticipantRole) {
+		t.Helper()
+		participantRoomID, _ := participant.NewRoomIDFromString(roomID)
+		participantUserID, _ := participant.NewUserIDFromString(userID)
+		p := participant.NewParticipant(participant.NewParticipantID(), participantRoomID, participantUserID, role, time.Now())
+		if err := f.participantRepo.Save(context.Background(), p); err != nil {
+			t.Fatalf("Failed to save participant: %v", err)
+		}
+	}
+
+	t.Run("ルームの参加者でないユーザーIDは拒否され識別されないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		addParticipant(t, f, otherRoomID, participant.RolePlayer)
+		h := newHandler(f)
+		client := &Client{send: make(chan *outbound, 10), roomID: roomID}
+		f.hub.Register(client, nil)
+
+		// act
+		cmdErr := h.handleClientConnected(client, &ClientConnectedPayload{UserID: userID})
+
+		// assert
+		if cmdErr == nil || cmdErr.code != "NOT_A_PARTICIPANT" {
+			t.Fatalf("Expected NOT_A_PARTICIPANT, got: %+v", cmdErr)
+		}
+		if client.userID != "" || client.identity.Load() != nil {
+			t.Error("Expected the client to stay unidentified")
+		}
+		if f.hub.IsOnline(roomID, userID) {
+			t.Error("Expected the user not to be marked online")
+		}
+	})
+
+	t.Run("ルームの参加者はロール付きで識別されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		addParticipant(t, f, roomID, participant.RoleHost)
+		h := newHandler(f)
+		client := &Client{send: make(chan *outbound, 10), roomID: roomID, resumed: true}
+		f.hub.Register(client, nil)
+
+		// act
+		cmdErr := h.handleClientConnected(client, &ClientConnectedPayload{UserID: userID})
+
+		// assert
+		if cmdErr != nil {
+			t.Fatalf("Expected no error, got: %+v", cmdErr)
+		}
+		identity := client.identity.Load()
+		if identity == nil || identity.UserID != userID || identity.Role != "host" {
+			t.Errorf("Expected the client to be identified as the host, got: %+v", identity)
+		}
+		if !f.hub.IsOnline(roomID, userID) {
+			t.Error("Expected the user to be marked online")
+		}
+	})
+}
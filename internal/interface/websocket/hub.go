@@ -0,0 +1,728 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"log"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/backplane"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+)
+
+const (
+	// roomTopicPrefix prefixes the backplane topics of sequenced room broadcasts
+	roomTopicPrefix = "room/"
+	// directTopicPrefix prefixes the backplane topics of transient and per-user room messages
+	directTopicPrefix = "direct/"
+	// presenceTopicPrefix prefixes the backplane topics of presence announcements
+	presenceTopicPrefix = "presence/"
+)
+
+// defaultHubQueueSize is the number of deliveries a shard queues when the configuration leaves it unset
+const defaultHubQueueSize = 4096
+
+// restartCloseReason tells clients disconnected by a shutdown to connect again
+const restartCloseReason = "server restarting, reconnect"
+
+// Client represents a WebSocket client
+type Client struct {
+	conn     *websocket.Conn
+	send     chan *outbound
+	encoding Encoding // wire format negotiated at connect time; JSON when empty
+	roomID   string
+	userID   string
+	resumed  bool                           // missed messages were replayed on registration, so no snapshot is needed
+	identity atomic.Pointer[ClientIdentity] // read by the hub for targeted delivery
+
+	// Set by the hub before it closes send, so they are visible once the channel is drained
+	closeCode   int
+	closeReason string
+}
+
+// ClientIdentity identifies the user behind a connection once CLIENT_CONNECTED is received
+type ClientIdentity struct {
+	UserID string
+	Role   string
+}
+
+// ClientFilter selects the identified clients a message is delivered to
+type ClientFilter func(identity ClientIdentity) bool
+
+// HubStats represents delivery counters of the hub
+type HubStats struct {
+	Shards     int    `json:"shards"`
+	Rooms      int    `json:"rooms"`
+	Clients    int    `json:"clients"`
+	Broadcasts uint64 `json:"broadcasts"`
+	Unicasts   uint64 `json:"unicasts"`
+	Delivered  uint64 `json:"delivered"`
+	Dropped    uint64 `json:"dropped"`    // messages not delivered because the client's buffer was full
+	Evicted    uint64 `json:"evicted"`    // clients disconnected for falling behind
+	Overflowed uint64 `json:"overflowed"` // messages not queued because their shard was falling behind
+}
+
+// Hub maintains active clients and broadcasts messages.
+// Rooms are partitioned into shards, each served by its own goroutine, so a
+// slow room only delays the rooms of its shard. Enqueueing never blocks, and
+// a shard queues a bounded number of deliveries.
+type Hub struct {
+	instanceID string
+	shards     []*hubShard
+	presence   *presenceTracker
+	backplane  backplane.Backplane
+	clock      clock.Clock
+	closing    atomic.Bool // set on shutdown; new clients are disconnected immediately
+
+	rooms      atomic.Int64
+	clients    atomic.Int64
+	broadcasts atomic.Uint64
+	unicasts   atomic.Uint64
+	delivered  atomic.Uint64
+	dropped    atomic.Uint64
+	evicted    atomic.Uint64
+	overflowed atomic.Uint64
+
+	replayBufferSize int
+	replayRetention  time.Duration
+}
+
+// hubShard owns the clients and streams of a subset of rooms.
+// Its maps are modified only by its own goroutine.
+type hubShard struct {
+	hub     *Hub
+	clients map[string]map[*Client]bool // roomID -> clients
+	mu      sync.RWMutex                // guards streams for readers outside the shard goroutine
+	streams map[string]*roomStream      // roomID -> sequence and replay buffer
+
+	queueMu    sync.Mutex
+	queue      []func()
+	queueLimit int                       // deliveries beyond it are dropped
+	lagging    map[string]StreamPosition // roomID -> latest broadcast dropped, while its eviction is queued
+	wake       chan struct{}
+}
+
+// BroadcastMessage represents a message to deliver to a room.
+// Messages to the whole room are sequenced for replay; targeted and transient messages are not.
+type BroadcastMessage struct {
+	RoomID    string
+	Message   Message
+	Client    *Client      // deliver only to this connection
+	Filter    ClientFilter // deliver only to identified clients matching the filter
+	Transient bool         // deliver to the whole room without sequencing or replay
+	seq       uint64       // sequence number assigned by the backplane
+	epoch     string       // epoch of seq
+}
+
+// relayedMessage is the backplane payload of a room message
+type relayedMessage struct {
+	RoomID    string          `json:"room_id"`
+	Message   json.RawMessage `json:"message"`
+	UserID    string          `json:"user_id,omitempty"` // deliver only to this user
+	Role      string          `json:"role,omitempty"`    // deliver only to users with this role
+	Transient bool            `json:"transient,omitempty"`
+}
+
+// presenceAnnouncement is the backplane payload listing the users online in a room on an instance
+type presenceAnnouncement struct {
+	Origin  string   `json:"origin"`
+	RoomID  string   `json:"room_id"`
+	UserIDs []string `json:"user_ids"`
+}
+
+// targeted reports whether the message is delivered to a subset of the room
+func (m BroadcastMessage) targeted() bool {
+	return m.Client != nil || m.Filter != nil
+}
+
+// sequenced reports whether the message is numbered and kept for replay
+func (m BroadcastMessage) sequenced() bool {
+	return !m.targeted() && !m.Transient
+}
+
+// NewHub creates a new Hub. Room broadcasts go through the backplane so that
+// clients connected to other instances receive them too.
+func NewHub(cfg Config, bp backplane.Backplane, clk clock.Clock) *Hub {
+	shards := cfg.HubShards
+	if shards <= 0 {
+		shards = runtime.NumCPU()
+	}
+	queueSize := cfg.HubQueueSize
+	if queueSize <= 0 {
+		queueSize = defaultHubQueueSize
+	}
+
+	h := &Hub{
+		instanceID:       uuid.NewString(),
+		shards:           make([]*hubShard, shards),
+		presence:         newPresenceTracker(cfg.PresenceGracePeriod, clk),
+		backplane:        bp,
+		clock:            clk,
+		replayBufferSize: cfg.ReplayBufferSize,
+		replayRetention:  cfg.ReplayRetention,
+	}
+	for i := range h.shards {
+		h.shards[i] = &hubShard{
+			hub:        h,
+			clients:    make(map[string]map[*Client]bool),
+			streams:    make(map[string]*roomStream),
+			queueLimit: queueSize,
+			lagging:    make(map[string]StreamPosition),
+			wake:       make(chan struct{}, 1),
+		}
+	}
+	bp.Subscribe(roomTopicPrefix, h.receive)
+	bp.Subscribe(directTopicPrefix, h.receive)
+	bp.Subscribe(presenceTopicPrefix, h.receivePresence)
+	h.presence.setOnChange(h.announcePresence)
+	return h
+}
+
+// Run starts the shards and blocks forever
+func (h *Hub) Run() {
+	go h.refreshPresence()
+
+	var wg sync.WaitGroup
+	for _, shard := range h.shards {
+		wg.Add(1)
+		go func(s *hubShard) {
+			defer wg.Done()
+			s.run()
+		}(shard)
+	}
+	wg.Wait()
+}
+
+// shard returns the shard that owns a room
+func (h *Hub) shard(roomID string) *hubShard {
+	hash := fnv.New32a()
+	hash.Write([]byte(roomID))
+	return h.shards[hash.Sum32()%uint32(len(h.shards))]
+}
+
+// enqueue schedules an operation on the shard goroutine without blocking.
+// Operations run in the order they were enqueued. Registrations and other
+// control operations are never dropped, so they do not count towards the limit.
+func (s *hubShard) enqueue(op func()) {
+	s.queueMu.Lock()
+	s.queue = append(s.queue, op)
+	s.queueMu.Unlock()
+	s.notify()
+}
+
+// enqueueDelivery schedules a message for delivery unless the queue is full.
+// A message that does not fit is dropped. Like a client whose buffer is full,
+// the room keeps its clients when the message is transient; otherwise they are
+// disconnected, and resume from the replay buffer or a snapshot.
+func (s *hubShard) enqueueDelivery(message BroadcastMessage) {
+	s.queueMu.Lock()
+	defer s.notify()
+	defer s.queueMu.Unlock()
+
+	if len(s.queue) < s.queueLimit {
+		s.queue = append(s.queue, func() {
+			s.deliver(message)
+		})
+		return
+	}
+
+	s.hub.overflowed.Add(1)
+	if message.Transient {
+		return
+	}
+	position, pending := s.lagging[message.RoomID]
+	if message.sequenced() {
+		position = StreamPosition{Epoch: message.epoch, Seq: message.seq}
+	}
+	s.lagging[message.RoomID] = position
+	if !pending {
+		s.queue = append(s.queue, func() {
+			s.evictRoom(message.RoomID)
+		})
+	}
+}
+
+// notify wakes the shard goroutine
+func (s *hubShard) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run processes queued operations and sweeps expired streams
+func (s *hubShard) run() {
+	sweep := s.hub.clock.NewTicker(time.Minute)
+	defer sweep.Stop()
+
+	var batch []func()
+	for {
+		select {
+		case <-s.wake:
+			s.queueMu.Lock()
+			batch, s.queue = s.queue, batch[:0]
+			s.queueMu.Unlock()
+
+			for i, op := range batch {
+				op()
+				batch[i] = nil
+			}
+
+		case now := <-sweep.C():
+			s.mu.Lock()
+			for roomID, stream := range s.streams {
+				if !stream.emptySince.IsZero() && now.Sub(stream.emptySince) > s.hub.replayRetention {
+					delete(s.streams, roomID)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// deliver sends a message to the clients of a room it is addressed to.
+// A client whose send buffer is full misses transient messages; for any other
+// message it is disconnected, and can resume from the replay buffer.
+func (s *hubShard) deliver(message BroadcastMessage) {
+	h := s.hub
+	if message.sequenced() {
+		s.mu.Lock()
+		s.stream(message.RoomID).advance(message.epoch, message.seq)
+		s.mu.Unlock()
+		message.Message.Seq = message.seq
+		message.Message.Epoch = message.epoch
+	}
+	data, err := json.Marshal(message.Message)
+	if err != nil {
+		log.Printf("Error marshaling message: %v", err)
+		return
+	}
+	// The message is encoded once per encoding, not once per client
+	f := newFrame(data, StreamPosition{Epoch: message.Message.Epoch, Seq: message.Message.Seq})
+	if message.sequenced() {
+		s.mu.Lock()
+		s.streams[message.RoomID].append(message.Message.Seq, f)
+		s.mu.Unlock()
+	}
+	if message.targeted() {
+		h.unicasts.Add(1)
+	} else {
+		h.broadcasts.Add(1)
+	}
+
+	for client := range s.clients[message.RoomID] {
+		if message.Client != nil && client != message.Client {
+			continue
+		}
+		if message.Filter != nil {
+			identity := client.identity.Load()
+			if identity == nil || !message.Filter(*identity) {
+				continue
+			}
+		}
+
+		out, err := f.encode(client.encoding)
+		if err != nil {
+			log.Printf("Error encoding %s message: %v", client.encoding, err)
+			continue
+		}
+
+		select {
+		case client.send <- out:
+			h.delivered.Add(1)
+		default:
+			h.dropped.Add(1)
+			if !message.Transient {
+				h.evicted.Add(1)
+				s.removeClient(client)
+			}
+		}
+	}
+}
+
+// addClient adds a client to its room and replays the messages it missed after from.
+// It reports whether the gap was replayed; otherwise the client needs a full snapshot.
+func (s *hubShard) addClient(client *Client, from *StreamPosition) bool {
+	h := s.hub
+	if h.closing.Load() {
+		client.closeCode = websocket.CloseServiceRestart
+		client.closeReason = restartCloseReason
+		close(client.send)
+		return false
+	}
+	if s.clients[client.roomID] == nil {
+		s.clients[client.roomID] = make(map[*Client]bool)
+		h.rooms.Add(1)
+	}
+	s.clients[client.roomID][client] = true
+	h.clients.Add(1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stream := s.stream(client.roomID)
+	stream.emptySince = time.Time{}
+
+	if from == nil {
+		return false
+	}
+	missed, ok := stream.since(*from)
+	if !ok || len(missed) > cap(client.send)-len(client.send) {
+		return false
+	}
+	frames := make([]*outbound, 0, len(missed))
+	for _, f := range missed {
+		out, err := f.encode(client.encoding)
+		if err != nil {
+			log.Printf("Error encoding %s message: %v", client.encoding, err)
+			return false
+		}
+		frames = append(frames, out)
+	}
+	for _, out := range frames {
+		client.send <- out
+	}
+	return true
+}
+
+// removeClient removes a client from its room and closes its send channel.
+// Only the shard goroutine calls it and it ignores clients that were already
+// removed, so the channel is closed exactly once.
+func (s *hubShard) removeClient(client *Client) {
+	clients, ok := s.clients[client.roomID]
+	if !ok {
+		return
+	}
+	if _, ok := clients[client]; !ok {
+		return
+	}
+
+	delete(clients, client)
+	close(client.send)
+	s.hub.clients.Add(-1)
+	if len(clients) == 0 {
+		delete(s.clients, client.roomID)
+		s.hub.rooms.Add(-1)
+
+		s.mu.Lock()
+		if stream, ok := s.streams[client.roomID]; ok {
+			stream.emptySince = s.hub.clock.Now()
+		}
+		s.mu.Unlock()
+	}
+}
+
+// evictRoom disconnects the clients of a room that missed a message because the queue was full.
+// When the message was sequenced, the stream moves past it with an empty buffer, so no
+// client can resume across the gap.
+func (s *hubShard) evictRoom(roomID string) {
+	s.queueMu.Lock()
+	position := s.lagging[roomID]
+	delete(s.lagging, roomID)
+	s.queueMu.Unlock()
+
+	if position.Seq > 0 {
+		s.mu.Lock()
+		stream := s.stream(roomID)
+		stream.advance(position.Epoch, position.Seq)
+		stream.reset()
+		s.mu.Unlock()
+	}
+
+	for client := range s.clients[roomID] {
+		s.hub.evicted.Add(1)
+		s.removeClient(client)
+	}
+}
+
+// disconnectAll removes every client of the shard, telling them why they are disconnected
+func (s *hubShard) disconnectAll(code int, reason string) {
+	for _, clients := range s.clients {
+		for client := range clients {
+			client.closeCode = code
+			client.closeReason = reason
+			s.removeClient(client)
+		}
+	}
+}
+
+// stream returns the stream of a room, creating it if needed. Callers must hold s.mu.
+func (s *hubShard) stream(roomID string) *roomStream {
+	stream, ok := s.streams[roomID]
+	if !ok {
+		stream = newRoomStream(s.hub.replayBufferSize)
+		if len(s.clients[roomID]) == 0 {
+			// Rooms whose clients are all on other instances are swept like empty rooms
+			stream.emptySince = s.hub.clock.Now()
+		}
+		s.streams[roomID] = stream
+	}
+	return stream
+}
+
+// enqueueMessage schedules a message for delivery to local clients
+func (h *Hub) enqueueMessage(message BroadcastMessage) {
+	h.shard(message.RoomID).enqueueDelivery(message)
+}
+
+// publish relays a room message to every instance through the backplane
+func (h *Hub) publish(topicPrefix string, relayed relayedMessage, message Message) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Error marshaling message: %v", err)
+		return
+	}
+	relayed.Message = data
+	payload, err := json.Marshal(relayed)
+	if err != nil {
+		log.Printf("Error marshaling relayed message: %v", err)
+		return
+	}
+	if err := h.backplane.Publish(context.Background(), topicPrefix+relayed.RoomID, payload); err != nil {
+		log.Printf("Error publishing %s to room %s: %v", message.Type, relayed.RoomID, err)
+	}
+}
+
+// receive queues a room message relayed by the backplane for delivery to local clients
+func (h *Hub) receive(envelope backplane.Envelope) {
+	var relayed relayedMessage
+	if err := json.Unmarshal(envelope.Payload, &relayed); err != nil {
+		log.Printf("Error decoding relayed message: %v", err)
+		return
+	}
+	var message struct {
+		Type      MessageType     `json:"type"`
+		RequestID string          `json:"request_id"`
+		Payload   json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(relayed.Message, &message); err != nil {
+		log.Printf("Error decoding relayed message: %v", err)
+		return
+	}
+
+	broadcast := BroadcastMessage{
+		RoomID: relayed.RoomID,
+		Message: Message{
+			Type:      message.Type,
+			RequestID: message.RequestID,
+			Payload:   message.Payload,
+		},
+		Transient: relayed.Transient,
+	}
+	switch {
+	case relayed.UserID != "":
+		broadcast.Filter = func(identity ClientIdentity) bool {
+			return identity.UserID == relayed.UserID
+		}
+	case relayed.Role != "":
+		broadcast.Filter = func(identity ClientIdentity) bool {
+			return identity.Role == relayed.Role
+		}
+	}
+	if strings.HasPrefix(envelope.Topic, roomTopicPrefix) {
+		broadcast.seq = envelope.Seq
+		broadcast.epoch = envelope.Epoch
+	}
+	h.enqueueMessage(broadcast)
+}
+
+// Register adds a client to the hub. When from is given and still in the stream of
+// the room, messages broadcast after it are replayed before any new message, and
+// true is returned.
+func (h *Hub) Register(client *Client, from *StreamPosition) bool {
+	resumed := make(chan bool, 1)
+	s := h.shard(client.roomID)
+	s.enqueue(func() {
+		resumed <- s.addClient(client, from)
+	})
+	return <-resumed
+}
+
+// Unregister removes a client from the hub and closes its send channel.
+// It is safe to call after the hub has already disconnected the client.
+func (h *Hub) Unregister(client *Client) {
+	s := h.shard(client.roomID)
+	s.enqueue(func() {
+		s.removeClient(client)
+	})
+}
+
+// Position returns the position of the latest message broadcast to a room
+func (h *Hub) Position(roomID string) StreamPosition {
+	s := h.shard(roomID)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if stream, ok := s.streams[roomID]; ok {
+		return stream.position()
+	}
+	return StreamPosition{}
+}
+
+// MarkOnline records that a user opened a connection to a room
+func (h *Hub) MarkOnline(roomID, userID string) {
+	h.presence.connect(roomID, userID)
+	h.announcePresence(roomID)
+}
+
+// MarkOffline records that a user closed a connection to a room.
+// The user is reported offline once the grace period passes without a reconnect.
+func (h *Hub) MarkOffline(roomID, userID string) {
+	h.presence.disconnect(roomID, userID)
+}
+
+// IsOnline reports whether a user has an open connection to a room on any instance
+// (or is within the grace period)
+func (h *Hub) IsOnline(roomID, userID string) bool {
+	return h.presence.isOnline(roomID, userID)
+}
+
+// OnPresenceChange sets the callback invoked when a user in a room goes offline on this instance
+func (h *Hub) OnPresenceChange(fn func(roomID string)) {
+	h.presence.setOnChange(func(roomID string) {
+		h.announcePresence(roomID)
+		fn(roomID)
+	})
+}
+
+// ForgetRoom drops the backplane counters of a room that will see no more messages
+func (h *Hub) ForgetRoom(roomID string) {
+	for _, prefix := range []string{roomTopicPrefix, directTopicPrefix, presenceTopicPrefix} {
+		if err := h.backplane.Forget(context.Background(), prefix+roomID); err != nil {
+			log.Printf("Error forgetting backplane topic %s: %v", prefix+roomID, err)
+		}
+	}
+}
+
+// announcePresence tells the other instances which users are online in a room on this one
+func (h *Hub) announcePresence(roomID string) {
+	payload, err := json.Marshal(presenceAnnouncement{
+		Origin:  h.instanceID,
+		RoomID:  roomID,
+		UserIDs: h.presence.online(roomID),
+	})
+	if err != nil {
+		log.Printf("Error marshaling presence: %v", err)
+		return
+	}
+	if err := h.backplane.Publish(context.Background(), presenceTopicPrefix+roomID, payload); err != nil {
+		log.Printf("Error relaying presence: %v", err)
+	}
+}
+
+// receivePresence records the users online on another instance
+func (h *Hub) receivePresence(envelope backplane.Envelope) {
+	var announcement presenceAnnouncement
+	if err := json.Unmarshal(envelope.Payload, &announcement); err != nil {
+		log.Printf("Error decoding presence: %v", err)
+		return
+	}
+	if announcement.Origin == h.instanceID {
+		return
+	}
+	h.presence.setRemote(announcement.RoomID, announcement.Origin, announcement.UserIDs, h.clock.Now().Add(presenceTTL))
+}
+
+// refreshPresence periodically announces the users online on this instance,
+// so announcements of a stopped instance lapse after presenceTTL
+func (h *Hub) refreshPresence() {
+	ticker := h.clock.NewTicker(presenceRefreshInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C() {
+		for _, roomID := range h.presence.rooms() {
+			h.announcePresence(roomID)
+		}
+		h.presence.pruneRemote(now)
+	}
+}
+
+// Broadcast sends a message to all clients in a room on every instance
+func (h *Hub) Broadcast(roomID string, message Message) {
+	h.publish(roomTopicPrefix, relayedMessage{RoomID: roomID}, message)
+}
+
+// BroadcastTransient sends a message to all clients in a room without sequencing it.
+// It is meant for high-volume messages that reconnecting clients do not need to replay.
+func (h *Hub) BroadcastTransient(roomID string, message Message) {
+	h.publish(directTopicPrefix, relayedMessage{RoomID: roomID, Transient: true}, message)
+}
+
+// SendToClient sends a message to a single connection
+func (h *Hub) SendToClient(client *Client, message Message) {
+	h.enqueueMessage(BroadcastMessage{
+		RoomID:  client.roomID,
+		Message: message,
+		Client:  client,
+	})
+}
+
+// SendToUser sends a message to every connection of a user in a room on every instance
+func (h *Hub) SendToUser(roomID, userID string, message Message) {
+	h.publish(directTopicPrefix, relayedMessage{RoomID: roomID, UserID: userID}, message)
+}
+
+// SendToRole sends a message to every connection of users with the given role in a room on every instance
+func (h *Hub) SendToRole(roomID, role string, message Message) {
+	h.publish(directTopicPrefix, relayedMessage{RoomID: roomID, Role: role}, message)
+}
+
+// SendToFilter sends a message to the identified connections in a room matching the filter.
+// Filters cannot be relayed, so only clients of this instance are reached.
+func (h *Hub) SendToFilter(roomID string, filter ClientFilter, message Message) {
+	h.enqueueMessage(BroadcastMessage{
+		RoomID:  roomID,
+		Message: message,
+		Filter:  filter,
+	})
+}
+
+// Identify associates a connection with a user for targeted delivery
+func (h *Hub) Identify(client *Client, identity ClientIdentity) {
+	client.identity.Store(&identity)
+}
+
+// Shutdown disconnects every client with a close message asking it to reconnect,
+// and from then on disconnects new clients as soon as they register.
+// It returns once every shard has closed its clients' send channels.
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.closing.Store(true)
+
+	done := make(chan struct{}, len(h.shards))
+	for _, shard := range h.shards {
+		shard.enqueue(func() {
+			shard.disconnectAll(websocket.CloseServiceRestart, restartCloseReason)
+			done <- struct{}{}
+		})
+	}
+	for range h.shards {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Stats returns the current delivery counters
+func (h *Hub) Stats() HubStats {
+	return HubStats{
+		Shards:     len(h.shards),
+		Rooms:      int(h.rooms.Load()),
+		Clients:    int(h.clients.Load()),
+		Broadcasts: h.broadcasts.Load(),
+		Unicasts:   h.unicasts.Load(),
+		Delivered:  h.delivered.Load(),
+		Dropped:    h.dropped.Load(),
+		Evicted:    h.evicted.Load(),
+		Overflowed: h.overflowed.Load(),
+	}
+}
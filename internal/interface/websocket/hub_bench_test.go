@@ -0,0 +1,85 @@
+package websocket
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	infrastructureBackplane "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/backplane"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+)
+
+// benchmarkHub fills a hub with rooms of one client each, drained by its own goroutine.
+// slowRooms of them are never drained, like clients on a stalled network.
+func benchmarkHub(b *testing.B, shards, rooms, slowRooms int) (*Hub, []string) {
+	b.Helper()
+
+	hub := NewHub(Config{ReplayBufferSize: 16, ReplayRetention: time.Minute, HubShards: shards}, infrastructureBackplane.NewInMemoryBackplane(), infrastructureClock.NewSystemClock())
+	go hub.Run()
+
+	roomIDs := make([]string, rooms)
+	for i := range roomIDs {
+		roomIDs[i] = fmt.Sprintf("room-%d", i)
+		client := &Client{send: make(chan *outbound, 256), roomID: roomIDs[i]}
+		hub.Register(client, nil)
+		if i < slowRooms {
+			continue
+		}
+		go func() {
+			for range client.send {
+			}
+		}()
+	}
+	return hub, roomIDs
+}
+
+// waitDelivered waits until the hub has processed n messages
+func waitDelivered(hub *Hub, n uint64) {
+	for {
+		stats := hub.Stats()
+		if stats.Delivered+stats.Dropped >= n {
+			return
+		}
+		time.Sleep(100 * time.Microsecond)
+	}
+}
+
+// BenchmarkHubBroadcast measures broadcasting to thousands of rooms, from
+// enqueue to delivery. shards=1 corresponds to the former single-goroutine hub.
+func BenchmarkHubBroadcast(b *testing.B) {
+	for _, rooms := range []int{1000, 5000} {
+		for _, shards := range []int{1, 16} {
+			b.Run(fmt.Sprintf("rooms=%d/shards=%d", rooms, shards), func(b *testing.B) {
+				hub, roomIDs := benchmarkHub(b, shards, rooms, 0)
+				message := Message{Type: MessageTypeTimerTick, Payload: TimerTickPayload{Time: "04:59"}}
+				base := hub.Stats().Delivered
+
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					i := 0
+					for pb.Next() {
+						hub.Broadcast(roomIDs[i%len(roomIDs)], message)
+						i++
+					}
+				})
+				waitDelivered(hub, base+uint64(b.N))
+			})
+		}
+	}
+}
+
+// BenchmarkHubBroadcastWithSlowClients measures how long callers spend in
+// Broadcast while some rooms have clients that never read.
+func BenchmarkHubBroadcastWithSlowClients(b *testing.B) {
+	for _, shards := range []int{1, 16} {
+		b.Run(fmt.Sprintf("rooms=5000/slow=50/shards=%d", shards), func(b *testing.B) {
+			hub, roomIDs := benchmarkHub(b, shards, 5000, 50)
+			message := Message{Type: MessageTypeReaction, Payload: ReactionPayload{}}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				hub.BroadcastTransient(roomIDs[i%len(roomIDs)], message)
+			}
+		})
+	}
+}
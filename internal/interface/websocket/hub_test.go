@@ -0,0 +1,441 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	infrastructureBackplane "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/backplane"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+)
+
+func TestHubTargetedDelivery(t *testing.T) {
+	type fixture struct {
+		hub    *Hub
+		host   *Client
+		player *Client
+		tab    *Client // second connection of player
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		hub := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, infrastructureBackplane.NewInMemoryBackplane(), infrastructureClock.NewSystemClock())
+		go hub.Run()
+
+		f := &fixture{
+			hub:    hub,
+			host:   &Client{send: make(chan *outbound, 10), roomID: "room-1"},
+			player: &Client{send: make(chan *outbound, 10), roomID: "room-1"},
+			tab:    &Client{send: make(chan *outbound, 10), roomID: "room-1"},
+		}
+		hub.Register(f.host, nil)
+		hub.Register(f.player, nil)
+		hub.Register(f.tab, nil)
+		hub.Identify(f.host, ClientIdentity{UserID: "host-1", Role: "host"})
+		hub.Identify(f.player, ClientIdentity{UserID: "player-1", Role: "player"})
+		hub.Identify(f.tab, ClientIdentity{UserID: "player-1", Role: "player"})
+		return f
+	}
+
+	// flush waits until all previously queued deliveries have been processed.
+	// Operations of a room run in order on its shard, so registering to the same room is enough.
+	flush := func(f *fixture) {
+		f.hub.Register(&Client{send: make(chan *outbound, 10), roomID: "room-1"}, nil)
+	}
+
+	t.Run("ユーザー宛てのメッセージは全ての接続に届き他のユーザーには届かないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		f.hub.SendToUser("room-1", "player-1", Message{Type: MessageTypeAssignment})
+		flush(f)
+
+		// assert
+		if len(f.player.send) != 1 || len(f.tab.send) != 1 {
+			t.Errorf("Expected both connections of the user to receive the message, got: %d and %d", len(f.player.send), len(f.tab.send))
+		}
+		if len(f.host.send) != 0 {
+			t.Errorf("Expected other users not to receive the message, got: %d", len(f.host.send))
+		}
+	})
+
+	t.Run("ロール宛てのメッセージは該当ロールのみに届くこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		f.hub.SendToRole("room-1", "host", Message{Type: MessageTypeAssignment})
+		flush(f)
+
+		// assert
+		if len(f.host.send) != 1 {
+			t.Errorf("Expected host to receive the message, got: %d", len(f.host.send))
+		}
+		if len(f.player.send) != 0 || len(f.tab.send) != 0 {
+			t.Error("Expected players not to receive the message")
+		}
+	})
+
+	t.Run("個別メッセージにはシーケンス番号が振られないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		f.hub.Broadcast("room-1", Message{Type: MessageTypeTimerTick})
+
+		// act
+		f.hub.SendToClient(f.host, Message{Type: MessageTypeError})
+		flush(f)
+
+		// assert
+		<-f.host.send // broadcast
+		var msg Message
+		if err := json.Unmarshal((<-f.host.send).data, &msg); err != nil {
+			t.Fatalf("Expected valid message, got: %v", err)
+		}
+		if msg.Seq != 0 {
+			t.Errorf("Expected no seq on targeted message, got: %d", msg.Seq)
+		}
+		if f.hub.Position("room-1").Seq != 1 {
+			t.Errorf("Expected room seq 1, got: %d", f.hub.Position("room-1").Seq)
+		}
+		if len(f.player.send) != 1 {
+			t.Errorf("Expected player to receive only the broadcast, got: %d", len(f.player.send))
+		}
+	})
+
+	t.Run("一時的なブロードキャストは全員に届くがシーケンス番号が振られず再送されないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		f.hub.Broadcast("room-1", Message{Type: MessageTypeTimerTick})
+
+		// act
+		f.hub.BroadcastTransient("room-1", Message{Type: MessageTypeReaction})
+		flush(f)
+		resumed := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+		from := StreamPosition{Epoch: f.hub.Position("room-1").Epoch, Seq: 1}
+		ok := f.hub.Register(resumed, &from)
+
+		// assert
+		<-f.host.send // broadcast
+		var msg Message
+		if err := json.Unmarshal((<-f.host.send).data, &msg); err != nil {
+			t.Fatalf("Expected valid message, got: %v", err)
+		}
+		if msg.Type != MessageTypeReaction || msg.Seq != 0 {
+			t.Errorf("Expected unsequenced reaction, got: %s with seq %d", msg.Type, msg.Seq)
+		}
+		if len(f.player.send) != 2 || len(f.tab.send) != 2 {
+			t.Errorf("Expected every connection to receive both messages, got: %d and %d", len(f.player.send), len(f.tab.send))
+		}
+		if !ok || len(resumed.send) != 0 {
+			t.Errorf("Expected nothing to replay after seq 1, got: %d", len(resumed.send))
+		}
+	})
+
+	t.Run("配信数が統計に反映されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		f.hub.Broadcast("room-1", Message{Type: MessageTypeTimerTick})
+		f.hub.SendToUser("room-1", "host-1", Message{Type: MessageTypeAssignment})
+		flush(f)
+		stats := f.hub.Stats()
+
+		// assert
+		if stats.Broadcasts != 1 || stats.Unicasts != 1 {
+			t.Errorf("Expected 1 broadcast and 1 unicast, got: %d and %d", stats.Broadcasts, stats.Unicasts)
+		}
+		if stats.Delivered != 4 {
+			t.Errorf("Expected 4 deliveries, got: %d", stats.Delivered)
+		}
+	})
+}
+
+func TestHubBackplane(t *testing.T) {
+	t.Run("別インスタンスのクライアントにも同じシーケンス番号で届くこと", func(t *testing.T) {
+		// arrange
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		hubA := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, bp, infrastructureClock.NewSystemClock())
+		hubB := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, bp, infrastructureClock.NewSystemClock())
+		go hubA.Run()
+		go hubB.Run()
+		clientA := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+		clientB := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+		hubA.Register(clientA, nil)
+		hubB.Register(clientB, nil)
+
+		// act
+		hubA.Broadcast("room-1", Message{Type: MessageTypeTimerTick, Payload: TimerTickPayload{Time: "04:59"}})
+		hubB.Broadcast("room-1", Message{Type: MessageTypeTimerTick, Payload: TimerTickPayload{Time: "04:58"}})
+
+		// assert
+		for name, client := range map[string]*Client{"A": clientA, "B": clientB} {
+			for i, want := range []string{"04:59", "04:58"} {
+				var got struct {
+					Seq     uint64           `json:"seq"`
+					Payload TimerTickPayload `json:"payload"`
+				}
+				select {
+				case out := <-client.send:
+					if err := json.Unmarshal(out.data, &got); err != nil {
+						t.Fatalf("Failed to decode message: %v", err)
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("Expected client %s to receive message %d", name, i+1)
+				}
+				if got.Seq != uint64(i+1) || got.Payload.Time != want {
+					t.Errorf("Expected client %s to receive seq %d %s, got: seq %d %s", name, i+1, want, got.Seq, got.Payload.Time)
+				}
+			}
+		}
+	})
+
+	t.Run("ユーザー宛てのメッセージは別インスタンスの接続にも届くこと", func(t *testing.T) {
+		// arrange
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		hubA := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, bp, infrastructureClock.NewSystemClock())
+		hubB := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, bp, infrastructureClock.NewSystemClock())
+		go hubA.Run()
+		go hubB.Run()
+		player := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+		hubB.Register(player, nil)
+		hubB.Identify(player, ClientIdentity{UserID: "player-1", Role: "player"})
+
+		// act
+		hubA.SendToUser("room-1", "player-1", Message{Type: MessageTypeAssignment})
+
+		// assert
+		select {
+		case <-player.send:
+		case <-time.After(time.Second):
+			t.Fatal("Expected the user on the other instance to receive the message")
+		}
+	})
+
+	t.Run("別インスタンスに接続中のユーザーもオンラインと判定されること", func(t *testing.T) {
+		// arrange
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		hubA := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute, PresenceGracePeriod: 10 * time.Millisecond}, bp, infrastructureClock.NewSystemClock())
+		hubB := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, bp, infrastructureClock.NewSystemClock())
+		changed := make(chan string, 1)
+		hubA.OnPresenceChange(func(roomID string) { changed <- roomID })
+
+		// act
+		hubA.MarkOnline("room-1", "player-1")
+		online := hubB.IsOnline("room-1", "player-1")
+		hubA.MarkOffline("room-1", "player-1")
+		select {
+		case <-changed:
+		case <-time.After(time.Second):
+			t.Fatal("Expected the user to go offline after the grace period")
+		}
+
+		// assert
+		if !online {
+			t.Error("Expected the user connected to the other instance to be online")
+		}
+		if hubB.IsOnline("room-1", "player-1") {
+			t.Error("Expected the user to be offline on every instance after disconnecting")
+		}
+	})
+}
+
+func TestHubSlowClient(t *testing.T) {
+	newHub := func(t *testing.T) *Hub {
+		t.Helper()
+
+		hub := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute, HubShards: 4}, infrastructureBackplane.NewInMemoryBackplane(), infrastructureClock.NewSystemClock())
+		go hub.Run()
+		return hub
+	}
+
+	t.Run("バッファが一杯のクライアントには一時的なメッセージが破棄され接続は維持されること", func(t *testing.T) {
+		// arrange
+		hub := newHub(t)
+		slow := &Client{send: make(chan *outbound, 1), roomID: "room-1"}
+		hub.Register(slow, nil)
+
+		// act
+		hub.BroadcastTransient("room-1", Message{Type: MessageTypeReaction})
+		hub.BroadcastTransient("room-1", Message{Type: MessageTypeReaction})
+		hub.Register(&Client{send: make(chan *outbound, 10), roomID: "room-1"}, nil)
+		stats := hub.Stats()
+
+		// assert
+		if stats.Dropped != 1 || stats.Evicted != 0 {
+			t.Errorf("Expected 1 dropped message and no eviction, got: %d and %d", stats.Dropped, stats.Evicted)
+		}
+		if stats.Clients != 2 {
+			t.Errorf("Expected the slow client to stay connected, got: %d clients", stats.Clients)
+		}
+	})
+
+	t.Run("シーケンス付きメッセージで溢れたクライアントは一度だけ切断されること", func(t *testing.T) {
+		// arrange
+		hub := newHub(t)
+		slow := &Client{send: make(chan *outbound, 1), roomID: "room-1"}
+		hub.Register(slow, nil)
+
+		// act
+		hub.Broadcast("room-1", Message{Type: MessageTypeTimerTick})
+		hub.Broadcast("room-1", Message{Type: MessageTypeTimerTick})
+		hub.Unregister(slow) // the connection closes on its own after eviction
+		hub.Register(&Client{send: make(chan *outbound, 10), roomID: "room-1"}, nil)
+		stats := hub.Stats()
+
+		// assert
+		if stats.Evicted != 1 {
+			t.Errorf("Expected 1 eviction, got: %d", stats.Evicted)
+		}
+		<-slow.send
+		if _, ok := <-slow.send; ok {
+			t.Error("Expected the send channel to be closed")
+		}
+		if stats.Clients != 1 {
+			t.Errorf("Expected only the new client to remain, got: %d", stats.Clients)
+		}
+	})
+}
+
+func TestHubQueueOverflow(t *testing.T) {
+	type fixture struct {
+		hub     *Hub
+		client  *Client
+		release chan struct{}
+	}
+
+	// newFixture blocks the only shard so that deliveries pile up in its queue of one
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		hub := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute, HubShards: 1, HubQueueSize: 1}, infrastructureBackplane.NewInMemoryBackplane(), infrastructureClock.NewSystemClock())
+		go hub.Run()
+		client := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+		hub.Register(client, nil)
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		hub.shards[0].enqueue(func() {
+			close(started)
+			<-release
+		})
+		<-started
+		return &fixture{hub: hub, client: client, release: release}
+	}
+
+	t.Run("キューが一杯のとき一時的なメッセージは破棄され接続は維持されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		f.hub.BroadcastTransient("room-1", Message{Type: MessageTypeReaction})
+		f.hub.BroadcastTransient("room-1", Message{Type: MessageTypeReaction})
+		close(f.release)
+		f.hub.Register(&Client{send: make(chan *outbound, 10), roomID: "room-1"}, nil)
+		stats := f.hub.Stats()
+
+		// assert
+		if stats.Overflowed != 1 || stats.Evicted != 0 {
+			t.Errorf("Expected 1 overflowed message and no eviction, got: %d and %d", stats.Overflowed, stats.Evicted)
+		}
+		if len(f.client.send) != 1 {
+			t.Errorf("Expected the queued message to be delivered, got: %d", len(f.client.send))
+		}
+	})
+
+	t.Run("キューが一杯のときシーケンス付きメッセージを破棄したルームは切断され再開できないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		f.hub.Broadcast("room-1", Message{Type: MessageTypeStateUpdate})
+		f.hub.Broadcast("room-1", Message{Type: MessageTypeStateUpdate})
+		close(f.release)
+		resumed := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+		from := StreamPosition{Epoch: f.hub.Position("room-1").Epoch, Seq: 1}
+		ok := f.hub.Register(resumed, &from)
+		stats := f.hub.Stats()
+
+		// assert
+		if stats.Overflowed != 1 || stats.Evicted != 1 {
+			t.Errorf("Expected 1 overflowed message and 1 eviction, got: %d and %d", stats.Overflowed, stats.Evicted)
+		}
+		<-f.client.send // the message queued before the overflow
+		if _, open := <-f.client.send; open {
+			t.Error("Expected the send channel to be closed")
+		}
+		if ok {
+			t.Error("Expected a client that missed the dropped message not to be resumed")
+		}
+		if f.hub.Position("room-1").Seq != 2 {
+			t.Errorf("Expected room seq 2, got: %d", f.hub.Position("room-1").Seq)
+		}
+	})
+}
+
+func TestHubShutdown(t *testing.T) {
+	newHub := func(t *testing.T) *Hub {
+		t.Helper()
+
+		hub := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute, HubShards: 4}, infrastructureBackplane.NewInMemoryBackplane(), infrastructureClock.NewSystemClock())
+		go hub.Run()
+		return hub
+	}
+
+	t.Run("停止時に全てのクライアントが再接続を促す理由付きで切断されること", func(t *testing.T) {
+		// arrange
+		hub := newHub(t)
+		clients := []*Client{
+			{send: make(chan *outbound, 10), roomID: "room-1"},
+			{send: make(chan *outbound, 10), roomID: "room-1"},
+			{send: make(chan *outbound, 10), roomID: "room-2"},
+		}
+		for _, client := range clients {
+			hub.Register(client, nil)
+		}
+
+		// act
+		err := hub.Shutdown(context.Background())
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		for _, client := range clients {
+			if _, ok := <-client.send; ok {
+				t.Fatal("Expected the send channel to be closed")
+			}
+			if client.closeCode != websocket.CloseServiceRestart || client.closeReason != restartCloseReason {
+				t.Errorf("Expected a service restart close, got: %d %q", client.closeCode, client.closeReason)
+			}
+		}
+		if stats := hub.Stats(); stats.Clients != 0 || stats.Rooms != 0 {
+			t.Errorf("Expected no clients to remain, got: %d clients in %d rooms", stats.Clients, stats.Rooms)
+		}
+	})
+
+	t.Run("停止後に登録したクライアントは即座に切断されること", func(t *testing.T) {
+		// arrange
+		hub := newHub(t)
+		hub.Shutdown(context.Background())
+		client := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+
+		// act
+		resumed := hub.Register(client, nil)
+
+		// assert
+		if resumed {
+			t.Error("Expected the client not to be resumed")
+		}
+		if _, ok := <-client.send; ok {
+			t.Error("Expected the send channel to be closed")
+		}
+		if client.closeReason != restartCloseReason {
+			t.Errorf("Expected the restart reason, got: %q", client.closeReason)
+		}
+	})
+}
@@ -1,26 +1,47 @@
 package websocket
 
+import (
+	"errors"
+	"time"
+)
+
 // MessageType represents the type of WebSocket message
 type MessageType string
 
 const (
 	// Client -> Server
-	MessageTypeClientConnected  MessageType = "CLIENT_CONNECTED"
+	MessageTypeClientConnected   MessageType = "CLIENT_CONNECTED"
 	MessageTypeFetchParticipants MessageType = "FETCH_PARTICIPANTS"
 	MessageTypeSubmitTopic       MessageType = "SUBMIT_TOPIC"
 	MessageTypeAnswering         MessageType = "ANSWERING"
+	MessageTypePing              MessageType = "PING"
+	MessageTypeChatSend          MessageType = "CHAT_SEND"
+	MessageTypeChatDelete        MessageType = "CHAT_DELETE"
+	MessageTypeSendReaction      MessageType = "SEND_REACTION"
 
 	// Server -> Client
-	MessageTypeStateUpdate        MessageType = "STATE_UPDATE"
-	MessageTypeParticipantUpdate  MessageType = "PARTICIPANT_UPDATE"
-	MessageTypeTimerTick          MessageType = "TIMER_TICK"
-	MessageTypeError              MessageType = "ERROR"
+	MessageTypeStateUpdate       MessageType = "STATE_UPDATE"
+	MessageTypeParticipantUpdate MessageType = "PARTICIPANT_UPDATE"
+	MessageTypeTimerTick         MessageType = "TIMER_TICK"
+	MessageTypeError             MessageType = "ERROR"
+	MessageTypeRoomClosed        MessageType = "ROOM_CLOSED"
+	MessageTypePong              MessageType = "PONG"
+	MessageTypeAssignment        MessageType = "ASSIGNMENT"
+	MessageTypeChatMessage       MessageType = "CHAT_MESSAGE"
+	MessageTypeChatDeleted       MessageType = "CHAT_DELETED"
+	MessageTypeChatHistory       MessageType = "CHAT_HISTORY"
+	MessageTypeReaction          MessageType = "REACTION"
+	MessageTypeAck               MessageType = "ACK"
+	MessageTypePhaseTimeout      MessageType = "PHASE_TIMEOUT"
 )
 
 // Message represents a WebSocket message
 type Message struct {
-	Type    MessageType `json:"type"`
-	Payload interface{} `json:"payload"`
+	Type      MessageType `json:"type"`
+	Seq       uint64      `json:"seq,omitempty"`        // per-room sequence of broadcasts; snapshots carry the sequence they reflect
+	Epoch     string      `json:"epoch,omitempty"`      // epoch of seq, sent back with it on reconnect
+	RequestID string      `json:"request_id,omitempty"` // echoes the request_id of the command an ACK, ERROR or PONG replies to
+	Payload   interface{} `json:"payload"`
 }
 
 // ClientConnectedPayload represents the payload for CLIENT_CONNECTED
@@ -29,6 +50,14 @@ type ClientConnectedPayload struct {
 	UserName string `json:"user_name"`
 }
 
+// Validate checks the CLIENT_CONNECTED payload
+func (p *ClientConnectedPayload) Validate() error {
+	if p.UserID == "" {
+		return errors.New("user_id is required")
+	}
+	return nil
+}
+
 // SubmitTopicPayload represents the payload for SUBMIT_TOPIC
 type SubmitTopicPayload struct {
 	DisplayedEmojis []string `json:"displayedEmojis"`
@@ -37,6 +66,20 @@ type SubmitTopicPayload struct {
 	DummyEmoji      string   `json:"dummyEmoji"`
 }
 
+// Validate checks the SUBMIT_TOPIC payload
+func (p *SubmitTopicPayload) Validate() error {
+	if len(p.DisplayedEmojis) == 0 {
+		return errors.New("displayedEmojis is required")
+	}
+	if p.DummyIndex < 0 || p.DummyIndex >= len(p.DisplayedEmojis) {
+		return errors.New("dummyIndex is out of range")
+	}
+	if p.DummyEmoji == "" {
+		return errors.New("dummyEmoji is required")
+	}
+	return nil
+}
+
 // AnsweringPayload represents the payload for ANSWERING
 type AnsweringPayload struct {
 	Answer          string   `json:"answer"`
@@ -46,22 +89,32 @@ type AnsweringPayload struct {
 	DummyEmoji      string   `json:"dummyEmoji"`
 }
 
+// Validate checks the ANSWERING payload
+func (p *AnsweringPayload) Validate() error {
+	if p.Answer == "" {
+		return errors.New("answer is required")
+	}
+	return nil
+}
+
 // StateUpdatePayload represents the payload for STATE_UPDATE
 type StateUpdatePayload struct {
-	NextState string                `json:"nextState"`
+	NextState string                  `json:"nextState"`
 	Data      *StateUpdateDataPayload `json:"data,omitempty"`
 }
 
 // StateUpdateDataPayload represents the data in STATE_UPDATE
 type StateUpdateDataPayload struct {
-	Theme           string   `json:"theme,omitempty"`
-	Topic           string   `json:"topic,omitempty"`
-	Answer          string   `json:"answer,omitempty"`
-	DisplayedEmojis []string `json:"displayedEmojis,omitempty"`
-	OriginalEmojis  []string `json:"originalEmojis,omitempty"`
-	DummyIndex      *int     `json:"dummyIndex,omitempty"`
-	DummyEmoji      string   `json:"dummyEmoji,omitempty"`
-	Assignments     []string `json:"assignments,omitempty"`
+	Theme           string                `json:"theme,omitempty"`
+	Topic           string                `json:"topic,omitempty"`
+	Answer          string                `json:"answer,omitempty"`
+	DisplayedEmojis []string              `json:"displayedEmojis,omitempty"`
+	OriginalEmojis  []string              `json:"originalEmojis,omitempty"`
+	DummyIndex      *int                  `json:"dummyIndex,omitempty"`
+	DummyEmoji      string                `json:"dummyEmoji,omitempty"`
+	Deadline        *DeadlinePayload      `json:"deadline,omitempty"`
+	PhaseDeadline   *PhaseDeadlinePayload `json:"phaseDeadline,omitempty"`
+	AnswerCorrect   *bool                 `json:"answerCorrect,omitempty"` // false when no answer was submitted in time
 }
 
 // ParticipantData represents participant information
@@ -70,8 +123,15 @@ type ParticipantData struct {
 	UserName string `json:"user_name"`
 	Role     string `json:"role"`
 	IsLeader bool   `json:"is_leader"`
+	Presence string `json:"presence"`
 }
 
+// Presence values in ParticipantData
+const (
+	PresenceOnline  = "online"
+	PresenceOffline = "offline"
+)
+
 // ParticipantUpdatePayload represents the payload for PARTICIPANT_UPDATE
 type ParticipantUpdatePayload struct {
 	Participants []ParticipantData `json:"participants"`
@@ -79,7 +139,38 @@ type ParticipantUpdatePayload struct {
 
 // TimerTickPayload represents the payload for TIMER_TICK
 type TimerTickPayload struct {
-	Time string `json:"time"`
+	Time       string    `json:"time"`       // remaining time as "MM:SS"
+	EndsAt     time.Time `json:"endsAt"`     // end of the countdown
+	ServerTime time.Time `json:"serverTime"` // for clients to correct their clock offset
+}
+
+// DeadlinePayload represents the discussion countdown in STATE_UPDATE.
+// Clients render the countdown locally from endsAt, corrected by serverTime.
+type DeadlinePayload struct {
+	StartsAt          time.Time `json:"startsAt"`
+	EndsAt            time.Time `json:"endsAt"`
+	PausedRemainingMs *int64    `json:"pausedRemainingMs,omitempty"` // set while the countdown is paused
+	ServerTime        time.Time `json:"serverTime"`
+}
+
+// PhaseDeadlinePayload represents the deadline of the setting_topic, answering or checking phase in STATE_UPDATE
+type PhaseDeadlinePayload struct {
+	Phase      string    `json:"phase"`
+	EndsAt     time.Time `json:"endsAt"`
+	ServerTime time.Time `json:"serverTime"`
+}
+
+// Fallback values in PhaseTimeoutPayload
+const (
+	FallbackAutoTopic  = "auto_topic"
+	FallbackNoAnswer   = "no_answer"
+	FallbackAutoFinish = "auto_finish"
+)
+
+// PhaseTimeoutPayload represents the payload for PHASE_TIMEOUT, broadcast before the resulting STATE_UPDATE
+type PhaseTimeoutPayload struct {
+	Phase    string `json:"phase"`
+	Fallback string `json:"fallback"`
 }
 
 // ErrorPayload represents the payload for ERROR
@@ -87,3 +178,83 @@ type ErrorPayload struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 }
+
+// AckPayload represents the payload for ACK
+type AckPayload struct {
+	Type MessageType `json:"type"` // type of the acknowledged command
+}
+
+// RoomClosedPayload represents the payload for ROOM_CLOSED
+type RoomClosedPayload struct {
+	Reason string `json:"reason"`
+}
+
+// AssignmentPayload represents the payload for ASSIGNMENT, sent only to the assigned player
+type AssignmentPayload struct {
+	UserID string `json:"user_id"`
+	Emoji  string `json:"emoji"`
+}
+
+// ChatSendPayload represents the payload for CHAT_SEND
+type ChatSendPayload struct {
+	Body string `json:"body"`
+}
+
+// Validate checks the CHAT_SEND payload
+func (p *ChatSendPayload) Validate() error {
+	if p.Body == "" {
+		return errors.New("body is required")
+	}
+	return nil
+}
+
+// ChatDeletePayload represents the payload for CHAT_DELETE
+type ChatDeletePayload struct {
+	MessageID string `json:"message_id"`
+}
+
+// Validate checks the CHAT_DELETE payload
+func (p *ChatDeletePayload) Validate() error {
+	if p.MessageID == "" {
+		return errors.New("message_id is required")
+	}
+	return nil
+}
+
+// ChatMessagePayload represents the payload for CHAT_MESSAGE
+type ChatMessagePayload struct {
+	MessageID string `json:"message_id"`
+	UserID    string `json:"user_id"`
+	UserName  string `json:"user_name"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ChatDeletedPayload represents the payload for CHAT_DELETED
+type ChatDeletedPayload struct {
+	MessageID string `json:"message_id"`
+}
+
+// ChatHistoryPayload represents the payload for CHAT_HISTORY
+type ChatHistoryPayload struct {
+	Messages []ChatMessagePayload `json:"messages"`
+}
+
+// SendReactionPayload represents the payload for SEND_REACTION
+type SendReactionPayload struct {
+	Emoji string `json:"emoji"`
+}
+
+// Validate checks the SEND_REACTION payload
+func (p *SendReactionPayload) Validate() error {
+	if p.Emoji == "" {
+		return errors.New("emoji is required")
+	}
+	return nil
+}
+
+// ReactionPayload represents the payload for REACTION
+type ReactionPayload struct {
+	UserID string `json:"user_id"`
+	Emoji  string `json:"emoji"`
+}
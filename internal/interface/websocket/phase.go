@@ -0,0 +1,169 @@
+package websocket
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/lease"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
+)
+
+const (
+	// phaseCheckInterval is how often passed phase deadlines are looked for
+	phaseCheckInterval = time.Second
+	// phaseLeaseName is the lease that elects the instance applying phase fallbacks
+	phaseLeaseName = "phase-deadlines"
+)
+
+// PhaseWatcher applies the fallbacks of the rooms whose phase deadline has passed.
+// Every instance runs it, but only the one holding the lease checks the deadlines;
+// the resulting events reach the clients of every instance through the backplane.
+type PhaseWatcher struct {
+	expirePhasesUseCase *roomUseCase.ExpirePhasesUseCase
+	leases              lease.Repository
+	clock               clock.Clock
+	owner               string
+	leaseTTL            time.Duration
+	owned               bool
+	stop                chan struct{}
+	done                chan struct{}
+	stopOnce            sync.Once
+}
+
+// NewPhaseWatcher creates a new PhaseWatcher
+func NewPhaseWatcher(
+	expirePhasesUseCase *roomUseCase.ExpirePhasesUseCase,
+	leases lease.Repository,
+	clk clock.Clock,
+	leaseTTL time.Duration,
+) *PhaseWatcher {
+	return &PhaseWatcher{
+		expirePhasesUseCase: expirePhasesUseCase,
+		leases:              leases,
+		clock:               clk,
+		owner:               uuid.NewString(),
+		leaseTTL:            leaseTTL,
+		stop:                make(chan struct{}),
+		done:                make(chan struct{}),
+	}
+}
+
+// Run checks the phase deadlines every second until Shutdown is called
+func (w *PhaseWatcher) Run() {
+	defer close(w.done)
+
+	ticker := w.clock.NewTicker(phaseCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			w.check()
+		case <-w.stop:
+			w.release()
+			return
+		}
+	}
+}
+
+// check applies the due fallbacks if this instance holds the lease
+func (w *PhaseWatcher) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), w.leaseTTL/3)
+	defer cancel()
+
+	owned, err := w.leases.Acquire(ctx, phaseLeaseName, w.owner, w.leaseTTL)
+	if err != nil {
+		log.Printf("Error acquiring phase deadline lease: %v", err)
+		owned = false
+	}
+	w.owned = owned
+	if !owned {
+		return
+	}
+
+	output, err := w.expirePhasesUseCase.Execute(ctx)
+	if err != nil {
+		log.Printf("Error checking phase deadlines: %v", err)
+		return
+	}
+	if output.Expired > 0 {
+		log.Printf("Applied %d phase fallbacks", output.Expired)
+	}
+}
+
+// release gives up the lease so that another instance takes over immediately
+func (w *PhaseWatcher) release() {
+	if !w.owned {
+		return
+	}
+	if err := w.leases.Release(context.Background(), phaseLeaseName, w.owner); err != nil {
+		log.Printf("Error releasing phase deadline lease: %v", err)
+	}
+	w.owned = false
+}
+
+// Shutdown stops checking the deadlines and waits for the running check to finish
+func (w *PhaseWatcher) Shutdown(ctx context.Context) error {
+	w.stopOnce.Do(func() { close(w.stop) })
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// phaseDeadlinePayload returns the deadline of the current phase for STATE_UPDATE, or nil when it has none
+func phaseDeadlinePayload(foundRoom *room.Room, now time.Time) *PhaseDeadlinePayload {
+	deadline := foundRoom.PhaseDeadline()
+	if deadline == nil {
+		return nil
+	}
+
+	return &PhaseDeadlinePayload{
+		Phase:      foundRoom.Status().String(),
+		EndsAt:     *deadline,
+		ServerTime: now,
+	}
+}
+
+// handlePhaseTimeout broadcasts PHASE_TIMEOUT for a fallback and then the resulting state
+func (h *Handler) handlePhaseTimeout(roomID, phase, fallback string, broadcastState func()) {
+	h.hub.Broadcast(roomID, Message{
+		Type: MessageTypePhaseTimeout,
+		Payload: PhaseTimeoutPayload{
+			Phase:    phase,
+			Fallback: fallback,
+		},
+	})
+	broadcastState()
+}
+
+// subscribePhaseTimeouts broadcasts the fallbacks applied when a phase deadline passes.
+// Each fallback leads to the same state as the action it replaces.
+func (h *Handler) subscribePhaseTimeouts(eventPublisher event.Publisher) {
+	eventPublisher.Subscribe("TopicAutoSelected", func(evt event.Event) {
+		h.handlePhaseTimeout(evt.AggregateID(), room.StatusSettingTopic.String(), FallbackAutoTopic, func() {
+			h.handleDiscussionStartedEvent(event.NewDiscussionStartedEvent(evt.AggregateID(), evt.OccurredAt()))
+		})
+	})
+
+	eventPublisher.Subscribe("AnswerTimedOut", func(evt event.Event) {
+		h.handlePhaseTimeout(evt.AggregateID(), room.StatusAnswering.String(), FallbackNoAnswer, func() {
+			h.handleAnswerSubmittedEvent(event.NewAnswerSubmittedEvent(evt.AggregateID(), evt.OccurredAt()))
+		})
+	})
+
+	eventPublisher.Subscribe("GameAutoFinished", func(evt event.Event) {
+		h.handlePhaseTimeout(evt.AggregateID(), room.StatusChecking.String(), FallbackAutoFinish, func() {
+			h.handleGameFinishedEvent(event.NewGameFinishedEvent(evt.AggregateID(), evt.OccurredAt()))
+		})
+	})
+}
@@ -0,0 +1,195 @@
+package websocket
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+)
+
+const (
+	// presenceRefreshInterval is how often an instance announces the users online on it
+	presenceRefreshInterval = 10 * time.Second
+	// presenceTTL is how long an announcement counts; it lapses when its instance stops
+	presenceTTL = 3 * presenceRefreshInterval
+)
+
+// presenceTracker tracks which users are online in each room.
+// A user stays online while at least one of their connections is open,
+// and is marked offline only after the grace period passes without a reconnect.
+// Users online on other instances are known from their announcements.
+type presenceTracker struct {
+	mu       sync.Mutex
+	grace    time.Duration
+	clock    clock.Clock
+	conns    map[string]map[string]int            // roomID -> userID -> open connections (0 during the grace period)
+	pending  map[string]map[string]clock.Timer    // roomID -> userID -> offline timer
+	remote   map[string]map[string]remotePresence // roomID -> instanceID -> users online there
+	onChange func(roomID string)
+}
+
+// remotePresence is the set of users online in a room on another instance
+type remotePresence struct {
+	users     map[string]bool
+	expiresAt time.Time
+}
+
+// newPresenceTracker creates a new presenceTracker
+func newPresenceTracker(grace time.Duration, clk clock.Clock) *presenceTracker {
+	return &presenceTracker{
+		grace:   grace,
+		clock:   clk,
+		conns:   make(map[string]map[string]int),
+		pending: make(map[string]map[string]clock.Timer),
+		remote:  make(map[string]map[string]remotePresence),
+	}
+}
+
+// connect records a new connection of a user
+func (p *presenceTracker) connect(roomID, userID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if timer, ok := p.pending[roomID][userID]; ok {
+		timer.Stop()
+		delete(p.pending[roomID], userID)
+	}
+
+	if p.conns[roomID] == nil {
+		p.conns[roomID] = make(map[string]int)
+	}
+	p.conns[roomID][userID]++
+}
+
+// disconnect records a closed connection of a user and schedules the
+// offline transition when it was their last connection
+func (p *presenceTracker) disconnect(roomID, userID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	count := p.conns[roomID][userID]
+	if count == 0 {
+		return
+	}
+	// The entry is kept at zero connections so the user stays online during the grace period
+	p.conns[roomID][userID] = count - 1
+	if count > 1 {
+		return
+	}
+
+	if p.pending[roomID] == nil {
+		p.pending[roomID] = make(map[string]clock.Timer)
+	}
+	var timer clock.Timer
+	timer = p.clock.AfterFunc(p.grace, func() {
+		p.mu.Lock()
+		if p.pending[roomID][userID] != timer {
+			// Reconnected in the meantime
+			p.mu.Unlock()
+			return
+		}
+		delete(p.pending[roomID], userID)
+		if len(p.pending[roomID]) == 0 {
+			delete(p.pending, roomID)
+		}
+		delete(p.conns[roomID], userID)
+		if len(p.conns[roomID]) == 0 {
+			delete(p.conns, roomID)
+		}
+		onChange := p.onChange
+		p.mu.Unlock()
+
+		if onChange != nil {
+			onChange(roomID)
+		}
+	})
+	p.pending[roomID][userID] = timer
+}
+
+// isOnline reports whether a user is online, including during the grace period
+func (p *presenceTracker) isOnline(roomID, userID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.conns[roomID][userID]; ok {
+		return true
+	}
+	now := p.clock.Now()
+	for _, presence := range p.remote[roomID] {
+		if presence.users[userID] && now.Before(presence.expiresAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// online returns the users online in a room on this instance
+func (p *presenceTracker) online(roomID string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	userIDs := make([]string, 0, len(p.conns[roomID]))
+	for userID := range p.conns[roomID] {
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs
+}
+
+// rooms returns the rooms with users online on this instance
+func (p *presenceTracker) rooms() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	roomIDs := make([]string, 0, len(p.conns))
+	for roomID := range p.conns {
+		roomIDs = append(roomIDs, roomID)
+	}
+	return roomIDs
+}
+
+// setRemote replaces the users online in a room on another instance
+func (p *presenceTracker) setRemote(roomID, instanceID string, userIDs []string, expiresAt time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(userIDs) == 0 {
+		delete(p.remote[roomID], instanceID)
+		if len(p.remote[roomID]) == 0 {
+			delete(p.remote, roomID)
+		}
+		return
+	}
+	users := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		users[userID] = true
+	}
+	if p.remote[roomID] == nil {
+		p.remote[roomID] = make(map[string]remotePresence)
+	}
+	p.remote[roomID][instanceID] = remotePresence{users: users, expiresAt: expiresAt}
+}
+
+// pruneRemote drops the announcements of instances that stopped refreshing them
+func (p *presenceTracker) pruneRemote(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for roomID, instances := range p.remote {
+		for instanceID, presence := range instances {
+			if !now.Before(presence.expiresAt) {
+				delete(instances, instanceID)
+			}
+		}
+		if len(instances) == 0 {
+			delete(p.remote, roomID)
+		}
+	}
+}
+
+// setOnChange sets the callback invoked when a user goes offline
+func (p *presenceTracker) setOnChange(fn func(roomID string)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.onChange = fn
+}
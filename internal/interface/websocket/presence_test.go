@@ -0,0 +1,133 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+)
+
+func TestPresenceTracker(t *testing.T) {
+	const grace = 20 * time.Second
+
+	newClock := func() *infrastructureClock.FakeClock {
+		return infrastructureClock.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	}
+
+	t.Run("最後の接続が切れても猶予期間中はオンラインのままであること", func(t *testing.T) {
+		// arrange
+		c := newClock()
+		p := newPresenceTracker(grace, c)
+		p.connect("room-1", "user-1")
+
+		// act
+		p.disconnect("room-1", "user-1")
+		c.Advance(grace - time.Second)
+
+		// assert
+		if !p.isOnline("room-1", "user-1") {
+			t.Error("Expected user to be online during the grace period")
+		}
+	})
+
+	t.Run("猶予期間後にオフラインになり通知されること", func(t *testing.T) {
+		// arrange
+		c := newClock()
+		p := newPresenceTracker(grace, c)
+		changed := make(chan string, 1)
+		p.setOnChange(func(roomID string) { changed <- roomID })
+		p.connect("room-1", "user-1")
+
+		// act
+		p.disconnect("room-1", "user-1")
+		c.Advance(grace)
+
+		// assert
+		select {
+		case roomID := <-changed:
+			if roomID != "room-1" {
+				t.Errorf("Expected change for room-1, got: %s", roomID)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected presence change to be notified")
+		}
+		if p.isOnline("room-1", "user-1") {
+			t.Error("Expected user to be offline")
+		}
+	})
+
+	t.Run("猶予期間中に再接続した場合はオフラインにならないこと", func(t *testing.T) {
+		// arrange
+		c := newClock()
+		p := newPresenceTracker(grace, c)
+		changed := make(chan string, 1)
+		p.setOnChange(func(roomID string) { changed <- roomID })
+		p.connect("room-1", "user-1")
+		p.disconnect("room-1", "user-1")
+
+		// act
+		p.connect("room-1", "user-1")
+		c.Advance(3 * grace)
+
+		// assert
+		select {
+		case <-changed:
+			t.Fatal("Expected no presence change")
+		case <-time.After(50 * time.Millisecond):
+		}
+		if !p.isOnline("room-1", "user-1") {
+			t.Error("Expected user to be online")
+		}
+	})
+
+	t.Run("複数タブのうち1つが切れてもオンラインのままであること", func(t *testing.T) {
+		// arrange
+		c := newClock()
+		p := newPresenceTracker(grace, c)
+		p.connect("room-1", "user-1")
+		p.connect("room-1", "user-1")
+
+		// act
+		p.disconnect("room-1", "user-1")
+		c.Advance(3 * grace)
+
+		// assert
+		if !p.isOnline("room-1", "user-1") {
+			t.Error("Expected user to stay online while another tab is connected")
+		}
+	})
+
+	t.Run("別インスタンスで通知されたユーザーは期限内はオンラインと判定されること", func(t *testing.T) {
+		// arrange
+		c := newClock()
+		p := newPresenceTracker(grace, c)
+
+		// act
+		p.setRemote("room-1", "instance-b", []string{"user-1"}, c.Now().Add(time.Minute))
+
+		// assert
+		if !p.isOnline("room-1", "user-1") {
+			t.Error("Expected user online on another instance to be online")
+		}
+	})
+
+	t.Run("期限切れや空の通知で別インスタンスのユーザーがオフラインになること", func(t *testing.T) {
+		// arrange
+		c := newClock()
+		p := newPresenceTracker(grace, c)
+		p.setRemote("room-1", "instance-b", []string{"user-1"}, c.Now().Add(-time.Second))
+		p.setRemote("room-1", "instance-c", []string{"user-2"}, c.Now().Add(time.Minute))
+
+		// act
+		p.setRemote("room-1", "instance-c", nil, c.Now().Add(time.Minute))
+		p.pruneRemote(c.Now())
+
+		// assert
+		if p.isOnline("room-1", "user-1") || p.isOnline("room-1", "user-2") {
+			t.Error("Expected users of lapsed or emptied announcements to be offline")
+		}
+		if len(p.remote) != 0 {
+			t.Errorf("Expected remote presence to be dropped, got: %v", p.remote)
+		}
+	})
+}
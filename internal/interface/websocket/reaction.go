@@ -0,0 +1,48 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room_emoji"
+	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
+)
+
+// handleSendReaction handles SEND_REACTION message
+func (h *Handler) handleSendReaction(client *Client, data *SendReactionPayload) *commandError {
+	output, err := h.sendReactionUseCase.Execute(context.Background(), roomUseCase.SendReactionInput{
+		RoomID: client.roomID,
+		UserID: client.userID,
+		Emoji:  data.Emoji,
+	})
+	if err != nil {
+		log.Printf("Error sending reaction: %v", err)
+		return &commandError{code: reactionErrorCode(err), message: err.Error()}
+	}
+
+	// Reactions are frequent and only meaningful live, so they are not kept for replay
+	h.hub.BroadcastTransient(client.roomID, Message{
+		Type: MessageTypeReaction,
+		Payload: ReactionPayload{
+			UserID: output.UserID,
+			Emoji:  output.Emoji,
+		},
+	})
+
+	return nil
+}
+
+// reactionErrorCode returns the client-facing error code for a reaction error
+func reactionErrorCode(err error) string {
+	switch {
+	case errors.Is(err, room_emoji.ErrTooManyReactions):
+		return "RATE_LIMITED"
+	case errors.Is(err, room_emoji.ErrInvalidReaction):
+		return "INVALID_REACTION"
+	case errors.Is(err, room_emoji.ErrReactionNotAllowed):
+		return "REACTION_NOT_ALLOWED"
+	default:
+		return "REACTION_ERROR"
+	}
+}
@@ -0,0 +1,244 @@
+package websocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ProtocolVersion is the WebSocket subprotocol spoken by this server.
+// Clients negotiate it with the Sec-WebSocket-Protocol header.
+const ProtocolVersion = "guess-title.v1"
+
+var (
+	ErrInvalidEnvelope    = errors.New("invalid message format")
+	ErrUnknownMessageType = errors.New("unknown message type")
+	ErrInvalidPayload     = errors.New("invalid payload")
+)
+
+// Direction describes who sends a message
+type Direction string
+
+const (
+	DirectionClientToServer Direction = "client_to_server"
+	DirectionServerToClient Direction = "server_to_client"
+)
+
+// Validator is implemented by client payloads that check their own fields after decoding
+type Validator interface {
+	Validate() error
+}
+
+// MessageSpec describes a message type and the payload it carries
+type MessageSpec struct {
+	Type        MessageType
+	Direction   Direction
+	Description string
+	Payload     interface{} // zero value of the payload type, or nil when the message has none
+}
+
+// messageSpecs lists every message of the protocol, in the order they are documented
+var messageSpecs = []MessageSpec{
+	{MessageTypeClientConnected, DirectionClientToServer, "Identifies the user of the connection", ClientConnectedPayload{}},
+	{MessageTypeFetchParticipants, DirectionClientToServer, "Requests a PARTICIPANT_UPDATE broadcast", nil},
+	{MessageTypeSubmitTopic, DirectionClientToServer, "Submits the emojis for the discussion", SubmitTopicPayload{}},
+	{MessageTypeAnswering, DirectionClientToServer, "Submits the final answer", AnsweringPayload{}},
+	{MessageTypePing, DirectionClientToServer, "Application-level heartbeat", nil},
+	{MessageTypeChatSend, DirectionClientToServer, "Posts a chat message", ChatSendPayload{}},
+	{MessageTypeChatDelete, DirectionClientToServer, "Deletes a chat message (host only)", ChatDeletePayload{}},
+	{MessageTypeSendReaction, DirectionClientToServer, "Sends an emoji reaction", SendReactionPayload{}},
+
+	{MessageTypeStateUpdate, DirectionServerToClient, "Room state transition", StateUpdatePayload{}},
+	{MessageTypeParticipantUpdate, DirectionServerToClient, "Participant list with presence", ParticipantUpdatePayload{}},
+	{MessageTypeTimerTick, DirectionServerToClient, "Remaining discussion time", TimerTickPayload{}},
+	{MessageTypeError, DirectionServerToClient, "Error caused by a client message", ErrorPayload{}},
+	{MessageTypeRoomClosed, DirectionServerToClient, "The room was closed", RoomClosedPayload{}},
+	{MessageTypePong, DirectionServerToClient, "Reply to PING", nil},
+	{MessageTypeAssignment, DirectionServerToClient, "Emoji assigned to the receiving player", AssignmentPayload{}},
+	{MessageTypeChatMessage, DirectionServerToClient, "New chat message", ChatMessagePayload{}},
+	{MessageTypeChatDeleted, DirectionServerToClient, "A chat message was deleted", ChatDeletedPayload{}},
+	{MessageTypeChatHistory, DirectionServerToClient, "Recent chat messages", ChatHistoryPayload{}},
+	{MessageTypeReaction, DirectionServerToClient, "Emoji reaction (not sequenced)", ReactionPayload{}},
+	{MessageTypeAck, DirectionServerToClient, "Successful completion of a command sent with request_id", AckPayload{}},
+	{MessageTypePhaseTimeout, DirectionServerToClient, "A phase deadline passed and its fallback was applied", PhaseTimeoutPayload{}},
+}
+
+// clientMessages indexes the messages a client may send
+var clientMessages = func() map[MessageType]MessageSpec {
+	specs := make(map[MessageType]MessageSpec)
+	for _, spec := range messageSpecs {
+		if spec.Direction == DirectionClientToServer {
+			specs[spec.Type] = spec
+		}
+	}
+	return specs
+}()
+
+// maxRequestIDLength bounds the request ID a client may attach to a command
+const maxRequestIDLength = 64
+
+// inboundMessage is the envelope of a client message before its payload is decoded
+type inboundMessage struct {
+	Type      MessageType     `json:"type"`
+	RequestID string          `json:"request_id,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// Command is a decoded client message
+type Command struct {
+	Type      MessageType
+	RequestID string      // optional ID echoed in the ACK or ERROR reply
+	Payload   interface{} // pointer to the typed payload, or nil when the message has none
+}
+
+// decodeMessage parses a client frame into a command with a typed payload.
+// Unknown fields are rejected and the payload is validated. The returned
+// command carries whatever type and request ID could be read, even on error.
+func decodeMessage(data []byte) (Command, error) {
+	var envelope inboundMessage
+	if err := decodeStrict(data, &envelope); err != nil {
+		return Command{}, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	command := Command{Type: envelope.Type, RequestID: envelope.RequestID}
+	if len(envelope.RequestID) > maxRequestIDLength {
+		command.RequestID = ""
+		return command, fmt.Errorf("%w: request_id is too long", ErrInvalidEnvelope)
+	}
+
+	spec, ok := clientMessages[envelope.Type]
+	if !ok {
+		return command, fmt.Errorf("%w: %s", ErrUnknownMessageType, envelope.Type)
+	}
+	if spec.Payload == nil {
+		return command, nil
+	}
+
+	payload := reflect.New(reflect.TypeOf(spec.Payload)).Interface()
+	if len(envelope.Payload) > 0 && !bytes.Equal(envelope.Payload, []byte("null")) {
+		if err := decodeStrict(envelope.Payload, payload); err != nil {
+			return command, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+		}
+	}
+	if v, ok := payload.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return command, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+		}
+	}
+
+	command.Payload = payload
+	return command, nil
+}
+
+// decodeStrict unmarshals a single JSON value, rejecting unknown fields
+func decodeStrict(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("unexpected data after JSON value")
+	}
+	return nil
+}
+
+// decodeErrorCode returns the client-facing error code for a decoding error
+func decodeErrorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrUnknownMessageType):
+		return "UNKNOWN_MESSAGE_TYPE"
+	case errors.Is(err, ErrInvalidPayload):
+		return "INVALID_PAYLOAD"
+	default:
+		return "INVALID_MESSAGE"
+	}
+}
+
+// Schema is a machine-readable description of the protocol
+type Schema struct {
+	Protocol       string          `json:"protocol"`
+	Subprotocols   []string        `json:"subprotocols"` // accepted subprotocols, one per encoding
+	MaxMessageSize int64           `json:"max_message_size"`
+	Messages       []MessageSchema `json:"messages"`
+}
+
+// MessageSchema describes one message type
+type MessageSchema struct {
+	Type        MessageType            `json:"type"`
+	Direction   Direction              `json:"direction"`
+	Description string                 `json:"description"`
+	Payload     map[string]interface{} `json:"payload,omitempty"` // JSON Schema of the payload
+}
+
+// BuildSchema describes every registered message with a JSON Schema of its payload
+func BuildSchema(maxMessageSize int64) Schema {
+	schema := Schema{
+		Protocol:       ProtocolVersion,
+		Subprotocols:   Subprotocols(),
+		MaxMessageSize: maxMessageSize,
+		Messages:       make([]MessageSchema, 0, len(messageSpecs)),
+	}
+	for _, spec := range messageSpecs {
+		message := MessageSchema{
+			Type:        spec.Type,
+			Direction:   spec.Direction,
+			Description: spec.Description,
+		}
+		if spec.Payload != nil {
+			message.Payload = jsonSchema(reflect.TypeOf(spec.Payload))
+		}
+		schema.Messages = append(schema.Messages, message)
+	}
+	return schema
+}
+
+// jsonSchema derives a JSON Schema from a Go type using its json tags.
+// Fields without omitempty are required.
+func jsonSchema(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return jsonSchema(t.Elem())
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": jsonSchema(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			properties[name] = jsonSchema(field.Type)
+			if !strings.Contains(opts, "omitempty") {
+				required = append(required, name)
+			}
+		}
+		schema := map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		return map[string]interface{}{}
+	}
+}
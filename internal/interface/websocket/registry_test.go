@@ -0,0 +1,243 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	infrastructureBackplane "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/backplane"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+)
+
+func TestDecodeMessage(t *testing.T) {
+	t.Run("登録済みのメッセージは型付きのペイロードに変換されること", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"type":"CHAT_SEND","payload":{"body":"hello"}}`)
+
+		// act
+		command, err := decodeMessage(data)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if command.Type != MessageTypeChatSend {
+			t.Errorf("Expected CHAT_SEND, got: %s", command.Type)
+		}
+		p, ok := command.Payload.(*ChatSendPayload)
+		if !ok || p.Body != "hello" {
+			t.Errorf("Expected *ChatSendPayload with body, got: %#v", command.Payload)
+		}
+	})
+
+	t.Run("ペイロードのないメッセージはペイロードを省略できること", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"type":"PING"}`)
+
+		// act
+		command, err := decodeMessage(data)
+
+		// assert
+		if err != nil || command.Type != MessageTypePing || command.Payload != nil {
+			t.Errorf("Expected PING without payload, got: %+v %v", command, err)
+		}
+	})
+
+	t.Run("ペイロードの未知のフィールドはINVALID_PAYLOADになること", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"type":"CHAT_SEND","payload":{"body":"hello","extra":1}}`)
+
+		// act
+		_, err := decodeMessage(data)
+
+		// assert
+		if !errors.Is(err, ErrInvalidPayload) {
+			t.Errorf("Expected ErrInvalidPayload, got: %v", err)
+		}
+		if decodeErrorCode(err) != "INVALID_PAYLOAD" {
+			t.Errorf("Expected INVALID_PAYLOAD, got: %s", decodeErrorCode(err))
+		}
+	})
+
+	t.Run("エンベロープの未知のフィールドはINVALID_MESSAGEになること", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"type":"PING","payload":null,"extra":true}`)
+
+		// act
+		_, err := decodeMessage(data)
+
+		// assert
+		if !errors.Is(err, ErrInvalidEnvelope) || decodeErrorCode(err) != "INVALID_MESSAGE" {
+			t.Errorf("Expected ErrInvalidEnvelope, got: %v", err)
+		}
+	})
+
+	t.Run("ペイロードが不正でもリクエストIDは読み取られること", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"type":"CHAT_SEND","request_id":"req-1","payload":{}}`)
+
+		// act
+		command, err := decodeMessage(data)
+
+		// assert
+		if !errors.Is(err, ErrInvalidPayload) {
+			t.Errorf("Expected ErrInvalidPayload, got: %v", err)
+		}
+		if command.RequestID != "req-1" {
+			t.Errorf("Expected request ID req-1, got: %s", command.RequestID)
+		}
+	})
+
+	t.Run("長すぎるリクエストIDはINVALID_MESSAGEになること", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"type":"PING","request_id":"` + strings.Repeat("a", maxRequestIDLength+1) + `"}`)
+
+		// act
+		command, err := decodeMessage(data)
+
+		// assert
+		if !errors.Is(err, ErrInvalidEnvelope) {
+			t.Errorf("Expected ErrInvalidEnvelope, got: %v", err)
+		}
+		if command.RequestID != "" {
+			t.Errorf("Expected request ID not to be echoed, got: %s", command.RequestID)
+		}
+	})
+
+	t.Run("サーバーからのメッセージ種別はUNKNOWN_MESSAGE_TYPEになること", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"type":"STATE_UPDATE","payload":{}}`)
+
+		// act
+		_, err := decodeMessage(data)
+
+		// assert
+		if !errors.Is(err, ErrUnknownMessageType) || decodeErrorCode(err) != "UNKNOWN_MESSAGE_TYPE" {
+			t.Errorf("Expected ErrUnknownMessageType, got: %v", err)
+		}
+	})
+
+	t.Run("バリデーションに失敗した場合はINVALID_PAYLOADになること", func(t *testing.T) {
+		// arrange
+		cases := map[string]string{
+			"必須項目なし":         `{"type":"CLIENT_CONNECTED","payload":{"user_name":"a"}}`,
+			"ペイロードがnull":     `{"type":"CHAT_DELETE","payload":null}`,
+			"dummyIndexが範囲外": `{"type":"SUBMIT_TOPIC","payload":{"displayedEmojis":["a"],"originalEmojis":[],"dummyIndex":1,"dummyEmoji":"a"}}`,
+			"型が異なる":          `{"type":"SEND_REACTION","payload":{"emoji":1}}`,
+		}
+
+		for name, data := range cases {
+			// act
+			_, err := decodeMessage([]byte(data))
+
+			// assert
+			if !errors.Is(err, ErrInvalidPayload) {
+				t.Errorf("%s: expected ErrInvalidPayload, got: %v", name, err)
+			}
+		}
+	})
+}
+
+func TestBuildSchema(t *testing.T) {
+	t.Run("全てのメッセージがペイロードのスキーマと共に記述されること", func(t *testing.T) {
+		// act
+		schema := BuildSchema(1024)
+
+		// assert
+		if schema.Protocol != ProtocolVersion || schema.MaxMessageSize != 1024 {
+			t.Errorf("Unexpected header: %s %d", schema.Protocol, schema.MaxMessageSize)
+		}
+		if len(schema.Messages) != len(messageSpecs) {
+			t.Fatalf("Expected %d messages, got: %d", len(messageSpecs), len(schema.Messages))
+		}
+
+		var chatSend *MessageSchema
+		for i := range schema.Messages {
+			if schema.Messages[i].Type == MessageTypeChatSend {
+				chatSend = &schema.Messages[i]
+			}
+		}
+		if chatSend == nil || chatSend.Direction != DirectionClientToServer {
+			t.Fatalf("Expected CHAT_SEND to be described as a client message, got: %+v", chatSend)
+		}
+		properties := chatSend.Payload["properties"].(map[string]interface{})
+		if _, ok := properties["body"]; !ok {
+			t.Errorf("Expected body property, got: %v", properties)
+		}
+		required := chatSend.Payload["required"].([]string)
+		if len(required) != 1 || required[0] != "body" {
+			t.Errorf("Expected body to be required, got: %v", required)
+		}
+	})
+}
+
+func TestHandleMessageCorrelation(t *testing.T) {
+	type fixture struct {
+		handler *Handler
+		client  *Client
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		hub := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, infrastructureBackplane.NewInMemoryBackplane(), infrastructureClock.NewSystemClock())
+		go hub.Run()
+		client := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+		hub.Register(client, nil)
+
+		return &fixture{
+			handler: &Handler{hub: hub},
+			client:  client,
+		}
+	}
+
+	receive := func(t *testing.T, client *Client) Message {
+		t.Helper()
+		select {
+		case out := <-client.send:
+			var msg Message
+			if err := json.Unmarshal(out.data, &msg); err != nil {
+				t.Fatalf("Expected valid message, got: %v", err)
+			}
+			return msg
+		case <-time.After(time.Second):
+			t.Fatal("Expected a reply")
+			return Message{}
+		}
+	}
+
+	t.Run("失敗したコマンドのERRORにはリクエストIDが含まれること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		f.handler.handleMessage(f.client, []byte(`{"type":"ANSWERING","request_id":"req-1","payload":{"answer":""}}`))
+
+		// assert
+		msg := receive(t, f.client)
+		if msg.Type != MessageTypeError || msg.RequestID != "req-1" {
+			t.Errorf("Expected ERROR for req-1, got: %s %q", msg.Type, msg.RequestID)
+		}
+	})
+
+	t.Run("PINGへのPONGにはリクエストIDが含まれACKは送られないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		f.handler.handleMessage(f.client, []byte(`{"type":"PING","request_id":"req-2"}`))
+
+		// assert
+		msg := receive(t, f.client)
+		if msg.Type != MessageTypePong || msg.RequestID != "req-2" {
+			t.Errorf("Expected PONG for req-2, got: %s %q", msg.Type, msg.RequestID)
+		}
+		select {
+		case out := <-f.client.send:
+			t.Errorf("Expected no further reply, got: %s", out.data)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
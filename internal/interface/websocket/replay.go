@@ -0,0 +1,101 @@
+package websocket
+
+import "time"
+
+// StreamPosition identifies a broadcast of a room. Sequence numbers start over
+// whenever the backplane counter of the room does, so a position is only
+// comparable with those of the same epoch.
+type StreamPosition struct {
+	Epoch string
+	Seq   uint64
+}
+
+// bufferedMessage is a broadcast message kept for replay
+type bufferedMessage struct {
+	seq   uint64
+	frame *frame
+}
+
+// roomStream holds the sequence counter and replay buffer of a room
+type roomStream struct {
+	epoch      string
+	seq        uint64
+	buffer     []bufferedMessage // ring buffer
+	head       int               // index of the oldest message
+	count      int
+	emptySince time.Time // zero while the room has clients
+}
+
+// newRoomStream creates a roomStream that keeps up to size messages
+func newRoomStream(size int) *roomStream {
+	if size < 1 {
+		size = 1
+	}
+	return &roomStream{
+		buffer: make([]bufferedMessage, size),
+	}
+}
+
+// advance moves the stream to seq of epoch. When messages were skipped, for example
+// because this instance joined the room late or lost its backplane connection, or
+// the counter started over in a new epoch, the buffer is cleared so that clients
+// resuming from before the gap get a full snapshot.
+func (s *roomStream) advance(epoch string, seq uint64) {
+	if epoch != s.epoch || seq != s.seq+1 {
+		s.reset()
+	}
+	s.epoch = epoch
+	s.seq = seq
+}
+
+// position returns the position of the latest message of the stream
+func (s *roomStream) position() StreamPosition {
+	return StreamPosition{Epoch: s.epoch, Seq: s.seq}
+}
+
+// reset empties the buffer, keeping the sequence
+func (s *roomStream) reset() {
+	s.head = 0
+	s.count = 0
+}
+
+// append stores a message, evicting the oldest one when the buffer is full
+func (s *roomStream) append(seq uint64, f *frame) {
+	if s.count < len(s.buffer) {
+		s.buffer[(s.head+s.count)%len(s.buffer)] = bufferedMessage{seq: seq, frame: f}
+		s.count++
+		return
+	}
+	s.buffer[s.head] = bufferedMessage{seq: seq, frame: f}
+	s.head = (s.head + 1) % len(s.buffer)
+}
+
+// since returns the messages after from.
+// It reports false when the gap can no longer be filled from the buffer,
+// including when from belongs to another epoch.
+func (s *roomStream) since(from StreamPosition) ([]*frame, bool) {
+	if from.Epoch != s.epoch || from.Seq > s.seq {
+		return nil, false
+	}
+	lastSeq := from.Seq
+	if lastSeq == s.seq {
+		return []*frame{}, true
+	}
+	if s.count == 0 {
+		return nil, false
+	}
+
+	oldest := s.buffer[s.head].seq
+	if lastSeq+1 < oldest {
+		return nil, false
+	}
+
+	missed := make([]*frame, 0, s.seq-lastSeq)
+	for i := 0; i < s.count; i++ {
+		msg := s.buffer[(s.head+i)%len(s.buffer)]
+		if msg.seq > lastSeq {
+			missed = append(missed, msg.frame)
+		}
+	}
+	return missed, true
+}
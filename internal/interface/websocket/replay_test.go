@@ -0,0 +1,197 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	infrastructureBackplane "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/backplane"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+)
+
+func TestRoomStreamAdvance(t *testing.T) {
+	t.Run("シーケンスが飛んだ場合はギャップ前からの再送ができないこと", func(t *testing.T) {
+		// arrange
+		s := newRoomStream(5)
+		for _, seq := range []uint64{1, 2, 5, 6} {
+			s.advance("epoch-1", seq)
+			s.append(seq, newFrame([]byte(fmt.Sprintf("%d", seq)), StreamPosition{}))
+		}
+
+		// act
+		_, okBeforeGap := s.since(StreamPosition{Epoch: "epoch-1", Seq: 2})
+		got, okAfterGap := s.since(StreamPosition{Epoch: "epoch-1", Seq: 4})
+
+		// assert
+		if okBeforeGap {
+			t.Error("Expected messages before the gap not to be replayable")
+		}
+		if !okAfterGap {
+			t.Fatal("Expected messages after the gap to be replayable")
+		}
+		if len(got) != 2 || string(got[0].json.data) != "5" || string(got[1].json.data) != "6" {
+			t.Errorf("Expected messages 5 and 6, got: %d messages", len(got))
+		}
+	})
+}
+
+func TestRoomStreamEpoch(t *testing.T) {
+	t.Run("エポックが変わった場合は以前のエポックからの再送ができないこと", func(t *testing.T) {
+		// arrange
+		s := newRoomStream(5)
+		s.advance("epoch-1", 1)
+		s.append(1, newFrame([]byte("1"), StreamPosition{}))
+		s.advance("epoch-2", 1)
+		s.append(1, newFrame([]byte("1"), StreamPosition{}))
+
+		// act
+		_, okOldEpoch := s.since(StreamPosition{Epoch: "epoch-1", Seq: 1})
+		got, okNewEpoch := s.since(StreamPosition{Epoch: "epoch-2", Seq: 0})
+
+		// assert
+		if okOldEpoch {
+			t.Error("Expected a position of the previous epoch not to be resumable")
+		}
+		if !okNewEpoch || len(got) != 1 {
+			t.Errorf("Expected the message of the new epoch to be replayable, got: %v with %d messages", okNewEpoch, len(got))
+		}
+	})
+}
+
+func TestRoomStreamSince(t *testing.T) {
+	newStream := func(size, messages int) *roomStream {
+		s := newRoomStream(size)
+		for i := 0; i < messages; i++ {
+			seq := uint64(i + 1)
+			s.advance("epoch-1", seq)
+			s.append(seq, newFrame([]byte(fmt.Sprintf("%d", seq)), StreamPosition{}))
+		}
+		return s
+	}
+
+	tests := []struct {
+		name     string
+		size     int
+		messages int
+		lastSeq  uint64
+		want     []string
+		wantOK   bool
+	}{
+		{name: "欠損分のメッセージが順番に返されること", size: 5, messages: 4, lastSeq: 2, want: []string{"3", "4"}, wantOK: true},
+		{name: "最新まで受信済みの場合は空で返されること", size: 5, messages: 4, lastSeq: 4, want: []string{}, wantOK: true},
+		{name: "バッファが一周した後も保持分は再送できること", size: 3, messages: 7, lastSeq: 4, want: []string{"5", "6", "7"}, wantOK: true},
+		{name: "バッファから溢れた欠損は再送できないこと", size: 3, messages: 7, lastSeq: 3, wantOK: false},
+		{name: "未来のシーケンスは再送できないこと", size: 3, messages: 2, lastSeq: 5, wantOK: false},
+		{name: "初回接続（0）でバッファが溢れていない場合は全件返されること", size: 5, messages: 2, lastSeq: 0, want: []string{"1", "2"}, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			s := newStream(tt.size, tt.messages)
+
+			// act
+			got, ok := s.since(StreamPosition{Epoch: "epoch-1", Seq: tt.lastSeq})
+
+			// assert
+			if ok != tt.wantOK {
+				t.Fatalf("Expected ok %v, got: %v", tt.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %d messages, got: %d", len(tt.want), len(got))
+			}
+			for i := range got {
+				if string(got[i].json.data) != tt.want[i] {
+					t.Errorf("Expected message %s at %d, got: %s", tt.want[i], i, got[i].json.data)
+				}
+			}
+		})
+	}
+}
+
+func TestHubRegisterResume(t *testing.T) {
+	newHub := func(t *testing.T, bufferSize, messages int) *Hub {
+		t.Helper()
+
+		hub := NewHub(Config{ReplayBufferSize: bufferSize, ReplayRetention: time.Minute}, infrastructureBackplane.NewInMemoryBackplane(), infrastructureClock.NewSystemClock())
+		go hub.Run()
+
+		// A client must be present for the room to receive broadcasts in order
+		observer := &Client{send: make(chan *outbound, messages), roomID: "room-1"}
+		hub.Register(observer, nil)
+		for i := 0; i < messages; i++ {
+			hub.Broadcast("room-1", Message{Type: MessageTypeTimerTick})
+		}
+		for i := 0; i < messages; i++ {
+			<-observer.send
+		}
+		return hub
+	}
+
+	t.Run("再接続時に欠損したメッセージが再送されること", func(t *testing.T) {
+		// arrange
+		hub := newHub(t, 10, 5)
+		from := StreamPosition{Epoch: hub.Position("room-1").Epoch, Seq: 3}
+		client := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+
+		// act
+		resumed := hub.Register(client, &from)
+
+		// assert
+		if !resumed {
+			t.Fatal("Expected client to be resumed")
+		}
+		if len(client.send) != 2 {
+			t.Fatalf("Expected 2 replayed messages, got: %d", len(client.send))
+		}
+		var msg Message
+		if err := json.Unmarshal((<-client.send).data, &msg); err != nil {
+			t.Fatalf("Expected valid message, got: %v", err)
+		}
+		if msg.Seq != 4 {
+			t.Errorf("Expected first replayed seq 4, got: %d", msg.Seq)
+		}
+	})
+
+	t.Run("欠損が古すぎる場合はスナップショットが必要と判定されること", func(t *testing.T) {
+		// arrange
+		hub := newHub(t, 3, 10)
+		from := StreamPosition{Epoch: hub.Position("room-1").Epoch, Seq: 2}
+		client := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+
+		// act
+		resumed := hub.Register(client, &from)
+
+		// assert
+		if resumed {
+			t.Error("Expected client not to be resumed")
+		}
+		if len(client.send) != 0 {
+			t.Errorf("Expected no replayed messages, got: %d", len(client.send))
+		}
+		if hub.Position("room-1").Seq != 10 {
+			t.Errorf("Expected last seq 10, got: %d", hub.Position("room-1").Seq)
+		}
+	})
+	t.Run("エポックが異なる場合はスナップショットが必要と判定されること", func(t *testing.T) {
+		// arrange
+		hub := newHub(t, 10, 5)
+		from := StreamPosition{Epoch: "previous-epoch", Seq: 3}
+		client := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+
+		// act
+		resumed := hub.Register(client, &from)
+
+		// assert
+		if resumed {
+			t.Error("Expected client not to be resumed")
+		}
+		if len(client.send) != 0 {
+			t.Errorf("Expected no replayed messages, got: %d", len(client.send))
+		}
+	})
+}
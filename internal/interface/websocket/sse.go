@@ -0,0 +1,161 @@
+package websocket
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// sseRetry is the reconnection delay suggested to EventSource clients
+const sseRetry = 3 * time.Second
+
+// HandleEventStream handles GET /api/rooms/:room_id/events.
+// It streams the same messages the hub delivers to WebSocket clients of the room
+// as Server-Sent Events, for networks that block WebSocket upgrades. Sequenced
+// messages carry their epoch and seq as the event ID so that reconnecting clients
+// resume from Last-Event-ID. Commands are sent over the HTTP API instead.
+func (h *Handler) HandleEventStream(c echo.Context) error {
+	req := c.Request()
+	if h.upgrader.CheckOrigin != nil && !h.upgrader.CheckOrigin(req) {
+		return echo.NewHTTPError(http.StatusForbidden, "origin not allowed")
+	}
+	if h.hub.closing.Load() {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, restartCloseReason)
+	}
+	h.writers.Add(1)
+	defer h.writers.Done()
+
+	roomID := c.Param("room_id")
+	userID := c.QueryParam("user_id")
+
+	// EventSource sends Last-Event-ID on reconnect; last_event_id lets clients set it on the first connection
+	var from *StreamPosition
+	value := req.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = c.QueryParam("last_event_id")
+	}
+	if value != "" {
+		position, err := parseEventID(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Last-Event-ID must be an event ID sent by this stream")
+		}
+		from = &position
+	}
+
+	// Only participants of the room may receive the messages addressed to a user
+	var identity ClientIdentity
+	if userID != "" {
+		found, err := h.findIdentity(roomID, userID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		}
+		identity = found
+	}
+
+	client := &Client{
+		send:   make(chan *outbound, h.sendBufferSize),
+		roomID: roomID,
+		userID: userID,
+	}
+	resumed := h.hub.Register(client, from)
+	defer func() {
+		h.hub.Unregister(client)
+		if client.userID != "" {
+			h.hub.MarkOffline(client.roomID, client.userID)
+		}
+	}()
+
+	if userID != "" {
+		h.hub.MarkOnline(roomID, userID)
+		h.hub.Identify(client, identity)
+	}
+	if !resumed {
+		h.sendInitialRoomState(client)
+		h.sendChatHistory(client)
+	}
+	if userID != "" {
+		h.broadcastParticipantUpdate(roomID)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	fmt.Fprintf(res, "retry: %d\n\n", sseRetry.Milliseconds())
+	res.Flush()
+
+	rc := http.NewResponseController(res)
+	ticker := time.NewTicker(h.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return nil
+
+		case message, ok := <-client.send:
+			if !ok {
+				// The hub closed the stream; the client reconnects with Last-Event-ID
+				if client.closeReason != "" {
+					fmt.Fprintf(res, "event: close\ndata: %s\n\n", client.closeReason)
+					res.Flush()
+				}
+				return nil
+			}
+			rc.SetWriteDeadline(time.Now().Add(h.writeWait))
+			if err := writeEvent(res, message); err != nil {
+				log.Printf("SSE write error: %v", err)
+				return nil
+			}
+			res.Flush()
+
+		case <-ticker.C:
+			rc.SetWriteDeadline(time.Now().Add(h.writeWait))
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+// eventID formats a stream position as an SSE event ID
+func eventID(position StreamPosition) string {
+	return fmt.Sprintf("%s:%d", position.Epoch, position.Seq)
+}
+
+// parseEventID parses an event ID written by eventID. A bare seq is accepted
+// without an epoch, which never matches a stream, so it only triggers a snapshot.
+func parseEventID(id string) (StreamPosition, error) {
+	epoch, seq := "", id
+	if i := strings.LastIndex(id, ":"); i >= 0 {
+		epoch, seq = id[:i], id[i+1:]
+	}
+	value, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return StreamPosition{}, err
+	}
+	return StreamPosition{Epoch: epoch, Seq: value}, nil
+}
+
+// writeEvent writes a hub message as an SSE event, using its position as the event ID
+func writeEvent(w http.ResponseWriter, message *outbound) error {
+	var buf bytes.Buffer
+	if message.position.Seq > 0 {
+		fmt.Fprintf(&buf, "id: %s\n", eventID(message.position))
+	}
+	buf.WriteString("data: ")
+	buf.Write(message.data)
+	buf.WriteString("\n\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,180 @@
+package websocket
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	infrastructureBackplane "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/backplane"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+)
+
+func TestHandleEventStream(t *testing.T) {
+	type fixture struct {
+		hub    *Hub
+		server *httptest.Server
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		cfg := Config{
+			ReplayBufferSize: 10,
+			ReplayRetention:  time.Minute,
+			PingInterval:     time.Minute,
+			PongWait:         2 * time.Minute,
+			WriteWait:        time.Second,
+		}
+		hub := NewHub(cfg, infrastructureBackplane.NewInMemoryBackplane(), infrastructureClock.NewSystemClock())
+		go hub.Run()
+
+		h := &Handler{
+			hub:             hub,
+			sendBufferSize:  cfg.ReplayBufferSize,
+			pingInterval:    cfg.PingInterval,
+			writeWait:       cfg.WriteWait,
+			participantRepo: persistence.NewInMemoryParticipantRepository(persistence.NewMemoryStore()),
+		}
+		e := echo.New()
+		e.GET("/api/rooms/:room_id/events", h.HandleEventStream)
+		server := httptest.NewServer(e)
+		t.Cleanup(server.Close)
+
+		return &fixture{hub: hub, server: server}
+	}
+
+	// readEvent reads lines until the end of the next event that carries data
+	readEvent := func(t *testing.T, r *bufio.Reader) (id, data string) {
+		t.Helper()
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				t.Fatalf("Expected an event, got: %v", err)
+			}
+			line = strings.TrimRight(line, "\n")
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			case line == "" && data != "":
+				return id, data
+			}
+		}
+	}
+
+	t.Run("Last-Event-ID以降のメッセージが再送され新しいブロードキャストも届くこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		watcher := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+		f.hub.Register(watcher, nil) // keeps the room stream alive
+		f.hub.Broadcast("room-1", Message{Type: MessageTypeTimerTick, Payload: TimerTickPayload{Time: "1"}})
+		f.hub.Broadcast("room-1", Message{Type: MessageTypeTimerTick, Payload: TimerTickPayload{Time: "2"}})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, f.server.URL+"/api/rooms/room-1/events", nil)
+		<-watcher.send
+		<-watcher.send // both broadcasts have advanced the room stream
+		epoch := f.hub.Position("room-1").Epoch
+		req.Header.Set("Last-Event-ID", epoch+":1")
+
+		// act
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		defer res.Body.Close()
+		r := bufio.NewReader(res.Body)
+		replayedID, replayed := readEvent(t, r)
+		f.hub.Broadcast("room-1", Message{Type: MessageTypeTimerTick, Payload: TimerTickPayload{Time: "3"}})
+		liveID, live := readEvent(t, r)
+
+		// assert
+		if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+			t.Errorf("Expected text/event-stream, got: %s", ct)
+		}
+		if replayedID != epoch+":2" || !strings.Contains(replayed, `"time":"2"`) {
+			t.Errorf("Expected replay of seq 2, got: id=%s data=%s", replayedID, replayed)
+		}
+		if liveID != epoch+":3" || !strings.Contains(live, `"time":"3"`) {
+			t.Errorf("Expected live event with seq 3, got: id=%s data=%s", liveID, live)
+		}
+	})
+
+	t.Run("一時的なメッセージにはイベントIDが付かないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		watcher := &Client{send: make(chan *outbound, 10), roomID: "room-1"}
+		f.hub.Register(watcher, nil)
+		f.hub.Broadcast("room-1", Message{Type: MessageTypeTimerTick})
+		<-watcher.send
+		lastEventID := url.QueryEscape(f.hub.Position("room-1").Epoch + ":1")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, f.server.URL+"/api/rooms/room-1/events?last_event_id="+lastEventID, nil)
+
+		// act
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		defer res.Body.Close()
+		r := bufio.NewReader(res.Body)
+		if _, err := r.ReadString('\n'); err != nil { // retry line means the stream is registered
+			t.Fatalf("Expected stream to start, got: %v", err)
+		}
+		f.hub.BroadcastTransient("room-1", Message{Type: MessageTypeReaction})
+		id, data := readEvent(t, r)
+
+		// assert
+		if id != "" || !strings.Contains(data, string(MessageTypeReaction)) {
+			t.Errorf("Expected reaction without id, got: id=%s data=%s", id, data)
+		}
+	})
+
+	t.Run("不正なLast-Event-IDは400になること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		req, _ := http.NewRequest(http.MethodGet, f.server.URL+"/api/rooms/room-1/events", nil)
+		req.Header.Set("Last-Event-ID", "abc")
+
+		// act
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		res.Body.Close()
+
+		// assert
+		if res.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected 400, got: %d", res.StatusCode)
+		}
+	})
+
+	t.Run("ルームの参加者でないuser_idは403になること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		req, _ := http.NewRequest(http.MethodGet, f.server.URL+"/api/rooms/11111111-1111-1111-1111-111111111111/events?user_id=33333333-3333-3333-3333-333333333333", nil)
+
+		// act
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		res.Body.Close()
+
+		// assert
+		if res.StatusCode != http.StatusForbidden {
+			t.Errorf("Expected 403, got: %d", res.StatusCode)
+		}
+	})
+}
@@ -1,137 +1,481 @@
 package websocket
 
 import (
+	"container/heap"
+	"context"
+	"encoding/json"
 	"fmt"
+	"log"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/backplane"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/lease"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
 )
 
 const (
-	DiscussionDuration = 300 * time.Second // 5 minutes
-	StartDelay         = 5 * time.Second   // 5 seconds
+	// timerStartTopicPrefix prefixes the backplane topics announcing a room countdown
+	timerStartTopicPrefix = "timer/start/"
+	// timerStopTopicPrefix prefixes the backplane topics cancelling a room countdown
+	timerStopTopicPrefix = "timer/stop/"
+	// leaseWorkers bounds the lease calls a timer makes at the same time
+	leaseWorkers = 16
 )
 
-// Timer manages game timers for rooms
+// Timer manages game timers for rooms.
+// Every instance keeps the schedule of each countdown, but only the instance
+// holding the room's lease broadcasts its start and end; clients render the
+// countdown locally from the deadline, so nothing is pushed while it runs.
+// The owner renews the lease while the countdown runs; when it dies, another
+// instance takes the lease over and ends the countdown at the shared deadline.
+// Deadlines are persisted with the room, so countdowns are restored after a restart.
+//
+// A single scheduler goroutine drives the countdowns of all rooms from a queue
+// ordered by their next event, so an instance runs one goroutine and one clock
+// timer however many rooms are counting down. The rooms due at the same time
+// are broadcast in one batch, and lease renewals run on a bounded pool of workers.
 type Timer struct {
 	hub        *Hub
-	timers     map[string]*RoomTimer
-	timerMutex sync.RWMutex
+	backplane  backplane.Backplane
+	leases     lease.Repository
+	clock      clock.Clock
+	instanceID string
+	leaseTTL   time.Duration
+
+	mu        sync.Mutex
+	timers    map[string]*RoomTimer
+	queue     timerQueue
+	closed    bool           // set on shutdown; no countdown is scheduled afterwards
+	leaseWork sync.WaitGroup // lease batches in flight; added to only while holding mu and not closed
+
+	wake     chan struct{} // tells the scheduler that the queue has changed
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
 }
 
-// RoomTimer represents a timer for a specific room
+// RoomTimer represents the countdown of a room in the schedule.
+// Its fields are guarded by the mutex of the Timer.
 type RoomTimer struct {
+	roomID   string
+	owner    string // lease owner, unique to this instance and countdown
+	startsAt time.Time
+	endsAt   time.Time
+	started  bool      // the start delay has passed
+	nextTick time.Time // start of the countdown, then its end
+	renewAt  time.Time // next renewal of the lease
+	owned    bool      // this instance holds the lease and broadcasts the countdown
+	renewing bool      // a lease renewal is in flight
+	stopped  bool      // removed from the schedule
+	index    int       // position in the queue
+}
+
+// due returns the time of the next event of the countdown
+func (rt *RoomTimer) due() time.Time {
+	if rt.renewAt.Before(rt.nextTick) {
+		return rt.renewAt
+	}
+	return rt.nextTick
+}
+
+// timerQueue is a min-heap of countdowns ordered by their next event
+type timerQueue []*RoomTimer
+
+func (q timerQueue) Len() int           { return len(q) }
+func (q timerQueue) Less(i, j int) bool { return q[i].due().Before(q[j].due()) }
+
+func (q timerQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *timerQueue) Push(x any) {
+	rt := x.(*RoomTimer)
+	rt.index = len(*q)
+	*q = append(*q, rt)
+}
+
+func (q *timerQueue) Pop() any {
+	old := *q
+	rt := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return rt
+}
+
+// timerTick is a TIMER_TICK due for a room at the start or end of its countdown
+type timerTick struct {
 	roomID    string
 	remaining time.Duration
-	ticker    *time.Ticker
-	stopChan  chan bool
-	stopped   bool
-	mu        sync.Mutex
+	endsAt    time.Time
+}
+
+// timerSchedule is the backplane payload announcing a countdown
+type timerSchedule struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
 }
 
 // NewTimer creates a new Timer
-func NewTimer(hub *Hub) *Timer {
-	return &Timer{
-		hub:    hub,
-		timers: make(map[string]*RoomTimer),
+func NewTimer(
+	hub *Hub,
+	bp backplane.Backplane,
+	leases lease.Repository,
+	clk clock.Clock,
+	leaseTTL time.Duration,
+) *Timer {
+	t := &Timer{
+		hub:        hub,
+		backplane:  bp,
+		leases:     leases,
+		clock:      clk,
+		instanceID: uuid.NewString(),
+		leaseTTL:   leaseTTL,
+		timers:     make(map[string]*RoomTimer),
+		wake:       make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
 	}
+	bp.Subscribe("timer/", t.receive)
+	return t
 }
 
-// StartTimer starts a timer for a room with initial delay
-func (t *Timer) StartTimer(roomID string) {
-	t.timerMutex.Lock()
-	defer t.timerMutex.Unlock()
+// Run drives the scheduled countdowns until Shutdown is called
+func (t *Timer) Run() {
+	defer close(t.done)
 
-	// Stop existing timer if any
-	if existingTimer, exists := t.timers[roomID]; exists {
-		existingTimer.Stop()
+	alarm := t.clock.NewTimer(t.leaseTTL)
+	defer alarm.Stop()
+
+	for {
+		t.mu.Lock()
+		if len(t.queue) > 0 {
+			alarm.Reset(t.queue[0].due().Sub(t.clock.Now()))
+		} else {
+			alarm.Stop()
+		}
+		t.mu.Unlock()
+
+		select {
+		case <-alarm.C():
+			t.advance()
+		case <-t.wake:
+			// Rearm the alarm for the changed queue
+		case <-t.stop:
+			return
+		}
 	}
+}
 
-	// Wait for start delay, then start the actual timer
-	go func() {
-		time.Sleep(StartDelay)
-
-		roomTimer := &RoomTimer{
-			roomID:    roomID,
-			remaining: DiscussionDuration,
-			ticker:    time.NewTicker(1 * time.Second),
-			stopChan:  make(chan bool),
-			stopped:   false,
+// advance handles every countdown event that is due: the starts and ends of
+// countdowns are broadcast in a batch, and lease renewals are handed to the
+// lease workers
+func (t *Timer) advance() {
+	now := t.clock.Now()
+	var ticks []timerTick
+	var renewals, releases []*RoomTimer
+
+	t.mu.Lock()
+	for len(t.queue) > 0 && !t.queue[0].due().After(now) {
+		rt := t.queue[0]
+
+		if !rt.renewAt.After(now) {
+			renewals = t.appendRenewal(renewals, rt)
+			rt.renewAt = now.Add(t.leaseTTL / 3)
 		}
 
-		t.timerMutex.Lock()
-		t.timers[roomID] = roomTimer
-		t.timerMutex.Unlock()
+		if !rt.nextTick.After(now) {
+			remaining := max(rt.endsAt.Sub(now).Round(time.Second), 0)
+			if rt.owned {
+				ticks = append(ticks, timerTick{roomID: rt.roomID, remaining: remaining, endsAt: rt.endsAt})
+			}
+			if rt.started || remaining == 0 {
+				if t.removeLocked(rt) {
+					releases = append(releases, rt)
+				}
+				continue
+			}
+			rt.started = true
+			rt.nextTick = rt.endsAt
+			if !rt.owned {
+				// The previous countdown of the room may have released the lease in the meantime
+				renewals = t.appendRenewal(renewals, rt)
+			}
+		}
 
-		roomTimer.Run(t.hub)
-	}()
+		heap.Fix(&t.queue, rt.index)
+	}
+	t.startLeaseWorkLocked(renewals, t.acquire)
+	t.startLeaseWorkLocked(releases, t.release)
+	t.mu.Unlock()
+
+	// Ticks only resynchronize the countdown rendered from the deadline at its start
+	// and end, so they are neither sequenced nor kept for replay
+	for _, tick := range ticks {
+		t.hub.BroadcastTransient(tick.roomID, Message{
+			Type: MessageTypeTimerTick,
+			Payload: TimerTickPayload{
+				Time:       formatTime(tick.remaining),
+				EndsAt:     tick.endsAt,
+				ServerTime: now,
+			},
+		})
+	}
 }
 
-// StopTimer stops the timer for a room
+// appendRenewal adds a countdown to a renewal batch unless a renewal is already in flight.
+// Callers must hold t.mu.
+func (t *Timer) appendRenewal(renewals []*RoomTimer, rt *RoomTimer) []*RoomTimer {
+	if rt.renewing {
+		return renewals
+	}
+	rt.renewing = true
+	return append(renewals, rt)
+}
+
+// notify wakes up the scheduler without blocking
+func (t *Timer) notify() {
+	select {
+	case t.wake <- struct{}{}:
+	default:
+	}
+}
+
+// StartTimer starts the countdown of a room on every instance following its deadline.
+// A paused deadline stops the countdown instead.
+func (t *Timer) StartTimer(roomID string, deadline room.DiscussionDeadline) {
+	if deadline.IsPaused() {
+		t.StopTimer(roomID)
+		return
+	}
+	t.publish(timerStartTopicPrefix+roomID, timerSchedule{
+		StartsAt: deadline.StartsAt(),
+		EndsAt:   deadline.EndsAt(),
+	})
+}
+
+// StopTimer stops the countdown of a room on every instance, including during its start delay
 func (t *Timer) StopTimer(roomID string) {
-	t.timerMutex.Lock()
-	defer t.timerMutex.Unlock()
+	t.publish(timerStopTopicPrefix+roomID, timerSchedule{})
+}
 
-	if timer, exists := t.timers[roomID]; exists {
-		timer.Stop()
-		delete(t.timers, roomID)
+// publish relays a timer command to every instance
+func (t *Timer) publish(topic string, schedule timerSchedule) {
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		log.Printf("Error marshaling timer schedule: %v", err)
+		return
+	}
+	if err := t.backplane.Publish(context.Background(), topic, data); err != nil {
+		log.Printf("Error publishing %s: %v", topic, err)
 	}
 }
 
-// Run starts the room timer
-func (rt *RoomTimer) Run(hub *Hub) {
-	defer func() {
-		rt.ticker.Stop()
-	}()
+// receive applies a timer command relayed by the backplane
+func (t *Timer) receive(envelope backplane.Envelope) {
+	switch {
+	case strings.HasPrefix(envelope.Topic, timerStartTopicPrefix):
+		var schedule timerSchedule
+		if err := json.Unmarshal(envelope.Payload, &schedule); err != nil {
+			log.Printf("Error decoding timer schedule: %v", err)
+			return
+		}
+		t.schedule(strings.TrimPrefix(envelope.Topic, timerStartTopicPrefix), schedule)
 
-	for {
-		select {
-		case <-rt.ticker.C:
-			rt.mu.Lock()
-			if rt.stopped {
-				rt.mu.Unlock()
-				return
-			}
+	case strings.HasPrefix(envelope.Topic, timerStopTopicPrefix):
+		t.stopLocal(strings.TrimPrefix(envelope.Topic, timerStopTopicPrefix))
+	}
+}
 
-			rt.remaining -= 1 * time.Second
-
-			if rt.remaining <= 0 {
-				rt.mu.Unlock()
-				// Timer finished - send final tick
-				hub.Broadcast(rt.roomID, Message{
-					Type: MessageTypeTimerTick,
-					Payload: TimerTickPayload{
-						Time: "00:00",
-					},
-				})
-				return
-			}
+// schedule registers a countdown on this instance, replacing any existing one.
+// The lease is taken by the scheduler right away, before the start delay.
+func (t *Timer) schedule(roomID string, schedule timerSchedule) {
+	t.mu.Lock()
+	defer t.notify()
+	defer t.mu.Unlock()
 
-			// Send timer tick
-			timeStr := formatTime(rt.remaining)
-			rt.mu.Unlock()
+	if t.closed {
+		return
+	}
 
-			hub.Broadcast(rt.roomID, Message{
-				Type: MessageTypeTimerTick,
-				Payload: TimerTickPayload{
-					Time: timeStr,
-				},
-			})
+	var releases []*RoomTimer
+	if existing, exists := t.timers[roomID]; exists && t.removeLocked(existing) {
+		releases = append(releases, existing)
+	}
+	t.startLeaseWorkLocked(releases, t.release)
 
-		case <-rt.stopChan:
-			return
+	roomTimer := &RoomTimer{
+		roomID:   roomID,
+		owner:    fmt.Sprintf("%s/%d", t.instanceID, schedule.StartsAt.UnixNano()),
+		startsAt: schedule.StartsAt,
+		endsAt:   schedule.EndsAt,
+		nextTick: schedule.StartsAt,
+		renewAt:  t.clock.Now(),
+	}
+	t.timers[roomID] = roomTimer
+	heap.Push(&t.queue, roomTimer)
+}
+
+// stopLocal stops the countdown of a room on this instance
+func (t *Timer) stopLocal(roomID string) {
+	t.mu.Lock()
+	defer t.notify()
+	defer t.mu.Unlock()
+
+	if roomTimer, exists := t.timers[roomID]; exists && t.removeLocked(roomTimer) {
+		t.startLeaseWorkLocked([]*RoomTimer{roomTimer}, t.release)
+	}
+}
+
+// removeLocked takes a countdown out of the schedule and reports whether its
+// lease has to be released; a renewal in flight releases it once done.
+// Callers must hold t.mu.
+func (t *Timer) removeLocked(rt *RoomTimer) bool {
+	heap.Remove(&t.queue, rt.index)
+	if t.timers[rt.roomID] == rt {
+		delete(t.timers, rt.roomID)
+	}
+	rt.stopped = true
+	return rt.owned && !rt.renewing
+}
+
+// Restore schedules the countdown of a persisted deadline on this instance only,
+// as every instance restores the rooms on startup. It reports whether the
+// countdown was scheduled, which it is not once paused or over.
+func (t *Timer) Restore(roomID string, deadline room.DiscussionDeadline) bool {
+	if deadline.IsPaused() || !deadline.EndsAt().After(t.clock.Now()) {
+		return false
+	}
+	t.schedule(roomID, timerSchedule{StartsAt: deadline.StartsAt(), EndsAt: deadline.EndsAt()})
+	return true
+}
+
+// Shutdown stops the countdowns of this instance and waits for their leases to
+// be released, so another instance takes them over without waiting for the
+// leases to expire
+func (t *Timer) Shutdown(ctx context.Context) error {
+	t.mu.Lock()
+	t.closed = true
+	var releases []*RoomTimer
+	for _, roomTimer := range t.timers {
+		if t.removeLocked(roomTimer) {
+			releases = append(releases, roomTimer)
+		}
+	}
+	t.startLeaseWorkLocked(releases, t.release)
+	t.mu.Unlock()
+	t.stopOnce.Do(func() { close(t.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		<-t.done
+		t.leaseWork.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// leaseName returns the lease that guards the countdown of a room
+func leaseName(roomID string) string {
+	return "timer/" + roomID
+}
+
+// startLeaseWorkLocked applies a lease operation to a batch of countdowns on up
+// to leaseWorkers goroutines, so that slow lease calls never hold up the ticks.
+// Callers must hold t.mu.
+func (t *Timer) startLeaseWorkLocked(rooms []*RoomTimer, op func(rt *RoomTimer)) {
+	if len(rooms) == 0 {
+		return
+	}
+
+	t.leaseWork.Add(1)
+	go func() {
+		defer t.leaseWork.Done()
+
+		jobs := make(chan *RoomTimer)
+		var workers sync.WaitGroup
+		for range min(leaseWorkers, len(rooms)) {
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+				for rt := range jobs {
+					op(rt)
+				}
+			}()
+		}
+		for _, rt := range rooms {
+			jobs <- rt
 		}
+		close(jobs)
+		workers.Wait()
+	}()
+}
+
+// acquire takes or renews the lease of a countdown and records whether this instance owns it
+func (t *Timer) acquire(rt *RoomTimer) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.leaseTTL/3)
+	defer cancel()
+
+	owned, err := t.leases.Acquire(ctx, leaseName(rt.roomID), rt.owner, t.leaseTTL)
+	if err != nil {
+		// Stop ticking rather than risk a second owner; the lease is retried on the next renewal
+		log.Printf("Error acquiring timer lease for room %s: %v", rt.roomID, err)
+		owned = false
+	}
+
+	t.mu.Lock()
+	if owned && !rt.owned && !rt.stopped {
+		log.Printf("Took ownership of timer for room %s", rt.roomID)
+	}
+	rt.owned = owned
+	rt.renewing = false
+	stopped := rt.stopped
+	t.mu.Unlock()
+
+	if stopped && owned {
+		// The countdown was stopped while the lease was being renewed
+		t.release(rt)
 	}
 }
 
-// Stop stops the room timer
-func (rt *RoomTimer) Stop() {
-	rt.mu.Lock()
-	defer rt.mu.Unlock()
+// release gives up the lease of a countdown that this instance owns
+func (t *Timer) release(rt *RoomTimer) {
+	if err := t.leases.Release(context.Background(), leaseName(rt.roomID), rt.owner); err != nil {
+		log.Printf("Error releasing timer lease for room %s: %v", rt.roomID, err)
+	}
+
+	t.mu.Lock()
+	rt.owned = false
+	t.mu.Unlock()
+}
 
-	if !rt.stopped {
-		rt.stopped = true
-		close(rt.stopChan)
+// deadlinePayload returns the discussion countdown of a room for STATE_UPDATE, or nil outside discussing
+func deadlinePayload(foundRoom *room.Room, now time.Time) *DeadlinePayload {
+	deadline := foundRoom.DiscussionDeadline()
+	if foundRoom.Status() != room.StatusDiscussing || deadline == nil {
+		return nil
+	}
+
+	payload := &DeadlinePayload{
+		StartsAt:   deadline.StartsAt(),
+		EndsAt:     deadline.EndsAt(),
+		ServerTime: now,
+	}
+	if remaining := deadline.PausedRemaining(); remaining != nil {
+		ms := remaining.Milliseconds()
+		payload.PausedRemainingMs = &ms
 	}
+	return payload
 }
 
 // formatTime formats duration as "MM:SS"
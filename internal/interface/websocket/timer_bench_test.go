@@ -0,0 +1,88 @@
+package websocket
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	infrastructureBackplane "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/backplane"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+)
+
+// BenchmarkTimerTick measures the start of the countdowns of thousands of
+// concurrent rooms: an op is the batch of ticks broadcast by the scheduler
+// when the countdowns start together. The goroutines metric counts those of
+// the hub and the timer once every countdown is scheduled; it does not grow
+// with the number of rooms.
+func BenchmarkTimerTick(b *testing.B) {
+	for _, rooms := range []int{1000, 10000} {
+		b.Run(fmt.Sprintf("rooms=%d", rooms), func(b *testing.B) {
+			log.SetOutput(io.Discard)
+			defer log.SetOutput(os.Stderr)
+
+			before := runtime.NumGoroutine()
+			bp := infrastructureBackplane.NewInMemoryBackplane()
+			hub := NewHub(Config{ReplayBufferSize: 16, ReplayRetention: time.Minute, HubShards: 16}, bp, infrastructureClock.NewSystemClock())
+			go hub.Run()
+			clk := infrastructureClock.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+			leases := newMemoryLeases()
+			timer := NewTimer(hub, bp, leases, clk, 24*time.Hour)
+			go timer.Run()
+			defer timer.Shutdown(b.Context())
+
+			roomIDs := make([]string, rooms)
+			for i := range roomIDs {
+				roomIDs[i] = fmt.Sprintf("room-%d", i)
+			}
+			// schedule starts every countdown a second from now and waits until the timer owns them
+			schedule := func() {
+				for _, roomID := range roomIDs {
+					timer.StartTimer(roomID, room.NewDiscussionDeadline(clk.Now().Add(time.Second), 24*time.Hour))
+				}
+				waitOwned(b, timer, roomIDs...)
+				clk.BlockUntil(1)
+			}
+			// unschedule stops every countdown and waits until their leases are released
+			unschedule := func() {
+				for _, roomID := range roomIDs {
+					timer.StopTimer(roomID)
+				}
+				for {
+					leases.mu.Lock()
+					held := len(leases.owners)
+					leases.mu.Unlock()
+					if held == 0 {
+						return
+					}
+					time.Sleep(time.Millisecond)
+				}
+			}
+
+			schedule()
+			time.Sleep(10 * time.Millisecond) // let the lease workers exit
+			goroutines := runtime.NumGoroutine() - before
+			unschedule()
+
+			for b.Loop() {
+				b.StopTimer()
+				schedule()
+				b.StartTimer()
+
+				clk.Advance(time.Second)
+				// The scheduler rearms its alarm once the batch has been broadcast
+				clk.BlockUntil(1)
+
+				b.StopTimer()
+				unschedule()
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(goroutines), "goroutines")
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*rooms), "ns/room")
+		})
+	}
+}
@@ -0,0 +1,464 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	infrastructureBackplane "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/backplane"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+)
+
+// memoryLeases is an in-memory lease.Repository whose owners can be made to fail
+type memoryLeases struct {
+	mu      sync.Mutex
+	owners  map[string]string
+	expires map[string]time.Time
+	failing map[string]bool
+}
+
+func newMemoryLeases() *memoryLeases {
+	return &memoryLeases{
+		owners:  make(map[string]string),
+		expires: make(map[string]time.Time),
+		failing: make(map[string]bool),
+	}
+}
+
+func (l *memoryLeases) Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.failing[owner] {
+		return false, errors.New("connection lost")
+	}
+	if holder, ok := l.owners[name]; ok && holder != owner && time.Now().Before(l.expires[name]) {
+		return false, nil
+	}
+	l.owners[name] = owner
+	l.expires[name] = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (l *memoryLeases) Release(ctx context.Context, name, owner string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.owners[name] == owner {
+		delete(l.owners, name)
+		delete(l.expires, name)
+	}
+	return nil
+}
+
+// failHolder makes the current holder of a lease unable to renew it, as if its instance died
+func (l *memoryLeases) failHolder(name string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	holder := l.owners[name]
+	l.failing[holder] = true
+	return holder
+}
+
+func TestTimerOwnership(t *testing.T) {
+	type fixture struct {
+		leases *memoryLeases
+		timerA *Timer
+		timerB *Timer
+		client *Client
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		leases := newMemoryLeases()
+		newInstance := func() (*Hub, *Timer) {
+			hub := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, bp, infrastructureClock.NewSystemClock())
+			go hub.Run()
+			timer := NewTimer(hub, bp, leases, infrastructureClock.NewSystemClock(), 300*time.Millisecond)
+			go timer.Run()
+			return hub, timer
+		}
+		hubA, timerA := newInstance()
+		_, timerB := newInstance()
+
+		client := &Client{send: make(chan *outbound, 20), roomID: "room-1"}
+		hubA.Register(client, nil)
+		return &fixture{leases: leases, timerA: timerA, timerB: timerB, client: client}
+	}
+
+	// receiveTicks collects TIMER_TICK values until the countdown ends
+	receiveTicks := func(t *testing.T, client *Client) []string {
+		t.Helper()
+
+		var ticks []string
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case out := <-client.send:
+				var message struct {
+					Payload TimerTickPayload `json:"payload"`
+				}
+				if err := json.Unmarshal(out.data, &message); err != nil {
+					t.Fatalf("Failed to decode message: %v", err)
+				}
+				ticks = append(ticks, message.Payload.Time)
+				if message.Payload.Time == "00:00" {
+					return ticks
+				}
+			case <-timeout:
+				t.Fatalf("Expected the countdown to finish, got: %v", ticks)
+			}
+		}
+	}
+
+	t.Run("複数インスタンスでもカウントダウンは1つのインスタンスのみが配信すること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		f.timerA.StartTimer("room-1", room.NewDiscussionDeadline(time.Now().Add(500*time.Millisecond), 2*time.Second))
+		ticks := receiveTicks(t, f.client)
+
+		// assert
+		if got := strings.Join(ticks, ","); got != "00:02,00:00" {
+			t.Errorf("Expected the start and the end once, got: %s", got)
+		}
+	})
+
+	t.Run("所有インスタンスがリースを更新できなくなると別インスタンスが引き継ぐこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		f.timerB.StartTimer("room-1", room.NewDiscussionDeadline(time.Now().Add(time.Second), 2*time.Second))
+		time.Sleep(100 * time.Millisecond)
+
+		// act
+		f.leases.failHolder(leaseName("room-1"))
+		ticks := receiveTicks(t, f.client)
+
+		// assert
+		if got := strings.Join(ticks, ","); got != "00:02,00:00" {
+			t.Errorf("Expected the countdown to continue without gaps or duplicates, got: %s", got)
+		}
+	})
+
+	t.Run("停止するとどのインスタンスからも配信されないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		f.timerA.StartTimer("room-1", room.NewDiscussionDeadline(time.Now().Add(500*time.Millisecond), time.Second))
+
+		// act
+		f.timerB.StopTimer("room-1")
+		time.Sleep(2 * time.Second)
+
+		// assert
+		if len(f.client.send) != 0 {
+			t.Errorf("Expected no ticks after stopping, got: %d", len(f.client.send))
+		}
+	})
+}
+
+func TestTimerShutdown(t *testing.T) {
+	type fixture struct {
+		leases *memoryLeases
+		timer  *Timer
+		client *Client
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		hub := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, bp, infrastructureClock.NewSystemClock())
+		go hub.Run()
+
+		f := &fixture{
+			leases: newMemoryLeases(),
+			client: &Client{send: make(chan *outbound, 20), roomID: "room-1"},
+		}
+		f.timer = NewTimer(hub, bp, f.leases, infrastructureClock.NewSystemClock(), 300*time.Millisecond)
+		go f.timer.Run()
+		hub.Register(f.client, nil)
+		return f
+	}
+
+	t.Run("停止時に実行中のカウントダウンのリースが解放されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		f.timer.StartTimer("room-1", room.NewDiscussionDeadline(time.Now(), room.DiscussionDuration))
+		time.Sleep(100 * time.Millisecond)
+
+		// act
+		err := f.timer.Shutdown(context.Background())
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if _, ok := f.leases.owners[leaseName("room-1")]; ok {
+			t.Error("Expected the lease to be released")
+		}
+	})
+
+	t.Run("停止後はカウントダウンが開始されないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		f.timer.Shutdown(context.Background())
+
+		// act
+		f.timer.StartTimer("room-1", room.NewDiscussionDeadline(time.Now().Add(500*time.Millisecond), room.DiscussionDuration))
+		time.Sleep(1500 * time.Millisecond)
+
+		// assert
+		if len(f.client.send) != 0 {
+			t.Errorf("Expected no ticks after shutdown, got: %d", len(f.client.send))
+		}
+	})
+}
+
+// waitOwned waits until the timer holds the lease of every given room
+func waitOwned(tb testing.TB, timer *Timer, roomIDs ...string) {
+	tb.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		timer.mu.Lock()
+		owned := 0
+		for _, roomID := range roomIDs {
+			if rt, ok := timer.timers[roomID]; ok && rt.owned {
+				owned++
+			}
+		}
+		timer.mu.Unlock()
+
+		if owned == len(roomIDs) {
+			return
+		}
+		if time.Now().After(deadline) {
+			tb.Fatalf("Expected the timer to own %d countdowns, got: %d", len(roomIDs), owned)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// receiveTick waits for the next TIMER_TICK broadcast to a client
+func receiveTick(t *testing.T, client *Client) TimerTickPayload {
+	t.Helper()
+
+	select {
+	case out := <-client.send:
+		var message struct {
+			Payload TimerTickPayload `json:"payload"`
+		}
+		if err := json.Unmarshal(out.data, &message); err != nil {
+			t.Fatalf("Failed to decode message: %v", err)
+		}
+		return message.Payload
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a tick")
+		return TimerTickPayload{}
+	}
+}
+
+func TestTimerCountdown(t *testing.T) {
+	type fixture struct {
+		clock  *infrastructureClock.FakeClock
+		hub    *Hub
+		timer  *Timer
+		client *Client
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		hub := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, bp, infrastructureClock.NewSystemClock())
+		go hub.Run()
+
+		f := &fixture{
+			clock:  infrastructureClock.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)),
+			hub:    hub,
+			client: &Client{send: make(chan *outbound, 20), roomID: "room-1"},
+		}
+		f.timer = NewTimer(hub, bp, newMemoryLeases(), f.clock, time.Minute)
+		go f.timer.Run()
+		hub.Register(f.client, nil)
+		return f
+	}
+
+	t.Run("開始遅延の後の開始時と終了時にのみ残り時間が配信されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		deadline := room.NewDiscussionDeadline(f.clock.Now().Add(room.DiscussionStartDelay), room.DiscussionDuration)
+		f.timer.StartTimer("room-1", deadline)
+		waitOwned(t, f.timer, "room-1")
+		f.clock.BlockUntil(1)
+
+		// act
+		f.clock.Advance(room.DiscussionStartDelay)
+		start := receiveTick(t, f.client)
+		startedAt := f.clock.Now()
+		f.clock.BlockUntil(1)
+		f.clock.Advance(room.DiscussionDuration - time.Second)
+		f.clock.BlockUntil(1)
+		pending := len(f.client.send)
+		f.clock.Advance(time.Second)
+		end := receiveTick(t, f.client)
+		f.clock.Advance(time.Minute)
+
+		// assert
+		if start.Time != "05:00" || !start.ServerTime.Equal(startedAt) || !start.EndsAt.Equal(deadline.EndsAt()) {
+			t.Errorf("Expected 05:00 until %v at %v, got: %s until %v at %v", deadline.EndsAt(), startedAt, start.Time, start.EndsAt, start.ServerTime)
+		}
+		if pending != 0 {
+			t.Errorf("Expected no tick while the countdown runs, got: %d", pending)
+		}
+		if end.Time != "00:00" || !end.ServerTime.Equal(deadline.EndsAt()) {
+			t.Errorf("Expected 00:00 at %v, got: %s at %v", deadline.EndsAt(), end.Time, end.ServerTime)
+		}
+		if len(f.client.send) != 0 {
+			t.Errorf("Expected no tick after the end, got: %d", len(f.client.send))
+		}
+		f.timer.mu.Lock()
+		scheduled := len(f.timer.queue)
+		f.timer.mu.Unlock()
+		if scheduled != 0 {
+			t.Errorf("Expected the countdown to leave the schedule, got: %d", scheduled)
+		}
+	})
+
+	t.Run("残り時間の配信にはシーケンス番号が振られずルームのシーケンスが進まないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		f.timer.StartTimer("room-1", room.NewDiscussionDeadline(f.clock.Now().Add(time.Second), room.DiscussionDuration))
+		waitOwned(t, f.timer, "room-1")
+		f.clock.BlockUntil(1)
+
+		// act
+		f.clock.Advance(time.Second)
+		out := <-f.client.send
+
+		// assert
+		var msg Message
+		if err := json.Unmarshal(out.data, &msg); err != nil {
+			t.Fatalf("Expected valid message, got: %v", err)
+		}
+		if msg.Type != MessageTypeTimerTick || msg.Seq != 0 {
+			t.Errorf("Expected an unsequenced TIMER_TICK, got: %s with seq %d", msg.Type, msg.Seq)
+		}
+		if f.hub.Position("room-1").Seq != 0 {
+			t.Errorf("Expected the room seq not to advance, got: %d", f.hub.Position("room-1").Seq)
+		}
+	})
+
+	t.Run("開始遅延の間に停止すると配信されないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		f.timer.StartTimer("room-1", room.NewDiscussionDeadline(f.clock.Now().Add(room.DiscussionStartDelay), room.DiscussionDuration))
+		f.clock.BlockUntil(1)
+
+		// act
+		f.timer.StopTimer("room-1")
+		f.clock.Advance(room.DiscussionStartDelay + time.Second)
+
+		// assert
+		if len(f.client.send) != 0 {
+			t.Errorf("Expected no ticks after stopping, got: %d", len(f.client.send))
+		}
+	})
+}
+
+func TestTimerScheduler(t *testing.T) {
+	t.Run("複数の部屋のカウントダウンの開始が同じ時刻にまとめて配信されること", func(t *testing.T) {
+		// arrange
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		hub := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, bp, infrastructureClock.NewSystemClock())
+		go hub.Run()
+		clk := infrastructureClock.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+		timer := NewTimer(hub, bp, newMemoryLeases(), clk, time.Minute)
+		go timer.Run()
+
+		clients := make(map[string]*Client)
+		for _, roomID := range []string{"room-1", "room-2", "room-3"} {
+			clients[roomID] = &Client{send: make(chan *outbound, 20), roomID: roomID}
+			hub.Register(clients[roomID], nil)
+			timer.StartTimer(roomID, room.NewDiscussionDeadline(clk.Now().Add(time.Second), room.DiscussionDuration))
+		}
+		waitOwned(t, timer, "room-1", "room-2", "room-3")
+		clk.BlockUntil(1)
+
+		// act
+		clk.Advance(time.Second)
+
+		// assert
+		for roomID, client := range clients {
+			if tick := receiveTick(t, client); tick.Time != "05:00" || !tick.ServerTime.Equal(clk.Now()) {
+				t.Errorf("Expected room %s to tick 05:00 at %v, got: %s at %v", roomID, clk.Now(), tick.Time, tick.ServerTime)
+			}
+		}
+	})
+}
+
+func TestTimerRestore(t *testing.T) {
+	newTimer := func(t *testing.T) (*Timer, *infrastructureClock.FakeClock, *Client) {
+		t.Helper()
+
+		bp := infrastructureBackplane.NewInMemoryBackplane()
+		hub := NewHub(Config{ReplayBufferSize: 10, ReplayRetention: time.Minute}, bp, infrastructureClock.NewSystemClock())
+		go hub.Run()
+
+		client := &Client{send: make(chan *outbound, 20), roomID: "room-1"}
+		hub.Register(client, nil)
+		clk := infrastructureClock.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+		timer := NewTimer(hub, bp, newMemoryLeases(), clk, time.Minute)
+		go timer.Run()
+		return timer, clk, client
+	}
+
+	t.Run("永続化された終了時刻からカウントダウンが再開されること", func(t *testing.T) {
+		// arrange
+		timer, clk, client := newTimer(t)
+		deadline := room.NewDiscussionDeadline(clk.Now().Add(-time.Minute), room.DiscussionDuration)
+
+		// act
+		restored := timer.Restore("room-1", deadline)
+		waitOwned(t, timer, "room-1")
+		clk.BlockUntil(1)
+		clk.Advance(4 * time.Minute)
+		tick := receiveTick(t, client)
+
+		// assert
+		if !restored {
+			t.Fatal("Expected the countdown to be restored")
+		}
+		if tick.Time != "00:00" || !tick.ServerTime.Equal(deadline.EndsAt()) {
+			t.Errorf("Expected the countdown to end at the deadline, got: %s at %v", tick.Time, tick.ServerTime)
+		}
+		if !tick.EndsAt.Equal(deadline.EndsAt()) {
+			t.Errorf("Expected the tick to carry the deadline %v, got: %v", deadline.EndsAt(), tick.EndsAt)
+		}
+	})
+
+	t.Run("終了済みや一時停止中のカウントダウンは再開されないこと", func(t *testing.T) {
+		// arrange
+		timer, clk, _ := newTimer(t)
+		ended := room.NewDiscussionDeadline(clk.Now().Add(-10*time.Minute), room.DiscussionDuration)
+		paused, _ := room.NewDiscussionDeadline(clk.Now(), room.DiscussionDuration).Pause(clk.Now())
+
+		// act
+		restoredEnded := timer.Restore("room-1", ended)
+		restoredPaused := timer.Restore("room-1", paused)
+
+		// assert
+		if restoredEnded || restoredPaused {
+			t.Errorf("Expected neither countdown to be restored, got: %v and %v", restoredEnded, restoredPaused)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/usecase/auditlog"
+)
+
+// DeleteRoomInput represents the input for deleting a room
+type DeleteRoomInput struct {
+	RoomID string
+}
+
+// DeleteRoomUseCase deletes a room together with its participants
+type DeleteRoomUseCase struct {
+	roomRepo       room.Repository
+	eventPublisher event.Publisher
+	auditRepo      audit.Repository
+	clock          clock.Clock
+}
+
+// NewDeleteRoomUseCase creates a new DeleteRoomUseCase
+func NewDeleteRoomUseCase(
+	roomRepo room.Repository,
+	eventPublisher event.Publisher,
+	auditRepo audit.Repository,
+	clk clock.Clock,
+) *DeleteRoomUseCase {
+	return &DeleteRoomUseCase{
+		roomRepo:       roomRepo,
+		eventPublisher: eventPublisher,
+		auditRepo:      auditRepo,
+		clock:          clk,
+	}
+}
+
+// Execute deletes a room
+func (uc *DeleteRoomUseCase) Execute(ctx context.Context, input DeleteRoomInput) error {
+	roomID, err := room.NewRoomIDFromString(input.RoomID)
+	if err != nil {
+		return err
+	}
+
+	foundRoom, err := uc.roomRepo.FindByID(ctx, roomID)
+	if err != nil {
+		return ErrRoomNotFound
+	}
+
+	if err := uc.roomRepo.Delete(ctx, roomID); err != nil {
+		return err
+	}
+
+	auditlog.Record(ctx, uc.auditRepo, input.RoomID, audit.NewAdminActor(), audit.ActionDeleteRoom, foundRoom.Status().String(), "", uc.clock.Now())
+
+	uc.eventPublisher.Publish(event.NewRoomDeletedEvent(input.RoomID, uc.clock.Now()))
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+	adminUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/admin"
+)
+
+func TestDeleteRoomUseCaseExecute(t *testing.T) {
+	type fixture struct {
+		roomRepo        room.Repository
+		participantRepo participant.Repository
+		eventPublisher  *mockEventPublisher
+		auditRepo       audit.Repository
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		store := persistence.NewMemoryStore()
+		return &fixture{
+			roomRepo:        persistence.NewInMemoryRoomRepository(store),
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+			eventPublisher:  &mockEventPublisher{},
+			auditRepo:       persistence.NewInMemoryAuditRepository(store),
+		}
+	}
+
+	newUseCase := func(f *fixture) *adminUseCase.DeleteRoomUseCase {
+		return adminUseCase.NewDeleteRoomUseCase(
+			f.roomRepo,
+			f.eventPublisher,
+			f.auditRepo,
+			infrastructureClock.NewFakeClock(testNow),
+		)
+	}
+
+	t.Run("ルームが削除されRoomDeletedEventが発行されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := createTestRoom(room.StatusDiscussing)
+		mustSave(t, f.roomRepo.Save, testRoom)
+		mustSave(t, f.participantRepo.Save, createTestParticipant(testRoom, "550e8400-e29b-41d4-a716-446655440001", participant.RoleHost))
+
+		var publishedEvent event.Event
+		f.eventPublisher.publishFunc = func(evt event.Event) {
+			publishedEvent = evt
+		}
+
+		input := adminUseCase.DeleteRoomInput{
+			RoomID: testRoom.ID().String(),
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if _, err := f.roomRepo.FindByID(context.Background(), testRoom.ID()); err == nil {
+			t.Errorf("Expected room %s to be deleted", testRoom.ID().String())
+		}
+		participantRoomID, _ := participant.NewRoomIDFromString(testRoom.ID().String())
+		if participants, _ := f.participantRepo.FindByRoomID(context.Background(), participantRoomID); len(participants) != 0 {
+			t.Errorf("Expected the participants to be deleted with the room, got: %d", len(participants))
+		}
+		if _, ok := publishedEvent.(*event.RoomDeletedEvent); !ok {
+			t.Errorf("Expected RoomDeletedEvent, got: %T", publishedEvent)
+		}
+		logs := findAuditLogs(t, f.auditRepo, testRoom)
+		if len(logs) != 1 || logs[0].Action() != audit.ActionDeleteRoom {
+			t.Error("Expected delete_room audit log to be saved")
+		}
+	})
+
+	t.Run("削除に失敗した場合イベントが発行されないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := createTestRoom(room.StatusWaiting)
+		mustSave(t, f.roomRepo.Save, testRoom)
+		roomRepo := f.roomRepo
+		f.roomRepo = &mockRoomRepository{
+			findByIDFunc: roomRepo.FindByID,
+			deleteFunc: func(ctx context.Context, id room.RoomID) error {
+				return errors.New("delete error")
+			},
+		}
+		published := false
+		f.eventPublisher.publishFunc = func(evt event.Event) {
+			published = true
+		}
+
+		input := adminUseCase.DeleteRoomInput{
+			RoomID: testRoom.ID().String(),
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if err == nil {
+			t.Fatal("Expected error when delete fails")
+		}
+		if published {
+			t.Error("Expected no event to be published")
+		}
+		if _, err := roomRepo.FindByID(context.Background(), testRoom.ID()); err != nil {
+			t.Errorf("Expected the room to be kept, got: %v", err)
+		}
+	})
+
+	t.Run("ルームが存在しない場合エラーになること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		input := adminUseCase.DeleteRoomInput{
+			RoomID: "550e8400-e29b-41d4-a716-446655440099",
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if !errors.Is(err, adminUseCase.ErrRoomNotFound) {
+			t.Errorf("Expected ErrRoomNotFound, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,9 @@
+package admin
+
+import "errors"
+
+var (
+	ErrRoomNotFound          = errors.New("room not found")
+	ErrCannotForceWaiting    = errors.New("room cannot be moved back to waiting")
+	ErrAlreadyInTargetStatus = errors.New("room is already in the target status")
+)
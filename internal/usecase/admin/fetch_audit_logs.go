@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+)
+
+// FetchAuditLogsUseCase fetches the audit trail of a room
+type FetchAuditLogsUseCase struct {
+	roomRepo  room.Repository
+	auditRepo audit.Repository
+}
+
+// NewFetchAuditLogsUseCase creates a new FetchAuditLogsUseCase
+func NewFetchAuditLogsUseCase(
+	roomRepo room.Repository,
+	auditRepo audit.Repository,
+) *FetchAuditLogsUseCase {
+	return &FetchAuditLogsUseCase{
+		roomRepo:  roomRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// FetchAuditLogsInput represents input for fetching audit logs
+type FetchAuditLogsInput struct {
+	RoomID string
+}
+
+// AuditLogInfo represents a single audit log entry
+type AuditLogInfo struct {
+	ActorType    string
+	ActorID      string
+	Action       string
+	BeforeStatus string
+	AfterStatus  string
+	OccurredAt   time.Time
+}
+
+// FetchAuditLogsOutput represents output for fetching audit logs
+type FetchAuditLogsOutput struct {
+	AuditLogs []AuditLogInfo
+}
+
+// Execute fetches the audit logs of a room
+func (uc *FetchAuditLogsUseCase) Execute(ctx context.Context, input FetchAuditLogsInput) (*FetchAuditLogsOutput, error) {
+	roomID, err := room.NewRoomIDFromString(input.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.roomRepo.FindByID(ctx, roomID); err != nil {
+		return nil, ErrRoomNotFound
+	}
+
+	auditRoomID, _ := audit.NewRoomIDFromString(input.RoomID)
+	logs, err := uc.auditRepo.FindByRoomID(ctx, auditRoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLogInfoList := []AuditLogInfo{}
+	for _, l := range logs {
+		auditLogInfoList = append(auditLogInfoList, AuditLogInfo{
+			ActorType:    l.Actor().Type().String(),
+			ActorID:      l.Actor().ID(),
+			Action:       l.Action().String(),
+			BeforeStatus: l.BeforeStatus(),
+			AfterStatus:  l.AfterStatus(),
+			OccurredAt:   l.OccurredAt(),
+		})
+	}
+
+	return &FetchAuditLogsOutput{
+		AuditLogs: auditLogInfoList,
+	}, nil
+}
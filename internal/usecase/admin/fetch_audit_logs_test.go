@@ -0,0 +1,92 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+	adminUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/admin"
+)
+
+func TestFetchAuditLogsUseCaseExecute(t *testing.T) {
+	const hostUserID = "550e8400-e29b-41d4-a716-446655440001"
+
+	type fixture struct {
+		roomRepo  room.Repository
+		auditRepo audit.Repository
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		store := persistence.NewMemoryStore()
+		return &fixture{
+			roomRepo:  persistence.NewInMemoryRoomRepository(store),
+			auditRepo: persistence.NewInMemoryAuditRepository(store),
+		}
+	}
+
+	newUseCase := func(f *fixture) *adminUseCase.FetchAuditLogsUseCase {
+		return adminUseCase.NewFetchAuditLogsUseCase(
+			f.roomRepo,
+			f.auditRepo,
+		)
+	}
+
+	t.Run("ルームの監査ログを取得できること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := createTestRoom(room.StatusSettingTopic)
+		mustSave(t, f.roomRepo.Save, testRoom)
+		auditRoomID, _ := audit.NewRoomIDFromString(testRoom.ID().String())
+		mustSave(t, f.auditRepo.Save, audit.NewAuditLog(
+			audit.NewAuditLogID(),
+			auditRoomID,
+			audit.NewUserActor(hostUserID),
+			audit.ActionStartGame,
+			"waiting",
+			"setting_topic",
+			testNow,
+		))
+
+		input := adminUseCase.FetchAuditLogsInput{
+			RoomID: testRoom.ID().String(),
+		}
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(output.AuditLogs) != 1 {
+			t.Fatalf("Expected 1 audit log, got: %d", len(output.AuditLogs))
+		}
+		if output.AuditLogs[0].Action != "start_game" {
+			t.Errorf("Expected action 'start_game', got: %s", output.AuditLogs[0].Action)
+		}
+		if output.AuditLogs[0].ActorType != "user" {
+			t.Errorf("Expected actor type 'user', got: %s", output.AuditLogs[0].ActorType)
+		}
+	})
+
+	t.Run("存在しないルームはErrRoomNotFoundになること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		input := adminUseCase.FetchAuditLogsInput{
+			RoomID: room.NewRoomID().String(),
+		}
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if !errors.Is(err, adminUseCase.ErrRoomNotFound) {
+			t.Errorf("Expected ErrRoomNotFound, got: %v", err)
+		}
+	})
+}
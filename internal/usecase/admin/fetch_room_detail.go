@@ -0,0 +1,105 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/theme"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
+)
+
+// FetchRoomDetailUseCase fetches the full internal state of a room
+type FetchRoomDetailUseCase struct {
+	roomRepo        room.Repository
+	participantRepo participant.Repository
+	userRepo        user.Repository
+	themeRepo       theme.Repository
+}
+
+// NewFetchRoomDetailUseCase creates a new FetchRoomDetailUseCase
+func NewFetchRoomDetailUseCase(
+	roomRepo room.Repository,
+	participantRepo participant.Repository,
+	userRepo user.Repository,
+	themeRepo theme.Repository,
+) *FetchRoomDetailUseCase {
+	return &FetchRoomDetailUseCase{
+		roomRepo:        roomRepo,
+		participantRepo: participantRepo,
+		userRepo:        userRepo,
+		themeRepo:       themeRepo,
+	}
+}
+
+// FetchRoomDetailInput represents input for fetching room detail
+type FetchRoomDetailInput struct {
+	RoomID string
+}
+
+// ParticipantDetail represents a participant as seen by operators
+type ParticipantDetail struct {
+	UserID   string
+	UserName string
+	Role     string
+	IsLeader bool
+	JoinedAt time.Time
+}
+
+// FetchRoomDetailOutput represents output for fetching room detail
+type FetchRoomDetailOutput struct {
+	Room         *room.Room
+	ThemeTitle   string
+	Participants []ParticipantDetail
+}
+
+// Execute fetches a room including its game data and participants
+func (uc *FetchRoomDetailUseCase) Execute(ctx context.Context, input FetchRoomDetailInput) (*FetchRoomDetailOutput, error) {
+	roomID, err := room.NewRoomIDFromString(input.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	foundRoom, err := uc.roomRepo.FindByID(ctx, roomID)
+	if err != nil {
+		return nil, ErrRoomNotFound
+	}
+
+	themeTitle := ""
+	if themeID, err := theme.NewThemeIDFromString(foundRoom.ThemeID().String()); err == nil {
+		if t, err := uc.themeRepo.FindByID(ctx, themeID); err == nil && t != nil {
+			themeTitle = t.Title().String()
+		}
+	}
+
+	participantRoomID, _ := participant.NewRoomIDFromString(input.RoomID)
+	participants, err := uc.participantRepo.FindByRoomID(ctx, participantRoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := []ParticipantDetail{}
+	for _, p := range participants {
+		userName := "Unknown"
+		if userID, err := user.NewUserIDFromString(p.UserID().String()); err == nil {
+			if u, err := uc.userRepo.FindByID(ctx, userID); err == nil {
+				userName = u.Name().String()
+			}
+		}
+
+		details = append(details, ParticipantDetail{
+			UserID:   p.UserID().String(),
+			UserName: userName,
+			Role:     p.Role().String(),
+			IsLeader: p.IsLeader(),
+			JoinedAt: p.JoinedAt(),
+		})
+	}
+
+	return &FetchRoomDetailOutput{
+		Room:         foundRoom,
+		ThemeTitle:   themeTitle,
+		Participants: details,
+	}, nil
+}
@@ -0,0 +1,161 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+)
+
+// Mock Room Repository
+type mockRoomRepository struct {
+	saveFunc       func(context.Context, *room.Room) error
+	findByIDFunc   func(context.Context, room.RoomID) (*room.Room, error)
+	findByCodeFunc func(context.Context, room.RoomCode) (*room.Room, error)
+	searchFunc     func(context.Context, room.SearchCriteria) ([]room.SearchResult, error)
+	deleteFunc     func(context.Context, room.RoomID) error
+}
+
+func (m *mockRoomRepository) Save(ctx context.Context, r *room.Room) error {
+	if m.saveFunc != nil {
+		return m.saveFunc(ctx, r)
+	}
+	return nil
+}
+
+func (m *mockRoomRepository) FindByID(ctx context.Context, id room.RoomID) (*room.Room, error) {
+	if m.findByIDFunc != nil {
+		return m.findByIDFunc(ctx, id)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockRoomRepository) FindByCode(ctx context.Context, code room.RoomCode) (*room.Room, error) {
+	if m.findByCodeFunc != nil {
+		return m.findByCodeFunc(ctx, code)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockRoomRepository) Search(ctx context.Context, criteria room.SearchCriteria) ([]room.SearchResult, error) {
+	if m.searchFunc != nil {
+		return m.searchFunc(ctx, criteria)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockRoomRepository) Delete(ctx context.Context, id room.RoomID) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(ctx, id)
+	}
+	return errors.New("not implemented")
+}
+
+// Mock Participant Repository
+type mockParticipantRepository struct {
+	saveFunc              func(context.Context, *participant.Participant) error
+	findByIDFunc          func(context.Context, participant.ParticipantID) (*participant.Participant, error)
+	findByRoomIDFunc      func(context.Context, participant.RoomID) ([]*participant.Participant, error)
+	findByRoomAndUserFunc func(context.Context, participant.RoomID, participant.UserID) (*participant.Participant, error)
+	deleteFunc            func(context.Context, participant.RoomID, participant.UserID) error
+}
+
+func (m *mockParticipantRepository) Save(ctx context.Context, p *participant.Participant) error {
+	if m.saveFunc != nil {
+		return m.saveFunc(ctx, p)
+	}
+	return nil
+}
+
+func (m *mockParticipantRepository) FindByID(ctx context.Context, id participant.ParticipantID) (*participant.Participant, error) {
+	if m.findByIDFunc != nil {
+		return m.findByIDFunc(ctx, id)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockParticipantRepository) FindByRoomID(ctx context.Context, roomID participant.RoomID) ([]*participant.Participant, error) {
+	if m.findByRoomIDFunc != nil {
+		return m.findByRoomIDFunc(ctx, roomID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockParticipantRepository) FindByRoomAndUser(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
+	if m.findByRoomAndUserFunc != nil {
+		return m.findByRoomAndUserFunc(ctx, roomID, userID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockParticipantRepository) Delete(ctx context.Context, roomID participant.RoomID, userID participant.UserID) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(ctx, roomID, userID)
+	}
+	return errors.New("not implemented")
+}
+
+// Mock Event Publisher
+type mockEventPublisher struct {
+	publishFunc   func(event.Event)
+	subscribeFunc func(string, event.EventHandler)
+}
+
+func (m *mockEventPublisher) Publish(evt event.Event) {
+	if m.publishFunc != nil {
+		m.publishFunc(evt)
+	}
+}
+
+func (m *mockEventPublisher) Subscribe(eventType string, handler event.EventHandler) {
+	if m.subscribeFunc != nil {
+		m.subscribeFunc(eventType, handler)
+	}
+}
+
+// createTestRoom creates a room in the given status
+func createTestRoom(status room.RoomStatus) *room.Room {
+	roomID := room.NewRoomID()
+	roomCode := room.NewRoomCode()
+	themeID, _ := room.NewThemeIDFromString("550e8400-e29b-41d4-a716-446655440000")
+	hostUserID, _ := room.NewHostUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
+	rm := room.NewRoom(roomID, roomCode, themeID, hostUserID, testNow)
+	rm.SetStatus(status)
+	return rm
+}
+
+// testNow is the fixed time used by the use cases and entities under test
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// createTestParticipant creates a participant of the room
+func createTestParticipant(rm *room.Room, userID string, role participant.ParticipantRole) *participant.Participant {
+	participantRoomID, _ := participant.NewRoomIDFromString(rm.ID().String())
+	participantUserID, _ := participant.NewUserIDFromString(userID)
+	return participant.NewParticipant(participant.NewParticipantID(), participantRoomID, participantUserID, role, testNow)
+}
+
+// findAuditLogs reads the audit logs recorded for the room
+func findAuditLogs(t *testing.T, auditRepo audit.Repository, rm *room.Room) []*audit.AuditLog {
+	t.Helper()
+
+	auditRoomID, _ := audit.NewRoomIDFromString(rm.ID().String())
+	logs, err := auditRepo.FindByRoomID(context.Background(), auditRoomID)
+	if err != nil {
+		t.Fatalf("Failed to find audit logs: %v", err)
+	}
+	return logs
+}
+
+// mustSave stores an entity with an in-memory repository while arranging a test
+func mustSave[T any](t *testing.T, save func(context.Context, T) error, entity T) {
+	t.Helper()
+
+	if err := save(context.Background(), entity); err != nil {
+		t.Fatalf("Failed to save %T: %v", entity, err)
+	}
+}
@@ -0,0 +1,108 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/usecase/auditlog"
+)
+
+// ForceTransitionInput represents the input for forcing a room into a status
+type ForceTransitionInput struct {
+	RoomID string
+	Status string
+}
+
+// ForceTransitionUseCase moves a room into any status, bypassing the normal game flow
+type ForceTransitionUseCase struct {
+	roomRepo       room.Repository
+	eventPublisher event.Publisher
+	auditRepo      audit.Repository
+	clock          clock.Clock
+}
+
+// NewForceTransitionUseCase creates a new ForceTransitionUseCase
+func NewForceTransitionUseCase(
+	roomRepo room.Repository,
+	eventPublisher event.Publisher,
+	auditRepo audit.Repository,
+	clk clock.Clock,
+) *ForceTransitionUseCase {
+	return &ForceTransitionUseCase{
+		roomRepo:       roomRepo,
+		eventPublisher: eventPublisher,
+		auditRepo:      auditRepo,
+		clock:          clk,
+	}
+}
+
+// Execute forces the room into the target status and publishes the event
+// that the normal flow would have published for it. When another writer saves
+// the room first, it starts over from a fresh read.
+func (uc *ForceTransitionUseCase) Execute(ctx context.Context, input ForceTransitionInput) error {
+	return room.RetryOnConflict(func() error {
+		return uc.execute(ctx, input)
+	})
+}
+
+// execute forces the transition of the room as currently stored
+func (uc *ForceTransitionUseCase) execute(ctx context.Context, input ForceTransitionInput) error {
+	roomID, err := room.NewRoomIDFromString(input.RoomID)
+	if err != nil {
+		return err
+	}
+
+	target, err := room.NewRoomStatusFromString(input.Status)
+	if err != nil {
+		return err
+	}
+	if target == room.StatusWaiting {
+		return ErrCannotForceWaiting
+	}
+
+	foundRoom, err := uc.roomRepo.FindByID(ctx, roomID)
+	if err != nil {
+		return ErrRoomNotFound
+	}
+
+	beforeStatus := foundRoom.Status()
+	if beforeStatus == target {
+		return ErrAlreadyInTargetStatus
+	}
+
+	foundRoom.ForceStatus(target, uc.clock.Now())
+
+	if err := uc.roomRepo.Save(ctx, foundRoom); err != nil {
+		return err
+	}
+
+	action := audit.ActionForceTransition
+	if target == room.StatusFinished {
+		action = audit.ActionForceFinish
+	}
+	auditlog.Record(ctx, uc.auditRepo, input.RoomID, audit.NewAdminActor(), action, beforeStatus.String(), target.String(), uc.clock.Now())
+
+	uc.eventPublisher.Publish(statusEvent(input.RoomID, target, uc.clock.Now()))
+
+	return nil
+}
+
+// statusEvent returns the domain event published when a room enters the given status
+func statusEvent(roomID string, status room.RoomStatus, occurredAt time.Time) event.Event {
+	switch status {
+	case room.StatusSettingTopic:
+		return event.NewGameStartedEvent(roomID, occurredAt)
+	case room.StatusDiscussing:
+		return event.NewDiscussionStartedEvent(roomID, occurredAt)
+	case room.StatusAnswering:
+		return event.NewDiscussionSkippedEvent(roomID, occurredAt)
+	case room.StatusChecking:
+		return event.NewAnswerSubmittedEvent(roomID, occurredAt)
+	default:
+		return event.NewGameFinishedEvent(roomID, occurredAt)
+	}
+}
@@ -0,0 +1,228 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+	adminUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/admin"
+)
+
+func TestForceTransitionUseCaseExecute(t *testing.T) {
+	type fixture struct {
+		roomRepo       room.Repository
+		eventPublisher *mockEventPublisher
+		auditRepo      audit.Repository
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		store := persistence.NewMemoryStore()
+		return &fixture{
+			roomRepo:       persistence.NewInMemoryRoomRepository(store),
+			eventPublisher: &mockEventPublisher{},
+			auditRepo:      persistence.NewInMemoryAuditRepository(store),
+		}
+	}
+
+	newUseCase := func(f *fixture) *adminUseCase.ForceTransitionUseCase {
+		return adminUseCase.NewForceTransitionUseCase(
+			f.roomRepo,
+			f.eventPublisher,
+			f.auditRepo,
+			infrastructureClock.NewFakeClock(testNow),
+		)
+	}
+
+	t.Run("遷移ルールに関係なく指定した状態に変更されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := createTestRoom(room.StatusSettingTopic)
+		mustSave(t, f.roomRepo.Save, testRoom)
+		var publishedEvent event.Event
+		f.eventPublisher.publishFunc = func(evt event.Event) {
+			publishedEvent = evt
+		}
+
+		input := adminUseCase.ForceTransitionInput{
+			RoomID: testRoom.ID().String(),
+			Status: "checking",
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if stored, _ := f.roomRepo.FindByID(context.Background(), testRoom.ID()); stored.Status() != room.StatusChecking {
+			t.Fatal("Expected room to be saved with checking status")
+		}
+		if _, ok := publishedEvent.(*event.AnswerSubmittedEvent); !ok {
+			t.Errorf("Expected AnswerSubmittedEvent, got: %T", publishedEvent)
+		}
+		logs := findAuditLogs(t, f.auditRepo, testRoom)
+		if len(logs) != 1 {
+			t.Fatal("Expected audit log to be saved")
+		}
+		savedLog := logs[0]
+		if savedLog.Action() != audit.ActionForceTransition {
+			t.Errorf("Expected action %s, got: %s", audit.ActionForceTransition, savedLog.Action())
+		}
+		if savedLog.Actor().Type() != audit.ActorAdmin {
+			t.Errorf("Expected admin actor, got: %s", savedLog.Actor().Type())
+		}
+	})
+
+	t.Run("議論中への遷移ではDiscussionStartedEventが発行されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := createTestRoom(room.StatusSettingTopic)
+		mustSave(t, f.roomRepo.Save, testRoom)
+		var publishedEvent event.Event
+		f.eventPublisher.publishFunc = func(evt event.Event) {
+			publishedEvent = evt
+		}
+
+		input := adminUseCase.ForceTransitionInput{
+			RoomID: testRoom.ID().String(),
+			Status: "discussing",
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if _, ok := publishedEvent.(*event.DiscussionStartedEvent); !ok {
+			t.Errorf("Expected DiscussionStartedEvent, got: %T", publishedEvent)
+		}
+	})
+
+	t.Run("終了への遷移は強制終了として記録されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := createTestRoom(room.StatusDiscussing)
+		mustSave(t, f.roomRepo.Save, testRoom)
+		var publishedEvent event.Event
+		f.eventPublisher.publishFunc = func(evt event.Event) {
+			publishedEvent = evt
+		}
+
+		input := adminUseCase.ForceTransitionInput{
+			RoomID: testRoom.ID().String(),
+			Status: "finished",
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if _, ok := publishedEvent.(*event.GameFinishedEvent); !ok {
+			t.Errorf("Expected GameFinishedEvent, got: %T", publishedEvent)
+		}
+		logs := findAuditLogs(t, f.auditRepo, testRoom)
+		if len(logs) != 1 || logs[0].Action() != audit.ActionForceFinish {
+			t.Fatal("Expected force_finish audit log to be saved")
+		}
+		if logs[0].BeforeStatus() != "discussing" || logs[0].AfterStatus() != "finished" {
+			t.Errorf("Expected discussing -> finished, got: %s -> %s", logs[0].BeforeStatus(), logs[0].AfterStatus())
+		}
+	})
+
+	t.Run("待機中には戻せないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := createTestRoom(room.StatusFinished)
+		mustSave(t, f.roomRepo.Save, testRoom)
+
+		input := adminUseCase.ForceTransitionInput{
+			RoomID: testRoom.ID().String(),
+			Status: "waiting",
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if !errors.Is(err, adminUseCase.ErrCannotForceWaiting) {
+			t.Errorf("Expected ErrCannotForceWaiting, got: %v", err)
+		}
+	})
+
+	t.Run("同じ状態への遷移はエラーになりイベントが発行されないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := createTestRoom(room.StatusAnswering)
+		mustSave(t, f.roomRepo.Save, testRoom)
+		published := false
+		f.eventPublisher.publishFunc = func(evt event.Event) {
+			published = true
+		}
+
+		input := adminUseCase.ForceTransitionInput{
+			RoomID: testRoom.ID().String(),
+			Status: "answering",
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if !errors.Is(err, adminUseCase.ErrAlreadyInTargetStatus) {
+			t.Errorf("Expected ErrAlreadyInTargetStatus, got: %v", err)
+		}
+		if published {
+			t.Error("Expected no event to be published")
+		}
+	})
+
+	t.Run("不正な状態を指定した場合エラーになること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := createTestRoom(room.StatusAnswering)
+
+		input := adminUseCase.ForceTransitionInput{
+			RoomID: testRoom.ID().String(),
+			Status: "unknown",
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if !errors.Is(err, room.ErrInvalidStatus) {
+			t.Errorf("Expected ErrInvalidStatus, got: %v", err)
+		}
+	})
+
+	t.Run("ルームが存在しない場合エラーになること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		input := adminUseCase.ForceTransitionInput{
+			RoomID: "550e8400-e29b-41d4-a716-446655440099",
+			Status: "finished",
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if !errors.Is(err, adminUseCase.ErrRoomNotFound) {
+			t.Errorf("Expected ErrRoomNotFound, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+)
+
+const (
+	defaultListRoomsLimit = 100
+	maxListRoomsLimit     = 500
+)
+
+// ListRoomsUseCase lists rooms for operators
+type ListRoomsUseCase struct {
+	roomRepo room.Repository
+	clock    clock.Clock
+}
+
+// NewListRoomsUseCase creates a new ListRoomsUseCase
+func NewListRoomsUseCase(
+	roomRepo room.Repository,
+	clk clock.Clock,
+) *ListRoomsUseCase {
+	return &ListRoomsUseCase{
+		roomRepo: roomRepo,
+		clock:    clk,
+	}
+}
+
+// ListRoomsInput represents input for listing rooms.
+// Zero values are not applied as filters.
+type ListRoomsInput struct {
+	Status     string
+	MinAge     time.Duration
+	MaxAge     time.Duration
+	MinPlayers *int
+	MaxPlayers *int
+	Limit      int
+}
+
+// RoomSummary represents a room in the admin list
+type RoomSummary struct {
+	RoomID     string
+	RoomCode   string
+	Status     string
+	HostUserID string
+	// PlayerCount is the number of participants other than the host
+	PlayerCount int
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+}
+
+// ListRoomsOutput represents output for listing rooms
+type ListRoomsOutput struct {
+	Rooms []RoomSummary
+}
+
+// Execute lists rooms matching the filters, newest first
+func (uc *ListRoomsUseCase) Execute(ctx context.Context, input ListRoomsInput) (*ListRoomsOutput, error) {
+	criteria := room.SearchCriteria{
+		MinPlayers: input.MinPlayers,
+		MaxPlayers: input.MaxPlayers,
+		Limit:      input.Limit,
+	}
+
+	if input.Status != "" {
+		status, err := room.NewRoomStatusFromString(input.Status)
+		if err != nil {
+			return nil, err
+		}
+		criteria.Status = &status
+	}
+
+	if input.MinAge < 0 || input.MaxAge < 0 {
+		return nil, errors.New("age must not be negative")
+	}
+	now := uc.clock.Now()
+	if input.MinAge > 0 {
+		createdBefore := now.Add(-input.MinAge)
+		criteria.CreatedBefore = &createdBefore
+	}
+	if input.MaxAge > 0 {
+		createdAfter := now.Add(-input.MaxAge)
+		criteria.CreatedAfter = &createdAfter
+	}
+
+	if criteria.Limit <= 0 {
+		criteria.Limit = defaultListRoomsLimit
+	}
+	if criteria.Limit > maxListRoomsLimit {
+		criteria.Limit = maxListRoomsLimit
+	}
+
+	results, err := uc.roomRepo.Search(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := []RoomSummary{}
+	for _, result := range results {
+		rm := result.Room
+		summaries = append(summaries, RoomSummary{
+			RoomID:      rm.ID().String(),
+			RoomCode:    rm.Code().String(),
+			Status:      rm.Status().String(),
+			HostUserID:  rm.HostUserID().String(),
+			PlayerCount: result.PlayerCount,
+			CreatedAt:   rm.CreatedAt(),
+			StartedAt:   rm.StartedAt(),
+		})
+	}
+
+	return &ListRoomsOutput{
+		Rooms: summaries,
+	}, nil
+}
@@ -0,0 +1,124 @@
+package admin_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+	adminUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/admin"
+)
+
+func TestListRoomsUseCaseExecute(t *testing.T) {
+	type fixture struct {
+		roomRepo        room.Repository
+		participantRepo participant.Repository
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		store := persistence.NewMemoryStore()
+		return &fixture{
+			roomRepo:        persistence.NewInMemoryRoomRepository(store),
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+		}
+	}
+
+	newUseCase := func(f *fixture) *adminUseCase.ListRoomsUseCase {
+		return adminUseCase.NewListRoomsUseCase(
+			f.roomRepo,
+			infrastructureClock.NewFakeClock(testNow),
+		)
+	}
+
+	// saveRoom stores a room created at createdAt with the given number of players besides the host
+	saveRoom := func(t *testing.T, f *fixture, status room.RoomStatus, createdAt time.Time, players int) *room.Room {
+		t.Helper()
+
+		themeID, _ := room.NewThemeIDFromString("550e8400-e29b-41d4-a716-446655440000")
+		hostUserID, _ := room.NewHostUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
+		rm := room.NewRoom(room.NewRoomID(), room.NewRoomCode(), themeID, hostUserID, createdAt)
+		rm.SetStatus(status)
+		mustSave(t, f.roomRepo.Save, rm)
+		mustSave(t, f.participantRepo.Save, createTestParticipant(rm, hostUserID.String(), participant.RoleHost))
+		for i := 0; i < players; i++ {
+			mustSave(t, f.participantRepo.Save, createTestParticipant(rm, fmt.Sprintf("550e8400-e29b-41d4-a716-44665544001%d", i), participant.RolePlayer))
+		}
+		return rm
+	}
+
+	t.Run("フィルタに合うルームだけが参加者数とともに返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		old := testNow.Add(-20 * time.Minute)
+		testRoom := saveRoom(t, f, room.StatusDiscussing, old, 3)
+		saveRoom(t, f, room.StatusDiscussing, testNow, 3)
+		saveRoom(t, f, room.StatusWaiting, old, 3)
+		saveRoom(t, f, room.StatusDiscussing, old, 1)
+		minPlayers := 2
+
+		input := adminUseCase.ListRoomsInput{
+			Status:     "discussing",
+			MinAge:     10 * time.Minute,
+			MinPlayers: &minPlayers,
+		}
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(output.Rooms) != 1 {
+			t.Fatalf("Expected 1 room, got: %d", len(output.Rooms))
+		}
+		if output.Rooms[0].RoomID != testRoom.ID().String() {
+			t.Errorf("Expected room %s, got: %s", testRoom.ID().String(), output.Rooms[0].RoomID)
+		}
+		if output.Rooms[0].PlayerCount != 3 {
+			t.Errorf("Expected player count 3, got: %d", output.Rooms[0].PlayerCount)
+		}
+	})
+
+	t.Run("件数を指定しない場合は100件で打ち切られること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		for i := 0; i < 101; i++ {
+			saveRoom(t, f, room.StatusWaiting, testNow.Add(-time.Duration(i)*time.Second), 0)
+		}
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background(), adminUseCase.ListRoomsInput{})
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(output.Rooms) != 100 {
+			t.Errorf("Expected default limit 100, got: %d", len(output.Rooms))
+		}
+	})
+
+	t.Run("不正な状態を指定した場合エラーになること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		input := adminUseCase.ListRoomsInput{
+			Status: "unknown",
+		}
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if err == nil {
+			t.Fatal("Expected error for invalid status")
+		}
+	})
+}
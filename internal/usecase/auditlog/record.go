@@ -0,0 +1,42 @@
+package auditlog
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+)
+
+// Record persists an audit log for a privileged room operation.
+// Failures are logged rather than returned because the operation itself has already been applied.
+func Record(
+	ctx context.Context,
+	auditRepo audit.Repository,
+	roomID string,
+	actor audit.Actor,
+	action audit.Action,
+	beforeStatus string,
+	afterStatus string,
+	at time.Time,
+) {
+	auditRoomID, err := audit.NewRoomIDFromString(roomID)
+	if err != nil {
+		log.Printf("Error creating audit log for room %s: %v", roomID, err)
+		return
+	}
+
+	auditLog := audit.NewAuditLog(
+		audit.NewAuditLogID(),
+		auditRoomID,
+		actor,
+		action,
+		beforeStatus,
+		afterStatus,
+		at,
+	)
+
+	if err := auditRepo.Save(ctx, auditLog); err != nil {
+		log.Printf("Error saving audit log for room %s: %v", roomID, err)
+	}
+}
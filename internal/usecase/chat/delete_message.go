@@ -0,0 +1,76 @@
+package chat
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/chat"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+)
+
+// DeleteMessageInput represents the input for deleting a chat message
+type DeleteMessageInput struct {
+	RoomID    string
+	UserID    string
+	MessageID string
+}
+
+// DeleteMessageUseCase handles the logic for a host removing a chat message
+type DeleteMessageUseCase struct {
+	chatRepo        chat.Repository
+	participantRepo participant.Repository
+	clock           clock.Clock
+}
+
+// NewDeleteMessageUseCase creates a new DeleteMessageUseCase
+func NewDeleteMessageUseCase(
+	chatRepo chat.Repository,
+	participantRepo participant.Repository,
+	clk clock.Clock,
+) *DeleteMessageUseCase {
+	return &DeleteMessageUseCase{
+		chatRepo:        chatRepo,
+		participantRepo: participantRepo,
+		clock:           clk,
+	}
+}
+
+// Execute marks a chat message as deleted
+func (uc *DeleteMessageUseCase) Execute(ctx context.Context, input DeleteMessageInput) error {
+	messageID, err := chat.NewMessageIDFromString(input.MessageID)
+	if err != nil {
+		return err
+	}
+
+	// Verify user is host
+	participantRoomID, err := participant.NewRoomIDFromString(input.RoomID)
+	if err != nil {
+		return err
+	}
+	participantUserID, err := participant.NewUserIDFromString(input.UserID)
+	if err != nil {
+		return err
+	}
+	foundParticipant, err := uc.participantRepo.FindByRoomAndUser(ctx, participantRoomID, participantUserID)
+	if err != nil {
+		return errors.New("participant not found")
+	}
+	if foundParticipant.Role() != participant.RoleHost {
+		return chat.ErrOnlyHostCanDelete
+	}
+
+	message, err := uc.chatRepo.FindByID(ctx, messageID)
+	if err != nil {
+		return chat.ErrMessageNotFound
+	}
+	if message.RoomID().String() != input.RoomID {
+		return chat.ErrNotInRoom
+	}
+
+	if err := message.Delete(uc.clock.Now().UTC()); err != nil {
+		return err
+	}
+
+	return uc.chatRepo.Save(ctx, message)
+}
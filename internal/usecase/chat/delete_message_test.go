@@ -0,0 +1,138 @@
+package chat_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/chat"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+	chatUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/chat"
+)
+
+func TestDeleteMessageUseCaseExecute(t *testing.T) {
+	const (
+		testRoomID  = "550e8400-e29b-41d4-a716-446655440000"
+		testHostID  = "550e8400-e29b-41d4-a716-446655440001"
+		otherRoomID = "550e8400-e29b-41d4-a716-446655440009"
+	)
+
+	type fixture struct {
+		chatRepo        chat.Repository
+		participantRepo participant.Repository
+	}
+
+	newFixture := func(t *testing.T, role participant.ParticipantRole) *fixture {
+		t.Helper()
+
+		store := persistence.NewMemoryStore()
+		f := &fixture{
+			chatRepo:        persistence.NewInMemoryChatRepository(store),
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+		}
+		participantRoomID, _ := participant.NewRoomIDFromString(testRoomID)
+		participantUserID, _ := participant.NewUserIDFromString(testHostID)
+		mustSave(t, f.participantRepo.Save, participant.NewParticipant(participant.NewParticipantID(), participantRoomID, participantUserID, role, testNow))
+		return f
+	}
+
+	newUseCase := func(f *fixture) *chatUseCase.DeleteMessageUseCase {
+		return chatUseCase.NewDeleteMessageUseCase(f.chatRepo, f.participantRepo, infrastructureClock.NewFakeClock(testNow))
+	}
+
+	createTestMessage := func(roomID string) *chat.Message {
+		chatRoomID, _ := chat.NewRoomIDFromString(roomID)
+		userID, _ := chat.NewUserIDFromString("550e8400-e29b-41d4-a716-446655440002")
+		body, _ := chat.NewBody("hello")
+		return chat.NewMessage(chat.NewMessageID(), chatRoomID, userID, body, testNow)
+	}
+
+	t.Run("ホストがメッセージを削除できること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, participant.RoleHost)
+		message := createTestMessage(testRoomID)
+		mustSave(t, f.chatRepo.Save, message)
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), chatUseCase.DeleteMessageInput{
+			RoomID:    testRoomID,
+			UserID:    testHostID,
+			MessageID: message.ID().String(),
+		})
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		saved, _ := f.chatRepo.FindByID(context.Background(), message.ID())
+		if !saved.IsDeleted() {
+			t.Fatal("Expected message to be saved as deleted")
+		}
+		if !saved.DeletedAt().Equal(testNow) {
+			t.Errorf("Expected the message to be deleted at %v, got %v", testNow, *saved.DeletedAt())
+		}
+	})
+
+	t.Run("ホスト以外はErrOnlyHostCanDeleteが返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, participant.RolePlayer)
+		message := createTestMessage(testRoomID)
+		mustSave(t, f.chatRepo.Save, message)
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), chatUseCase.DeleteMessageInput{
+			RoomID:    testRoomID,
+			UserID:    testHostID,
+			MessageID: message.ID().String(),
+		})
+
+		// assert
+		if !errors.Is(err, chat.ErrOnlyHostCanDelete) {
+			t.Errorf("Expected ErrOnlyHostCanDelete, got: %v", err)
+		}
+		if saved, _ := f.chatRepo.FindByID(context.Background(), message.ID()); saved.IsDeleted() {
+			t.Error("Expected message not to be deleted")
+		}
+	})
+
+	t.Run("別のルームのメッセージはErrNotInRoomが返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, participant.RoleHost)
+		message := createTestMessage(otherRoomID)
+		mustSave(t, f.chatRepo.Save, message)
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), chatUseCase.DeleteMessageInput{
+			RoomID:    testRoomID,
+			UserID:    testHostID,
+			MessageID: message.ID().String(),
+		})
+
+		// assert
+		if !errors.Is(err, chat.ErrNotInRoom) {
+			t.Errorf("Expected ErrNotInRoom, got: %v", err)
+		}
+	})
+
+	t.Run("削除済みのメッセージはErrAlreadyDeletedが返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, participant.RoleHost)
+		message := createTestMessage(testRoomID)
+		_ = message.Delete(testNow)
+		mustSave(t, f.chatRepo.Save, message)
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), chatUseCase.DeleteMessageInput{
+			RoomID:    testRoomID,
+			UserID:    testHostID,
+			MessageID: message.ID().String(),
+		})
+
+		// assert
+		if !errors.Is(err, chat.ErrAlreadyDeleted) {
+			t.Errorf("Expected ErrAlreadyDeleted, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/chat"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
+)
+
+// FetchHistoryInput represents the input for fetching recent chat messages
+type FetchHistoryInput struct {
+	RoomID string
+}
+
+// FetchHistoryOutput represents the output for fetching recent chat messages
+type FetchHistoryOutput struct {
+	Messages []MessageInfo
+}
+
+// FetchHistoryUseCase fetches the most recent chat messages in a room
+type FetchHistoryUseCase struct {
+	chatRepo chat.Repository
+	userRepo user.Repository
+	limit    int
+}
+
+// NewFetchHistoryUseCase creates a new FetchHistoryUseCase
+func NewFetchHistoryUseCase(
+	chatRepo chat.Repository,
+	userRepo user.Repository,
+	limit int,
+) *FetchHistoryUseCase {
+	return &FetchHistoryUseCase{
+		chatRepo: chatRepo,
+		userRepo: userRepo,
+		limit:    limit,
+	}
+}
+
+// Execute returns up to the configured number of recent messages, oldest first
+func (uc *FetchHistoryUseCase) Execute(ctx context.Context, input FetchHistoryInput) (*FetchHistoryOutput, error) {
+	roomID, err := chat.NewRoomIDFromString(input.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := uc.chatRepo.FindRecentByRoomID(ctx, roomID, uc.limit)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]MessageInfo, 0, len(messages))
+	for _, m := range messages {
+		infos = append(infos, toMessageInfo(ctx, uc.userRepo, m))
+	}
+
+	return &FetchHistoryOutput{
+		Messages: infos,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package chat_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
+)
+
+// Mock Moderator
+type mockModerator struct {
+	moderateFunc func(moderation.Field, string) (string, error)
+}
+
+func (m *mockModerator) Moderate(field moderation.Field, value string) (string, error) {
+	if m.moderateFunc != nil {
+		return m.moderateFunc(field, value)
+	}
+	return value, nil
+}
+
+// Mock Rate Limiter
+type mockRateLimiter struct {
+	allowFunc func(string) bool
+}
+
+func (m *mockRateLimiter) Allow(key string) bool {
+	if m.allowFunc != nil {
+		return m.allowFunc(key)
+	}
+	return true
+}
+
+// mustSave stores an entity with an in-memory repository while arranging a test
+func mustSave[T any](t *testing.T, save func(context.Context, T) error, entity T) {
+	t.Helper()
+
+	if err := save(context.Background(), entity); err != nil {
+		t.Fatalf("Failed to save %T: %v", entity, err)
+	}
+}
+
+// testNow is the fixed time used by the use cases and entities under test
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
@@ -0,0 +1,36 @@
+package chat
+
+import (
+	"context"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/chat"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
+)
+
+// MessageInfo represents a chat message with author details
+type MessageInfo struct {
+	MessageID string
+	UserID    string
+	UserName  string
+	Body      string
+	CreatedAt time.Time
+}
+
+// toMessageInfo resolves the author name of a chat message
+func toMessageInfo(ctx context.Context, userRepo user.Repository, m *chat.Message) MessageInfo {
+	userName := "Unknown"
+	if userID, err := user.NewUserIDFromString(m.UserID().String()); err == nil {
+		if u, err := userRepo.FindByID(ctx, userID); err == nil {
+			userName = u.Name().String()
+		}
+	}
+
+	return MessageInfo{
+		MessageID: m.ID().String(),
+		UserID:    m.UserID().String(),
+		UserName:  userName,
+		Body:      m.Body().String(),
+		CreatedAt: m.CreatedAt(),
+	}
+}
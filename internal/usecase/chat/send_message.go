@@ -0,0 +1,95 @@
+package chat
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/chat"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/ratelimit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
+)
+
+// SendMessageInput represents the input for sending a chat message
+type SendMessageInput struct {
+	RoomID string
+	UserID string
+	Body   string
+}
+
+// SendMessageOutput represents the output for sending a chat message
+type SendMessageOutput struct {
+	Message MessageInfo
+}
+
+// SendMessageUseCase handles the logic for posting a chat message to a room
+type SendMessageUseCase struct {
+	chatRepo        chat.Repository
+	participantRepo participant.Repository
+	userRepo        user.Repository
+	moderator       moderation.Moderator
+	rateLimiter     ratelimit.Limiter
+	clock           clock.Clock
+}
+
+// NewSendMessageUseCase creates a new SendMessageUseCase
+func NewSendMessageUseCase(
+	chatRepo chat.Repository,
+	participantRepo participant.Repository,
+	userRepo user.Repository,
+	moderator moderation.Moderator,
+	rateLimiter ratelimit.Limiter,
+	clk clock.Clock,
+) *SendMessageUseCase {
+	return &SendMessageUseCase{
+		chatRepo:        chatRepo,
+		participantRepo: participantRepo,
+		userRepo:        userRepo,
+		moderator:       moderator,
+		rateLimiter:     rateLimiter,
+		clock:           clk,
+	}
+}
+
+// Execute validates, stores and returns a new chat message
+func (uc *SendMessageUseCase) Execute(ctx context.Context, input SendMessageInput) (*SendMessageOutput, error) {
+	roomID, err := chat.NewRoomIDFromString(input.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := chat.NewUserIDFromString(input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify user is a participant of the room
+	participantRoomID, _ := participant.NewRoomIDFromString(input.RoomID)
+	participantUserID, _ := participant.NewUserIDFromString(input.UserID)
+	if _, err := uc.participantRepo.FindByRoomAndUser(ctx, participantRoomID, participantUserID); err != nil {
+		return nil, errors.New("participant not found")
+	}
+
+	if !uc.rateLimiter.Allow(input.RoomID + ":" + input.UserID) {
+		return nil, chat.ErrRateLimited
+	}
+
+	moderatedBody, err := uc.moderator.Moderate(moderation.FieldChatMessage, input.Body)
+	if err != nil {
+		return nil, err
+	}
+	body, err := chat.NewBody(moderatedBody)
+	if err != nil {
+		return nil, err
+	}
+
+	message := chat.NewMessage(chat.NewMessageID(), roomID, userID, body, uc.clock.Now().UTC())
+	if err := uc.chatRepo.Save(ctx, message); err != nil {
+		return nil, err
+	}
+
+	return &SendMessageOutput{
+		Message: toMessageInfo(ctx, uc.userRepo, message),
+	}, nil
+}
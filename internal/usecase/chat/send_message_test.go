@@ -0,0 +1,196 @@
+package chat_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/chat"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+	chatUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/chat"
+)
+
+func TestSendMessageUseCaseExecute(t *testing.T) {
+	const (
+		testRoomID = "550e8400-e29b-41d4-a716-446655440000"
+		testUserID = "550e8400-e29b-41d4-a716-446655440001"
+	)
+
+	type fixture struct {
+		chatRepo        chat.Repository
+		participantRepo participant.Repository
+		userRepo        user.Repository
+		moderator       *mockModerator
+		rateLimiter     *mockRateLimiter
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		store := persistence.NewMemoryStore()
+		f := &fixture{
+			chatRepo:        persistence.NewInMemoryChatRepository(store),
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+			userRepo:        persistence.NewInMemoryUserRepository(store),
+			moderator:       &mockModerator{},
+			rateLimiter:     &mockRateLimiter{},
+		}
+
+		userID, _ := user.NewUserIDFromString(testUserID)
+		name, _ := user.NewUserName("Alice")
+		mustSave(t, f.userRepo.Save, user.NewUser(userID, name, testNow))
+		participantRoomID, _ := participant.NewRoomIDFromString(testRoomID)
+		participantUserID, _ := participant.NewUserIDFromString(testUserID)
+		mustSave(t, f.participantRepo.Save, participant.NewParticipant(participant.NewParticipantID(), participantRoomID, participantUserID, participant.RolePlayer, testNow))
+		return f
+	}
+
+	newUseCase := func(f *fixture) *chatUseCase.SendMessageUseCase {
+		return chatUseCase.NewSendMessageUseCase(
+			f.chatRepo,
+			f.participantRepo,
+			f.userRepo,
+			f.moderator,
+			f.rateLimiter,
+			infrastructureClock.NewFakeClock(testNow),
+		)
+	}
+
+	// savedMessages reads the messages stored in the test room
+	savedMessages := func(t *testing.T, f *fixture) []*chat.Message {
+		t.Helper()
+
+		chatRoomID, _ := chat.NewRoomIDFromString(testRoomID)
+		messages, err := f.chatRepo.FindRecentByRoomID(context.Background(), chatRoomID, 10)
+		if err != nil {
+			t.Fatalf("Failed to find messages: %v", err)
+		}
+		return messages
+	}
+
+	t.Run("正常にメッセージが保存され投稿者名付きで返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background(), chatUseCase.SendMessageInput{
+			RoomID: testRoomID,
+			UserID: testUserID,
+			Body:   "  hello  ",
+		})
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		messages := savedMessages(t, f)
+		if len(messages) != 1 {
+			t.Fatalf("Expected message to be saved, got: %d", len(messages))
+		}
+		saved := messages[0]
+		if output.Message.Body != "hello" {
+			t.Errorf("Expected body 'hello', got '%s'", output.Message.Body)
+		}
+		if output.Message.UserName != "Alice" {
+			t.Errorf("Expected user name 'Alice', got '%s'", output.Message.UserName)
+		}
+		if output.Message.MessageID != saved.ID().String() {
+			t.Errorf("Expected message ID %s, got %s", saved.ID().String(), output.Message.MessageID)
+		}
+		if !saved.CreatedAt().Equal(testNow) {
+			t.Errorf("Expected the message to be created at %v, got %v", testNow, saved.CreatedAt())
+		}
+	})
+
+	t.Run("参加者でない場合はエラーが返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), chatUseCase.SendMessageInput{
+			RoomID: testRoomID,
+			UserID: "550e8400-e29b-41d4-a716-446655440002",
+			Body:   "hello",
+		})
+
+		// assert
+		if err == nil {
+			t.Error("Expected error for non-participant")
+		}
+		if messages := savedMessages(t, f); len(messages) != 0 {
+			t.Errorf("Expected message not to be saved, got: %d", len(messages))
+		}
+	})
+
+	t.Run("レート制限を超えた場合はErrRateLimitedが返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		var key string
+		f.rateLimiter.allowFunc = func(k string) bool {
+			key = k
+			return false
+		}
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), chatUseCase.SendMessageInput{
+			RoomID: testRoomID,
+			UserID: testUserID,
+			Body:   "hello",
+		})
+
+		// assert
+		if !errors.Is(err, chat.ErrRateLimited) {
+			t.Errorf("Expected ErrRateLimited, got: %v", err)
+		}
+		if key != testRoomID+":"+testUserID {
+			t.Errorf("Expected rate limit key per room and user, got '%s'", key)
+		}
+		if messages := savedMessages(t, f); len(messages) != 0 {
+			t.Errorf("Expected message not to be saved, got: %d", len(messages))
+		}
+	})
+
+	t.Run("モデレーションで拒否された場合はエラーが返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		f.moderator.moderateFunc = func(field moderation.Field, value string) (string, error) {
+			if field != moderation.FieldChatMessage {
+				t.Errorf("Expected FieldChatMessage, got %v", field)
+			}
+			return "", moderation.ErrInappropriateContent
+		}
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), chatUseCase.SendMessageInput{
+			RoomID: testRoomID,
+			UserID: testUserID,
+			Body:   "bad word",
+		})
+
+		// assert
+		if !errors.Is(err, moderation.ErrInappropriateContent) {
+			t.Errorf("Expected ErrInappropriateContent, got: %v", err)
+		}
+	})
+
+	t.Run("空白のみのメッセージはErrEmptyBodyが返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), chatUseCase.SendMessageInput{
+			RoomID: testRoomID,
+			UserID: testUserID,
+			Body:   "   ",
+		})
+
+		// assert
+		if !errors.Is(err, chat.ErrEmptyBody) {
+			t.Errorf("Expected ErrEmptyBody, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package room
+
+import (
+	"encoding/json"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+)
+
+// emojiAssignment is the emoji a player has to describe during the discussion
+type emojiAssignment struct {
+	UserID string `json:"user_id"`
+	Emoji  string `json:"emoji"`
+}
+
+// assignEmojis gives each player (excluding the host) one displayed emoji in order
+// and returns the assignments as JSON strings
+func assignEmojis(participants []*participant.Participant, displayedEmojis []string) []string {
+	assignments := []string{}
+	emojiIndex := 0
+	for _, p := range participants {
+		if p.Role() == participant.RoleHost || emojiIndex >= len(displayedEmojis) {
+			continue
+		}
+		jsonBytes, _ := json.Marshal(emojiAssignment{
+			UserID: p.UserID().String(),
+			Emoji:  displayedEmojis[emojiIndex],
+		})
+		assignments = append(assignments, string(jsonBytes))
+		emojiIndex++
+	}
+	return assignments
+}
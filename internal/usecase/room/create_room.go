@@ -3,14 +3,27 @@ package room
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/theme"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/transaction"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
+	"github.com/shooooooma415/guess-title-game-api/internal/usecase/auditlog"
 	"github.com/shooooooma415/guess-title-game-api/utils"
 )
 
+// CreateRoomInput represents the input for creating a room.
+// Zero timeouts leave the phase without a deadline.
+type CreateRoomInput struct {
+	SettingTopicTimeout time.Duration
+	AnsweringTimeout    time.Duration
+	CheckingTimeout     time.Duration
+}
+
 // CreateRoomOutput represents the output after creating a room
 type CreateRoomOutput struct {
 	RoomID   string
@@ -26,6 +39,9 @@ type CreateRoomUseCase struct {
 	roomRepo        room.Repository
 	themeRepo       theme.Repository
 	participantRepo participant.Repository
+	auditRepo       audit.Repository
+	txManager       transaction.Manager
+	clock           clock.Clock
 }
 
 // NewCreateRoomUseCase creates a new CreateRoomUseCase
@@ -34,17 +50,28 @@ func NewCreateRoomUseCase(
 	roomRepo room.Repository,
 	themeRepo theme.Repository,
 	participantRepo participant.Repository,
+	auditRepo audit.Repository,
+	txManager transaction.Manager,
+	clk clock.Clock,
 ) *CreateRoomUseCase {
 	return &CreateRoomUseCase{
 		userRepo:        userRepo,
 		roomRepo:        roomRepo,
 		themeRepo:       themeRepo,
 		participantRepo: participantRepo,
+		auditRepo:       auditRepo,
+		txManager:       txManager,
+		clock:           clk,
 	}
 }
 
 // Execute creates a new room
-func (uc *CreateRoomUseCase) Execute(ctx context.Context) (*CreateRoomOutput, error) {
+func (uc *CreateRoomUseCase) Execute(ctx context.Context, input CreateRoomInput) (*CreateRoomOutput, error) {
+	timeouts, err := room.NewPhaseTimeouts(input.SettingTopicTimeout, input.AnsweringTimeout, input.CheckingTimeout)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get a random theme
 	themes, err := uc.themeRepo.FindAll(ctx)
 	if err != nil {
@@ -55,13 +82,12 @@ func (uc *CreateRoomUseCase) Execute(ctx context.Context) (*CreateRoomOutput, er
 	}
 	selectedTheme := utils.RandomSelect(themes)
 
+	now := uc.clock.Now()
+
 	// Create host user
 	hostUserID := user.NewUserID()
 	hostUserName, _ := user.NewUserName("Host")
-	hostUser := user.NewUser(hostUserID, hostUserName)
-	if err := uc.userRepo.Save(ctx, hostUser); err != nil {
-		return nil, err
-	}
+	hostUser := user.NewUser(hostUserID, hostUserName, now)
 
 	// Create room
 	roomID := room.NewRoomID()
@@ -69,10 +95,8 @@ func (uc *CreateRoomUseCase) Execute(ctx context.Context) (*CreateRoomOutput, er
 	themeID, _ := room.NewThemeIDFromString(selectedTheme.ID().String())
 	hostID, _ := room.NewHostUserIDFromString(hostUserID.String())
 
-	newRoom := room.NewRoom(roomID, roomCode, themeID, hostID)
-	if err := uc.roomRepo.Save(ctx, newRoom); err != nil {
-		return nil, err
-	}
+	newRoom := room.NewRoom(roomID, roomCode, themeID, hostID, now)
+	newRoom.SetPhaseTimeouts(timeouts)
 
 	// Create host participant
 	participantID := participant.NewParticipantID()
@@ -84,13 +108,30 @@ func (uc *CreateRoomUseCase) Execute(ctx context.Context) (*CreateRoomOutput, er
 		participantRoomID,
 		participantUserID,
 		participant.RoleHost,
+		now,
 	)
 	// ホストはLeaderではない。最初に参加したPlayerがLeaderになる
 
-	if err := uc.participantRepo.Save(ctx, hostParticipant); err != nil {
+	// Save the host, the room and its host participant together, so that a
+	// failure leaves neither an orphaned user nor a room without a host
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.Save(ctx, hostUser); err != nil {
+			return err
+		}
+		if err := uc.roomRepo.Save(ctx, newRoom); err != nil {
+			return err
+		}
+		return uc.participantRepo.Save(ctx, hostParticipant)
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	// Deadlines are room settings, so choosing them is audited like a later change would be
+	if !timeouts.IsZero() {
+		auditlog.Record(ctx, uc.auditRepo, roomID.String(), audit.NewUserActor(hostUserID.String()), audit.ActionUpdateSettings, "", newRoom.Status().String(), now)
+	}
+
 	return &CreateRoomOutput{
 		RoomID:   roomID.String(),
 		UserID:   hostUserID.String(),
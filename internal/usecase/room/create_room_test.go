@@ -4,64 +4,72 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/theme"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
 	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
 )
 
 func TestCreateRoomUseCaseExecute(t *testing.T) {
 	type fixture struct {
-		useCase         *roomUseCase.CreateRoomUseCase
-		userRepo        *mockUserRepository
-		roomRepo        *mockRoomRepository
-		themeRepo       *mockThemeRepository
-		participantRepo *mockParticipantRepository
+		userRepo        user.Repository
+		roomRepo        room.Repository
+		themeRepo       theme.Repository
+		participantRepo participant.Repository
+		auditRepo       audit.Repository
+		txManager       *persistence.InMemoryTxManager
 	}
 
 	newFixture := func(t *testing.T) *fixture {
 		t.Helper()
 
-		userRepo := &mockUserRepository{}
-		roomRepo := &mockRoomRepository{}
-		themeRepo := &mockThemeRepository{}
-		participantRepo := &mockParticipantRepository{}
-
-		useCase := roomUseCase.NewCreateRoomUseCase(
-			userRepo,
-			roomRepo,
-			themeRepo,
-			participantRepo,
-		)
-
+		store := persistence.NewMemoryStore()
 		return &fixture{
-			useCase:         useCase,
-			userRepo:        userRepo,
-			roomRepo:        roomRepo,
-			themeRepo:       themeRepo,
-			participantRepo: participantRepo,
+			userRepo:        persistence.NewInMemoryUserRepository(store),
+			roomRepo:        persistence.NewInMemoryRoomRepository(store),
+			themeRepo:       persistence.NewInMemoryThemeRepository(store),
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+			auditRepo:       persistence.NewInMemoryAuditRepository(store),
+			txManager:       persistence.NewInMemoryTxManager(store),
 		}
 	}
 
-	createTestTheme := func() *theme.Theme {
-		themeID := theme.NewThemeID()
-		themeTitle, _ := theme.NewThemeTitle("Test Theme")
-		hint := theme.NewHint("Test Hint")
-		return theme.NewTheme(themeID, themeTitle, hint)
+	newUseCase := func(f *fixture) *roomUseCase.CreateRoomUseCase {
+		return roomUseCase.NewCreateRoomUseCase(
+			f.userRepo,
+			f.roomRepo,
+			f.themeRepo,
+			f.participantRepo,
+			f.auditRepo,
+			f.txManager,
+			infrastructureClock.NewFakeClock(testNow),
+		)
+	}
+
+	// findRoom returns the stored room of the output
+	findRoom := func(t *testing.T, f *fixture, output *roomUseCase.CreateRoomOutput) *room.Room {
+		t.Helper()
+
+		roomID, _ := room.NewRoomIDFromString(output.RoomID)
+		stored, err := f.roomRepo.FindByID(context.Background(), roomID)
+		if err != nil {
+			t.Fatalf("Expected the room to be saved, got: %v", err)
+		}
+		return stored
 	}
 
 	t.Run("正常にルームが作成されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testTheme := createTestTheme()
-		f.themeRepo.findAllFunc = func(ctx context.Context) ([]*theme.Theme, error) {
-			return []*theme.Theme{testTheme}, nil
-		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background())
+		output, err := newUseCase(f).Execute(context.Background(), roomUseCase.CreateRoomInput{})
 
 		// assert
 		if err != nil {
@@ -84,24 +92,105 @@ func TestCreateRoomUseCaseExecute(t *testing.T) {
 			t.Error("Expected RoomCode to be set")
 		}
 
-		if output.Theme != "Test Theme" {
-			t.Errorf("Expected Theme to be 'Test Theme', got: %s", output.Theme)
+		stored := findRoom(t, f, output)
+		themeID, _ := theme.NewThemeIDFromString(stored.ThemeID().String())
+		storedTheme, err := f.themeRepo.FindByID(context.Background(), themeID)
+		if err != nil {
+			t.Fatalf("Expected the theme of the room to exist, got: %v", err)
+		}
+		if output.Theme != storedTheme.Title().String() || output.Hint != storedTheme.Hint().String() {
+			t.Errorf("Expected the theme of the room %s (%s), got: %s (%s)", storedTheme.Title().String(), storedTheme.Hint().String(), output.Theme, output.Hint)
+		}
+	})
+
+	t.Run("フェーズごとの締め切りがルームに設定されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		input := roomUseCase.CreateRoomInput{
+			SettingTopicTimeout: 60 * time.Second,
+			AnsweringTimeout:    30 * time.Second,
+		}
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		timeouts := findRoom(t, f, output).PhaseTimeouts()
+		if timeouts.SettingTopic() != 60*time.Second || timeouts.Answering() != 30*time.Second || timeouts.Checking() != 0 {
+			t.Errorf("Expected timeouts 1m0s/30s/0s, got: %v/%v/%v", timeouts.SettingTopic(), timeouts.Answering(), timeouts.Checking())
+		}
+	})
+
+	t.Run("締め切りを設定した場合は設定変更が監査ログに記録されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		input := roomUseCase.CreateRoomInput{AnsweringTimeout: 30 * time.Second}
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		auditRoomID, _ := audit.NewRoomIDFromString(output.RoomID)
+		logs, _ := f.auditRepo.FindByRoomID(context.Background(), auditRoomID)
+		if len(logs) != 1 {
+			t.Fatalf("Expected 1 audit log, got: %d", len(logs))
+		}
+		if logs[0].Action() != audit.ActionUpdateSettings || logs[0].Actor().ID() != output.UserID || logs[0].AfterStatus() != room.StatusWaiting.String() {
+			t.Errorf("Expected update_settings by the host, got: %s by %s", logs[0].Action(), logs[0].Actor().ID())
+		}
+	})
+
+	t.Run("締め切りがない場合は監査ログが記録されないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background(), roomUseCase.CreateRoomInput{})
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		auditRoomID, _ := audit.NewRoomIDFromString(output.RoomID)
+		logs, _ := f.auditRepo.FindByRoomID(context.Background(), auditRoomID)
+		if len(logs) != 0 {
+			t.Errorf("Expected no audit log to be saved, got: %d", len(logs))
 		}
+	})
+
+	t.Run("締め切りが範囲外の場合はエラーが返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		input := roomUseCase.CreateRoomInput{CheckingTimeout: 2 * time.Hour}
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background(), input)
 
-		if output.Hint != "Test Hint" {
-			t.Errorf("Expected Hint to be 'Test Hint', got: %s", output.Hint)
+		// assert
+		if !errors.Is(err, room.ErrInvalidPhaseTimeout) {
+			t.Errorf("Expected ErrInvalidPhaseTimeout, got: %v", err)
+		}
+		if output != nil {
+			t.Error("Expected nil output when error occurs")
 		}
 	})
 
 	t.Run("テーマが存在しない場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		f.themeRepo.findAllFunc = func(ctx context.Context) ([]*theme.Theme, error) {
-			return []*theme.Theme{}, nil
+		themes, _ := f.themeRepo.FindAll(context.Background())
+		for _, th := range themes {
+			f.themeRepo.Delete(context.Background(), th.ID())
 		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background())
+		output, err := newUseCase(f).Execute(context.Background(), roomUseCase.CreateRoomInput{})
 
 		// assert
 		if err == nil {
@@ -116,12 +205,14 @@ func TestCreateRoomUseCaseExecute(t *testing.T) {
 	t.Run("ThemeRepositoryでエラーが発生した場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		f.themeRepo.findAllFunc = func(ctx context.Context) ([]*theme.Theme, error) {
-			return nil, errors.New("database error")
+		f.themeRepo = &mockThemeRepository{
+			findAllFunc: func(ctx context.Context) ([]*theme.Theme, error) {
+				return nil, errors.New("database error")
+			},
 		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background())
+		output, err := newUseCase(f).Execute(context.Background(), roomUseCase.CreateRoomInput{})
 
 		// assert
 		if err == nil {
@@ -136,16 +227,14 @@ func TestCreateRoomUseCaseExecute(t *testing.T) {
 	t.Run("Userの保存に失敗した場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testTheme := createTestTheme()
-		f.themeRepo.findAllFunc = func(ctx context.Context) ([]*theme.Theme, error) {
-			return []*theme.Theme{testTheme}, nil
-		}
-		f.userRepo.saveFunc = func(ctx context.Context, _ *user.User) error {
-			return errors.New("user save error")
+		f.userRepo = &mockUserRepository{
+			saveFunc: func(ctx context.Context, _ *user.User) error {
+				return errors.New("user save error")
+			},
 		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background())
+		output, err := newUseCase(f).Execute(context.Background(), roomUseCase.CreateRoomInput{})
 
 		// assert
 		if err == nil {
@@ -160,16 +249,14 @@ func TestCreateRoomUseCaseExecute(t *testing.T) {
 	t.Run("Roomの保存に失敗した場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testTheme := createTestTheme()
-		f.themeRepo.findAllFunc = func(ctx context.Context) ([]*theme.Theme, error) {
-			return []*theme.Theme{testTheme}, nil
-		}
-		f.roomRepo.saveFunc = func(ctx context.Context, _ *room.Room) error {
-			return errors.New("room save error")
+		f.roomRepo = &mockRoomRepository{
+			saveFunc: func(ctx context.Context, _ *room.Room) error {
+				return errors.New("room save error")
+			},
 		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background())
+		output, err := newUseCase(f).Execute(context.Background(), roomUseCase.CreateRoomInput{})
 
 		// assert
 		if err == nil {
@@ -184,16 +271,14 @@ func TestCreateRoomUseCaseExecute(t *testing.T) {
 	t.Run("Participantの保存に失敗した場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testTheme := createTestTheme()
-		f.themeRepo.findAllFunc = func(ctx context.Context) ([]*theme.Theme, error) {
-			return []*theme.Theme{testTheme}, nil
-		}
-		f.participantRepo.saveFunc = func(ctx context.Context, _ *participant.Participant) error {
-			return errors.New("participant save error")
+		f.participantRepo = &mockParticipantRepository{
+			saveFunc: func(ctx context.Context, _ *participant.Participant) error {
+				return errors.New("participant save error")
+			},
 		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background())
+		output, err := newUseCase(f).Execute(context.Background(), roomUseCase.CreateRoomInput{})
 
 		// assert
 		if err == nil {
@@ -204,4 +289,56 @@ func TestCreateRoomUseCaseExecute(t *testing.T) {
 			t.Error("Expected nil output when error occurs")
 		}
 	})
+
+	t.Run("ユーザー・ルーム・参加者が保存されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background(), roomUseCase.CreateRoomInput{})
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		userID, _ := user.NewUserIDFromString(output.UserID)
+		if _, err := f.userRepo.FindByID(context.Background(), userID); err != nil {
+			t.Errorf("Expected the host user to be saved, got: %v", err)
+		}
+		findRoom(t, f, output)
+		participantRoomID, _ := participant.NewRoomIDFromString(output.RoomID)
+		participantUserID, _ := participant.NewUserIDFromString(output.UserID)
+		host, err := f.participantRepo.FindByRoomAndUser(context.Background(), participantRoomID, participantUserID)
+		if err != nil || host.Role() != participant.RoleHost {
+			t.Errorf("Expected the host participant to be saved, got: %v", err)
+		}
+	})
+
+	t.Run("Participantの保存に失敗した場合はユーザーとルームの保存もロールバックされること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		var failed *participant.Participant
+		f.participantRepo = &mockParticipantRepository{
+			saveFunc: func(ctx context.Context, p *participant.Participant) error {
+				failed = p
+				return errors.New("participant save error")
+			},
+		}
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), roomUseCase.CreateRoomInput{})
+
+		// assert
+		if err == nil {
+			t.Fatal("Expected error when participant save fails")
+		}
+		userID, _ := user.NewUserIDFromString(failed.UserID().String())
+		if _, err := f.userRepo.FindByID(context.Background(), userID); err == nil {
+			t.Error("Expected the host user to be rolled back")
+		}
+		roomID, _ := room.NewRoomIDFromString(failed.RoomID().String())
+		if _, err := f.roomRepo.FindByID(context.Background(), roomID); err == nil {
+			t.Error("Expected the room to be rolled back")
+		}
+	})
 }
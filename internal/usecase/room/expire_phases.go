@@ -0,0 +1,198 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/theme"
+	"github.com/shooooooma415/guess-title-game-api/internal/usecase/auditlog"
+)
+
+// phaseTimeoutActor is the audit actor of the fallbacks applied when a phase deadline passes
+const phaseTimeoutActor = "phase_deadline"
+
+// ExpirePhasesUseCase applies the fallback of every room whose phase deadline has passed:
+// emojis are picked from the theme preset in setting_topic, no answer is submitted as
+// incorrect in answering, and the game is finished in checking
+type ExpirePhasesUseCase struct {
+	roomRepo        room.Repository
+	participantRepo participant.Repository
+	themeRepo       theme.Repository
+	eventPublisher  event.Publisher
+	auditRepo       audit.Repository
+	clock           clock.Clock
+}
+
+// NewExpirePhasesUseCase creates a new ExpirePhasesUseCase
+func NewExpirePhasesUseCase(
+	roomRepo room.Repository,
+	participantRepo participant.Repository,
+	themeRepo theme.Repository,
+	eventPublisher event.Publisher,
+	auditRepo audit.Repository,
+	clk clock.Clock,
+) *ExpirePhasesUseCase {
+	return &ExpirePhasesUseCase{
+		roomRepo:        roomRepo,
+		participantRepo: participantRepo,
+		themeRepo:       themeRepo,
+		eventPublisher:  eventPublisher,
+		auditRepo:       auditRepo,
+		clock:           clk,
+	}
+}
+
+// ExpirePhasesOutput represents output for expiring phases
+type ExpirePhasesOutput struct {
+	Expired int
+}
+
+// Execute applies the fallbacks of the rooms whose phase deadline has passed.
+// A room that fails is logged and retried on the next run.
+func (uc *ExpirePhasesUseCase) Execute(ctx context.Context) (*ExpirePhasesOutput, error) {
+	now := uc.clock.Now()
+	results, err := uc.roomRepo.Search(ctx, room.SearchCriteria{PhaseEndsBefore: &now})
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ExpirePhasesOutput{}
+	for _, result := range results {
+		rm := result.Room
+		err := uc.expireWithRetry(ctx, rm)
+		if errors.Is(err, room.ErrPhaseDeadlineNotDue) {
+			// The room left the phase while the fallback was being applied
+			continue
+		}
+		if err != nil {
+			log.Printf("Error applying phase fallback for room %s: %v", rm.ID().String(), err)
+			continue
+		}
+		output.Expired++
+	}
+
+	return output, nil
+}
+
+// expireWithRetry expires a room found by the search, reading it again when
+// another writer saves it first
+func (uc *ExpirePhasesUseCase) expireWithRetry(ctx context.Context, rm *room.Room) error {
+	stale := false
+	return room.RetryOnConflict(func() error {
+		if stale {
+			latest, err := uc.roomRepo.FindByID(ctx, rm.ID())
+			if err != nil {
+				return err
+			}
+			rm = latest
+		}
+		stale = true
+		return uc.expire(ctx, rm)
+	})
+}
+
+// expire applies the fallback of the current phase of a room
+func (uc *ExpirePhasesUseCase) expire(ctx context.Context, rm *room.Room) error {
+	now := uc.clock.Now()
+	if !rm.PhaseDeadlinePassed(now) {
+		return room.ErrPhaseDeadlineNotDue
+	}
+
+	beforeStatus := rm.Status()
+	var evt event.Event
+	switch beforeStatus {
+	case room.StatusSettingTopic:
+		if err := uc.autoSelectTopic(ctx, rm, now); err != nil {
+			return err
+		}
+		evt = event.NewTopicAutoSelectedEvent(rm.ID().String(), now)
+
+	case room.StatusAnswering:
+		if err := rm.ForfeitAnswer(now); err != nil {
+			return err
+		}
+		evt = event.NewAnswerTimedOutEvent(rm.ID().String(), now)
+
+	case room.StatusChecking:
+		if err := rm.ChangeStatus(room.StatusFinished, now); err != nil {
+			return err
+		}
+		evt = event.NewGameAutoFinishedEvent(rm.ID().String(), now)
+
+	default:
+		// The deadline belongs to a phase the room has left; clear it
+		rm.SetPhaseDeadline(nil)
+		return uc.roomRepo.Save(ctx, rm)
+	}
+
+	if err := uc.roomRepo.Save(ctx, rm); err != nil {
+		return err
+	}
+
+	auditlog.Record(ctx, uc.auditRepo, rm.ID().String(), audit.NewSystemActor(phaseTimeoutActor), audit.ActionPhaseTimeout, beforeStatus.String(), rm.Status().String(), now)
+
+	uc.eventPublisher.Publish(evt)
+
+	return nil
+}
+
+// autoSelectTopic sets the theme as the topic unless the host has set one, picks one emoji per player from the
+// theme preset with a random dummy and starts the discussion
+func (uc *ExpirePhasesUseCase) autoSelectTopic(ctx context.Context, rm *room.Room, now time.Time) error {
+	themeID, err := theme.NewThemeIDFromString(rm.ThemeID().String())
+	if err != nil {
+		return err
+	}
+	foundTheme, err := uc.themeRepo.FindByID(ctx, themeID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch theme: %w", err)
+	}
+
+	participantRoomID, _ := participant.NewRoomIDFromString(rm.ID().String())
+	participants, err := uc.participantRepo.FindByRoomID(ctx, participantRoomID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch participants: %w", err)
+	}
+	players := 0
+	for _, p := range participants {
+		if p.Role() != participant.RoleHost {
+			players++
+		}
+	}
+
+	preset := foundTheme.EmojiPreset()
+	if len(preset) < 2 {
+		preset = room.DefaultEmojiPreset
+	}
+	preset = append([]string(nil), preset...)
+	rand.Shuffle(len(preset), func(i, j int) { preset[i], preset[j] = preset[j], preset[i] })
+
+	autoTopic, err := room.NewAutoTopic(preset, players, rand.IntN(max(players, 1)))
+	if err != nil {
+		return err
+	}
+
+	// Keep a topic the host has already set
+	if rm.Topic() == nil || rm.Topic().IsEmpty() {
+		topic, err := room.NewTopic(foundTheme.Title().String())
+		if err != nil {
+			return err
+		}
+		if err := rm.SetTopic(topic); err != nil {
+			return err
+		}
+	}
+	rm.SetGameData(autoTopic.OriginalEmojis, autoTopic.DisplayedEmojis, autoTopic.DummyIndex, autoTopic.DummyEmoji)
+	rm.SetAssignments(room.NewAssignments(assignEmojis(participants, autoTopic.DisplayedEmojis.Values())))
+
+	return rm.ChangeStatus(room.StatusDiscussing, now)
+}
@@ -0,0 +1,365 @@
+package room_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/theme"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
+)
+
+func TestExpirePhasesUseCaseExecute(t *testing.T) {
+	const themeID = "550e8400-e29b-41d4-a716-446655440002"
+
+	type fixture struct {
+		clock           *infrastructureClock.FakeClock
+		roomRepo        room.Repository
+		participantRepo participant.Repository
+		themeRepo       theme.Repository
+		eventPublisher  *mockEventPublisher
+		auditRepo       audit.Repository
+		published       []event.Event
+	}
+
+	newFixture := func(t *testing.T, rooms ...*room.Room) *fixture {
+		t.Helper()
+
+		store := persistence.NewMemoryStore()
+		f := &fixture{
+			clock:           infrastructureClock.NewFakeClock(testNow),
+			roomRepo:        persistence.NewInMemoryRoomRepository(store),
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+			themeRepo:       persistence.NewInMemoryThemeRepository(store),
+			eventPublisher:  &mockEventPublisher{},
+			auditRepo:       persistence.NewInMemoryAuditRepository(store),
+		}
+		for _, r := range rooms {
+			mustSave(t, f.roomRepo.Save, r)
+		}
+		f.eventPublisher.publishFunc = func(evt event.Event) {
+			f.published = append(f.published, evt)
+		}
+		return f
+	}
+
+	newUseCase := func(f *fixture) *roomUseCase.ExpirePhasesUseCase {
+		return roomUseCase.NewExpirePhasesUseCase(
+			f.roomRepo,
+			f.participantRepo,
+			f.themeRepo,
+			f.eventPublisher,
+			f.auditRepo,
+			f.clock,
+		)
+	}
+
+	// createRoom creates a room with deadlines of one minute for every phase, moved to status
+	createRoom := func(t *testing.T, status room.RoomStatus) *room.Room {
+		t.Helper()
+
+		roomID := room.NewRoomID()
+		roomThemeID, _ := room.NewThemeIDFromString(themeID)
+		hostUserID, _ := room.NewHostUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
+		r := room.NewRoom(roomID, room.NewRoomCode(), roomThemeID, hostUserID, testNow)
+		timeouts, _ := room.NewPhaseTimeouts(time.Minute, time.Minute, time.Minute)
+		r.SetPhaseTimeouts(timeouts)
+		r.Start(testNow)
+		for _, next := range []room.RoomStatus{room.StatusDiscussing, room.StatusAnswering, room.StatusChecking} {
+			if r.Status() == status {
+				break
+			}
+			r.ChangeStatus(next, testNow)
+		}
+		return r
+	}
+
+	createParticipant := func(roomID room.RoomID, userID string, role participant.ParticipantRole) *participant.Participant {
+		participantRoomID, _ := participant.NewRoomIDFromString(roomID.String())
+		participantUserID, _ := participant.NewUserIDFromString(userID)
+		return participant.NewParticipant(participant.NewParticipantID(), participantRoomID, participantUserID, role, testNow)
+	}
+
+	// saveTheme stores the theme of the rooms with the emoji preset
+	saveTheme := func(t *testing.T, f *fixture, title string, preset []string) {
+		t.Helper()
+
+		id, _ := theme.NewThemeIDFromString(themeID)
+		themeTitle, _ := theme.NewThemeTitle(title)
+		th := theme.NewTheme(id, themeTitle, theme.NewHint(""))
+		if preset != nil {
+			th.SetEmojiPreset(preset)
+		}
+		mustSave(t, f.themeRepo.Save, th)
+	}
+
+	findRoom := func(t *testing.T, f *fixture, id room.RoomID) *room.Room {
+		t.Helper()
+
+		stored, err := f.roomRepo.FindByID(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Failed to find room: %v", err)
+		}
+		return stored
+	}
+
+	t.Run("トピック設定の締め切りを過ぎるとテーマのプリセットから絵文字が選ばれ議論に進むこと", func(t *testing.T) {
+		// arrange
+		testRoom := createRoom(t, room.StatusSettingTopic)
+		f := newFixture(t, testRoom)
+		preset := []string{"🍜", "🥢", "🍥", "🥚"}
+		saveTheme(t, f, "ラーメン", preset)
+		mustSave(t, f.participantRepo.Save, createParticipant(testRoom.ID(), "550e8400-e29b-41d4-a716-446655440001", participant.RoleHost))
+		mustSave(t, f.participantRepo.Save, createParticipant(testRoom.ID(), "550e8400-e29b-41d4-a716-446655440003", participant.RolePlayer))
+		mustSave(t, f.participantRepo.Save, createParticipant(testRoom.ID(), "550e8400-e29b-41d4-a716-446655440004", participant.RolePlayer))
+		f.clock.Advance(time.Minute)
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background())
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if output.Expired != 1 {
+			t.Errorf("Expected 1 expired room, got: %d", output.Expired)
+		}
+		stored := findRoom(t, f, testRoom.ID())
+		if stored.Status() != room.StatusDiscussing {
+			t.Fatalf("Expected status discussing, got: %s", stored.Status().String())
+		}
+		if stored.Topic() == nil || stored.Topic().String() != "ラーメン" {
+			t.Errorf("Expected the theme to be the topic, got: %v", stored.Topic())
+		}
+		displayed := stored.DisplayedEmojis().Values()
+		if len(displayed) != 2 || stored.OriginalEmojis().Count() != 2 {
+			t.Fatalf("Expected one emoji per player, got: %v", displayed)
+		}
+		if displayed[stored.DummyIndex().Value()] != stored.DummyEmoji().String() {
+			t.Errorf("Expected the dummy emoji at the dummy index, got: %v", displayed)
+		}
+		for _, emoji := range append(displayed, stored.OriginalEmojis().Values()...) {
+			found := false
+			for _, candidate := range preset {
+				found = found || candidate == emoji
+			}
+			if !found {
+				t.Errorf("Expected emojis from the preset, got: %s", emoji)
+			}
+		}
+		for _, raw := range stored.Assignments().Values() {
+			var assignment struct {
+				UserID string `json:"user_id"`
+			}
+			json.Unmarshal([]byte(raw), &assignment)
+			if assignment.UserID == "550e8400-e29b-41d4-a716-446655440001" {
+				t.Error("Expected the host not to be assigned an emoji")
+			}
+		}
+		if stored.Assignments().Count() != 2 {
+			t.Errorf("Expected 2 assignments, got: %d", stored.Assignments().Count())
+		}
+		if stored.DiscussionDeadline() == nil {
+			t.Error("Expected the discussion countdown to be scheduled")
+		}
+		if len(f.published) != 1 || f.published[0].EventType() != "TopicAutoSelected" {
+			t.Errorf("Expected TopicAutoSelected to be published, got: %v", f.published)
+		}
+		auditRoomID, _ := audit.NewRoomIDFromString(testRoom.ID().String())
+		logs, _ := f.auditRepo.FindByRoomID(context.Background(), auditRoomID)
+		if len(logs) != 1 || logs[0].Actor().Type() != audit.ActorSystem || logs[0].Action() != audit.ActionPhaseTimeout {
+			t.Errorf("Expected a phase_timeout audit log by the system, got: %v", logs)
+		}
+	})
+
+	t.Run("テーマにプリセットがない場合は既定の絵文字が選ばれること", func(t *testing.T) {
+		// arrange
+		testRoom := createRoom(t, room.StatusSettingTopic)
+		f := newFixture(t, testRoom)
+		saveTheme(t, f, "桜", nil)
+		mustSave(t, f.participantRepo.Save, createParticipant(testRoom.ID(), "550e8400-e29b-41d4-a716-446655440003", participant.RolePlayer))
+		f.clock.Advance(time.Minute)
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background())
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		stored := findRoom(t, f, testRoom.ID())
+		if stored.Status() != room.StatusDiscussing || stored.DisplayedEmojis().Count() != 1 {
+			t.Errorf("Expected the discussion to start with the default preset, got: %s %v", stored.Status().String(), stored.DisplayedEmojis())
+		}
+	})
+
+	t.Run("回答の締め切りを過ぎると無回答が不正解として提出されること", func(t *testing.T) {
+		// arrange
+		testRoom := createRoom(t, room.StatusAnswering)
+		f := newFixture(t, testRoom)
+		f.clock.Advance(time.Minute)
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background())
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if output.Expired != 1 {
+			t.Errorf("Expected 1 expired room, got: %d", output.Expired)
+		}
+		stored := findRoom(t, f, testRoom.ID())
+		if stored.Status() != room.StatusChecking {
+			t.Errorf("Expected status checking, got: %s", stored.Status().String())
+		}
+		if stored.Answer() == nil || stored.Answer().String() != room.NoAnswer {
+			t.Errorf("Expected %q to be submitted, got: %v", room.NoAnswer, stored.Answer())
+		}
+		if correct := stored.AnswerCorrect(); correct == nil || *correct {
+			t.Errorf("Expected the answer to be marked incorrect, got: %v", correct)
+		}
+		if len(f.published) != 1 || f.published[0].EventType() != "AnswerTimedOut" {
+			t.Errorf("Expected AnswerTimedOut to be published, got: %v", f.published)
+		}
+	})
+
+	t.Run("確認の締め切りを過ぎるとゲームが終了すること", func(t *testing.T) {
+		// arrange
+		testRoom := createRoom(t, room.StatusChecking)
+		f := newFixture(t, testRoom)
+		f.clock.Advance(time.Minute)
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background())
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		stored := findRoom(t, f, testRoom.ID())
+		if stored.Status() != room.StatusFinished {
+			t.Errorf("Expected status finished, got: %s", stored.Status().String())
+		}
+		if stored.PhaseDeadline() != nil {
+			t.Errorf("Expected no deadline after finishing, got: %v", stored.PhaseDeadline())
+		}
+		if len(f.published) != 1 || f.published[0].EventType() != "GameAutoFinished" {
+			t.Errorf("Expected GameAutoFinished to be published, got: %v", f.published)
+		}
+	})
+
+	t.Run("締め切り前のルームは変更されないこと", func(t *testing.T) {
+		// arrange
+		testRoom := createRoom(t, room.StatusChecking)
+		f := newFixture(t, testRoom)
+		f.clock.Advance(59 * time.Second)
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background())
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		stored := findRoom(t, f, testRoom.ID())
+		if output.Expired != 0 || stored.Status() != room.StatusChecking || len(f.published) != 0 {
+			t.Errorf("Expected the room to be left as is, got: %d expired, status %s", output.Expired, stored.Status().String())
+		}
+	})
+
+	t.Run("保存に失敗したルームはイベントを発行せず他のルームの処理を続けること", func(t *testing.T) {
+		// arrange
+		failingRoom := createRoom(t, room.StatusChecking)
+		testRoom := createRoom(t, room.StatusChecking)
+		f := newFixture(t, failingRoom, testRoom)
+		roomRepo := f.roomRepo
+		f.roomRepo = &mockRoomRepository{
+			findByIDFunc: roomRepo.FindByID,
+			searchFunc:   roomRepo.Search,
+			saveFunc: func(ctx context.Context, r *room.Room) error {
+				if r.ID().Equals(failingRoom.ID()) {
+					return errors.New("save error")
+				}
+				return roomRepo.Save(ctx, r)
+			},
+		}
+		f.clock.Advance(time.Minute)
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background())
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if output.Expired != 1 {
+			t.Errorf("Expected 1 expired room, got: %d", output.Expired)
+		}
+		if len(f.published) != 1 || f.published[0].AggregateID() != testRoom.ID().String() {
+			t.Errorf("Expected only the saved room to publish an event, got: %v", f.published)
+		}
+		if stored, _ := roomRepo.FindByID(context.Background(), failingRoom.ID()); stored.Status() != room.StatusChecking {
+			t.Errorf("Expected the failing room to be left as is, got: %s", stored.Status().String())
+		}
+	})
+
+	t.Run("適用中に回答が提出された場合は最新のルームを読み直しフォールバックしないこと", func(t *testing.T) {
+		// arrange
+		testRoom := createRoom(t, room.StatusAnswering)
+		f := newFixture(t)
+		roomRepo := newRacingRoomRepository(testRoom)
+		roomRepo.beforeSave = func() {
+			// The leader submits an answer between the search and the save
+			roomRepo.beforeSave = nil
+			latest, _ := roomRepo.FindByID(context.Background(), testRoom.ID())
+			answer, _ := room.NewAnswer("ラーメン")
+			latest.SetAnswer(answer)
+			latest.ChangeStatus(room.StatusChecking, f.clock.Now())
+			roomRepo.Save(context.Background(), latest)
+		}
+		f.roomRepo = roomRepo
+		f.clock.Advance(time.Minute)
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background())
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if output.Expired != 0 || len(f.published) != 0 {
+			t.Errorf("Expected no fallback, got: %d expired and %v", output.Expired, f.published)
+		}
+		stored, _ := roomRepo.FindByID(context.Background(), testRoom.ID())
+		if stored.Answer() == nil || stored.Answer().String() != "ラーメン" {
+			t.Errorf("Expected the submitted answer to be kept, got: %v", stored.Answer())
+		}
+	})
+
+	t.Run("検索に失敗した場合はエラーが返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		f.roomRepo = &mockRoomRepository{
+			searchFunc: func(ctx context.Context, criteria room.SearchCriteria) ([]room.SearchResult, error) {
+				return nil, errors.New("database error")
+			},
+		}
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background())
+
+		// assert
+		if err == nil {
+			t.Error("Expected error when the room repository fails")
+		}
+	})
+}
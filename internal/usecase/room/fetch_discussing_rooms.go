@@ -0,0 +1,44 @@
+package room
+
+import (
+	"context"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+)
+
+// FetchDiscussingRoomsUseCase fetches the rooms whose discussion countdown may still be running
+type FetchDiscussingRoomsUseCase struct {
+	roomRepo room.Repository
+}
+
+// NewFetchDiscussingRoomsUseCase creates a new FetchDiscussingRoomsUseCase
+func NewFetchDiscussingRoomsUseCase(roomRepo room.Repository) *FetchDiscussingRoomsUseCase {
+	return &FetchDiscussingRoomsUseCase{
+		roomRepo: roomRepo,
+	}
+}
+
+// FetchDiscussingRoomsOutput represents output for fetching discussing rooms
+type FetchDiscussingRoomsOutput struct {
+	Rooms []*room.Room
+}
+
+// Execute fetches the rooms in discussing that have a scheduled deadline
+func (uc *FetchDiscussingRoomsUseCase) Execute(ctx context.Context) (*FetchDiscussingRoomsOutput, error) {
+	status := room.StatusDiscussing
+	results, err := uc.roomRepo.Search(ctx, room.SearchCriteria{Status: &status})
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := make([]*room.Room, 0, len(results))
+	for _, result := range results {
+		if result.Room.DiscussionDeadline() != nil {
+			scheduled = append(scheduled, result.Room)
+		}
+	}
+
+	return &FetchDiscussingRoomsOutput{
+		Rooms: scheduled,
+	}, nil
+}
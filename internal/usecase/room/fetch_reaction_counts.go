@@ -0,0 +1,73 @@
+package room
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room_emoji"
+)
+
+// FetchReactionCountsUseCase fetches the aggregated emoji reactions of a room
+type FetchReactionCountsUseCase struct {
+	roomRepo      room.Repository
+	roomEmojiRepo room_emoji.Repository
+}
+
+// NewFetchReactionCountsUseCase creates a new FetchReactionCountsUseCase
+func NewFetchReactionCountsUseCase(
+	roomRepo room.Repository,
+	roomEmojiRepo room_emoji.Repository,
+) *FetchReactionCountsUseCase {
+	return &FetchReactionCountsUseCase{
+		roomRepo:      roomRepo,
+		roomEmojiRepo: roomEmojiRepo,
+	}
+}
+
+// FetchReactionCountsInput represents input for fetching reaction counts
+type FetchReactionCountsInput struct {
+	RoomID string
+}
+
+// ReactionCountInfo represents how many times an emoji was sent
+type ReactionCountInfo struct {
+	Emoji string
+	Count int
+}
+
+// FetchReactionCountsOutput represents output for fetching reaction counts
+type FetchReactionCountsOutput struct {
+	Reactions []ReactionCountInfo
+	Total     int
+}
+
+// Execute fetches the reaction counts of a room, most frequent first
+func (uc *FetchReactionCountsUseCase) Execute(ctx context.Context, input FetchReactionCountsInput) (*FetchReactionCountsOutput, error) {
+	// Find room
+	roomID, err := room.NewRoomIDFromString(input.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.roomRepo.FindByID(ctx, roomID); err != nil {
+		return nil, errors.New("room not found")
+	}
+
+	emojiRoomID, _ := room_emoji.NewRoomIDFromString(input.RoomID)
+	counts, err := uc.roomEmojiRepo.CountByRoomID(ctx, emojiRoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &FetchReactionCountsOutput{Reactions: []ReactionCountInfo{}}
+	for _, c := range counts {
+		output.Reactions = append(output.Reactions, ReactionCountInfo{
+			Emoji: c.Emoji.String(),
+			Count: c.Count,
+		})
+		output.Total += c.Count
+	}
+
+	return output, nil
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/usecase/auditlog"
 )
 
 // FinishGameInput represents the input for finishing a game
@@ -20,6 +23,8 @@ type FinishGameUseCase struct {
 	roomRepo        room.Repository
 	participantRepo participant.Repository
 	eventPublisher  event.Publisher
+	auditRepo       audit.Repository
+	clock           clock.Clock
 }
 
 // NewFinishGameUseCase creates a new FinishGameUseCase
@@ -27,16 +32,28 @@ func NewFinishGameUseCase(
 	roomRepo room.Repository,
 	participantRepo participant.Repository,
 	eventPublisher event.Publisher,
+	auditRepo audit.Repository,
+	clk clock.Clock,
 ) *FinishGameUseCase {
 	return &FinishGameUseCase{
 		roomRepo:        roomRepo,
 		participantRepo: participantRepo,
 		eventPublisher:  eventPublisher,
+		auditRepo:       auditRepo,
+		clock:           clk,
 	}
 }
 
-// Execute finishes a game
+// Execute finishes a game.
+// When another writer saves the room first, it starts over from a fresh read.
 func (uc *FinishGameUseCase) Execute(ctx context.Context, input FinishGameInput) error {
+	return room.RetryOnConflict(func() error {
+		return uc.execute(ctx, input)
+	})
+}
+
+// execute applies the change to the room as currently stored
+func (uc *FinishGameUseCase) execute(ctx context.Context, input FinishGameInput) error {
 	// Find room
 	roomID, err := room.NewRoomIDFromString(input.RoomID)
 	if err != nil {
@@ -62,7 +79,8 @@ func (uc *FinishGameUseCase) Execute(ctx context.Context, input FinishGameInput)
 	}
 
 	// Change status to finished
-	if err := foundRoom.ChangeStatus(room.StatusFinished); err != nil {
+	beforeStatus := foundRoom.Status()
+	if err := foundRoom.ChangeStatus(room.StatusFinished, uc.clock.Now()); err != nil {
 		return err
 	}
 
@@ -71,8 +89,10 @@ func (uc *FinishGameUseCase) Execute(ctx context.Context, input FinishGameInput)
 		return err
 	}
 
+	auditlog.Record(ctx, uc.auditRepo, roomID.String(), audit.NewUserActor(input.UserID), audit.ActionFinishGame, beforeStatus.String(), foundRoom.Status().String(), uc.clock.Now())
+
 	// Publish GameFinishedEvent
-	uc.eventPublisher.Publish(event.NewGameFinishedEvent(input.RoomID))
+	uc.eventPublisher.Publish(event.NewGameFinishedEvent(input.RoomID, uc.clock.Now()))
 
 	return nil
 }
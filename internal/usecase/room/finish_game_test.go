@@ -5,85 +5,92 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
 	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
 )
 
 func TestFinishGameUseCaseExecute(t *testing.T) {
+	const hostUserID = "550e8400-e29b-41d4-a716-446655440001"
+
 	type fixture struct {
-		useCase         *roomUseCase.FinishGameUseCase
-		roomRepo        *mockRoomRepository
-		participantRepo *mockParticipantRepository
+		roomRepo        room.Repository
+		participantRepo participant.Repository
 		eventPublisher  *mockEventPublisher
+		auditRepo       audit.Repository
 	}
 
 	newFixture := func(t *testing.T) *fixture {
 		t.Helper()
 
-		roomRepo := &mockRoomRepository{}
-		participantRepo := &mockParticipantRepository{}
-		eventPublisher := &mockEventPublisher{}
-
-		useCase := roomUseCase.NewFinishGameUseCase(
-			roomRepo,
-			participantRepo,
-			eventPublisher,
-		)
-
+		store := persistence.NewMemoryStore()
 		return &fixture{
-			useCase:         useCase,
-			roomRepo:        roomRepo,
-			participantRepo: participantRepo,
-			eventPublisher:  eventPublisher,
+			roomRepo:        persistence.NewInMemoryRoomRepository(store),
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+			eventPublisher:  &mockEventPublisher{},
+			auditRepo:       persistence.NewInMemoryAuditRepository(store),
 		}
 	}
 
-	createTestRoom := func() *room.Room {
-		roomID := room.NewRoomID()
-		roomCode := room.NewRoomCode()
-		themeID, _ := room.NewThemeIDFromString("550e8400-e29b-41d4-a716-446655440000")
-		hostUserID, _ := room.NewHostUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
-		r := room.NewRoom(roomID, roomCode, themeID, hostUserID)
-		r.Start()
-		r.ChangeStatus(room.StatusDiscussing)
-		r.ChangeStatus(room.StatusAnswering)
-		r.ChangeStatus(room.StatusChecking)
-		return r
+	newUseCase := func(f *fixture) *roomUseCase.FinishGameUseCase {
+		return roomUseCase.NewFinishGameUseCase(
+			f.roomRepo,
+			f.participantRepo,
+			f.eventPublisher,
+			f.auditRepo,
+			infrastructureClock.NewFakeClock(testNow),
+		)
 	}
 
-	createHostParticipant := func(roomID, userID string) *participant.Participant {
+	createParticipant := func(roomID, userID string, role participant.ParticipantRole) *participant.Participant {
 		participantID := participant.NewParticipantID()
 		participantRoomID, _ := participant.NewRoomIDFromString(roomID)
 		participantUserID, _ := participant.NewUserIDFromString(userID)
-		return participant.NewParticipant(participantID, participantRoomID, participantUserID, participant.RoleHost)
+		return participant.NewParticipant(participantID, participantRoomID, participantUserID, role, testNow)
+	}
+
+	// saveCheckingRoom stores a room in the checking phase with its host
+	saveCheckingRoom := func(t *testing.T, f *fixture) *room.Room {
+		t.Helper()
+
+		roomID := room.NewRoomID()
+		roomCode := room.NewRoomCode()
+		themeID, _ := room.NewThemeIDFromString("550e8400-e29b-41d4-a716-446655440000")
+		host, _ := room.NewHostUserIDFromString(hostUserID)
+		r := room.NewRoom(roomID, roomCode, themeID, host, testNow)
+		r.Start(testNow)
+		r.ChangeStatus(room.StatusDiscussing, testNow)
+		r.ChangeStatus(room.StatusAnswering, testNow)
+		r.ChangeStatus(room.StatusChecking, testNow)
+		mustSave(t, f.roomRepo.Save, r)
+		mustSave(t, f.participantRepo.Save, createParticipant(r.ID().String(), hostUserID, participant.RoleHost))
+		return r
 	}
 
 	t.Run("正常にゲームが終了されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-		testParticipant := createHostParticipant(testRoom.ID().String(), "550e8400-e29b-41d4-a716-446655440001")
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
+		testRoom := saveCheckingRoom(t, f)
 
 		input := roomUseCase.FinishGameInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err != nil {
 			t.Fatalf("Expected no error, got: %v", err)
 		}
+		stored, _ := f.roomRepo.FindByID(context.Background(), testRoom.ID())
+		if stored.Status() != room.StatusFinished {
+			t.Errorf("Expected the room to be finished, got: %s", stored.Status())
+		}
 	})
 
 	t.Run("無効なRoomIDの場合はエラーが返されること", func(t *testing.T) {
@@ -92,11 +99,11 @@ func TestFinishGameUseCaseExecute(t *testing.T) {
 
 		input := roomUseCase.FinishGameInput{
 			RoomID: "invalid-uuid",
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -108,17 +115,13 @@ func TestFinishGameUseCaseExecute(t *testing.T) {
 		// arrange
 		f := newFixture(t)
 
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return nil, errors.New("not found")
-		}
-
 		input := roomUseCase.FinishGameInput{
 			RoomID: "550e8400-e29b-41d4-a716-446655440000",
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -129,27 +132,17 @@ func TestFinishGameUseCaseExecute(t *testing.T) {
 	t.Run("ホスト以外のユーザーがゲーム終了しようとした場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-
-		participantID := participant.NewParticipantID()
-		participantRoomID, _ := participant.NewRoomIDFromString(testRoom.ID().String())
-		participantUserID, _ := participant.NewUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
-		testParticipant := participant.NewParticipant(participantID, participantRoomID, participantUserID, participant.RolePlayer)
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
+		testRoom := saveCheckingRoom(t, f)
+		const playerUserID = "550e8400-e29b-41d4-a716-446655440002"
+		mustSave(t, f.participantRepo.Save, createParticipant(testRoom.ID().String(), playerUserID, participant.RolePlayer))
 
 		input := roomUseCase.FinishGameInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: playerUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -163,26 +156,22 @@ func TestFinishGameUseCaseExecute(t *testing.T) {
 	t.Run("Roomの保存に失敗した場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-		testParticipant := createHostParticipant(testRoom.ID().String(), "550e8400-e29b-41d4-a716-446655440001")
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
-		f.roomRepo.saveFunc = func(ctx context.Context, r *room.Room) error {
-			return errors.New("save error")
+		testRoom := saveCheckingRoom(t, f)
+		roomRepo := f.roomRepo
+		f.roomRepo = &mockRoomRepository{
+			findByIDFunc: roomRepo.FindByID,
+			saveFunc: func(ctx context.Context, r *room.Room) error {
+				return errors.New("save error")
+			},
 		}
 
 		input := roomUseCase.FinishGameInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
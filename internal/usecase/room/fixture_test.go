@@ -3,12 +3,18 @@ package room_test
 import (
 	"context"
 	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/theme"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
 )
 
 // Mock User Repository
@@ -44,6 +50,7 @@ type mockRoomRepository struct {
 	saveFunc       func(context.Context, *room.Room) error
 	findByIDFunc   func(context.Context, room.RoomID) (*room.Room, error)
 	findByCodeFunc func(context.Context, room.RoomCode) (*room.Room, error)
+	searchFunc     func(context.Context, room.SearchCriteria) ([]room.SearchResult, error)
 	deleteFunc     func(context.Context, room.RoomID) error
 }
 
@@ -68,6 +75,13 @@ func (m *mockRoomRepository) FindByCode(ctx context.Context, code room.RoomCode)
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockRoomRepository) Search(ctx context.Context, criteria room.SearchCriteria) ([]room.SearchResult, error) {
+	if m.searchFunc != nil {
+		return m.searchFunc(ctx, criteria)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockRoomRepository) Delete(ctx context.Context, id room.RoomID) error {
 	if m.deleteFunc != nil {
 		return m.deleteFunc(ctx, id)
@@ -172,3 +186,96 @@ func (m *mockEventPublisher) Subscribe(eventType string, handler event.EventHand
 		m.subscribeFunc(eventType, handler)
 	}
 }
+
+// Mock Moderator
+type mockModerator struct {
+	moderateFunc func(moderation.Field, string) (string, error)
+}
+
+func (m *mockModerator) Moderate(field moderation.Field, value string) (string, error) {
+	if m.moderateFunc != nil {
+		return m.moderateFunc(field, value)
+	}
+	return value, nil
+}
+
+// Mock Audit Repository
+type mockAuditRepository struct {
+	saveFunc         func(context.Context, *audit.AuditLog) error
+	findByRoomIDFunc func(context.Context, audit.RoomID) ([]*audit.AuditLog, error)
+}
+
+func (m *mockAuditRepository) Save(ctx context.Context, l *audit.AuditLog) error {
+	if m.saveFunc != nil {
+		return m.saveFunc(ctx, l)
+	}
+	return nil
+}
+
+func (m *mockAuditRepository) FindByRoomID(ctx context.Context, roomID audit.RoomID) ([]*audit.AuditLog, error) {
+	if m.findByRoomIDFunc != nil {
+		return m.findByRoomIDFunc(ctx, roomID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+// Mock Rate Limiter
+type mockRateLimiter struct {
+	allowFunc func(string) bool
+}
+
+func (m *mockRateLimiter) Allow(key string) bool {
+	if m.allowFunc != nil {
+		return m.allowFunc(key)
+	}
+	return true
+}
+
+// testNow is the fixed time used by the use cases and entities under test
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// racingRoomRepository is an in-memory room repository that runs beforeSave
+// before each save, e.g. to let another writer save the room first
+type racingRoomRepository struct {
+	*persistence.InMemoryRoomRepository
+	conflicts  atomic.Int32
+	beforeSave func()
+}
+
+// newRacingRoomRepository creates a racingRoomRepository holding the given rooms
+func newRacingRoomRepository(rooms ...*room.Room) *racingRoomRepository {
+	repo := &racingRoomRepository{
+		InMemoryRoomRepository: persistence.NewInMemoryRoomRepository(persistence.NewMemoryStore()),
+	}
+	for _, r := range rooms {
+		repo.InMemoryRoomRepository.Save(context.Background(), r)
+	}
+	return repo
+}
+
+func (r *racingRoomRepository) Save(ctx context.Context, rm *room.Room) error {
+	if r.beforeSave != nil {
+		r.beforeSave()
+	}
+
+	err := r.InMemoryRoomRepository.Save(ctx, rm)
+	if errors.Is(err, room.ErrVersionConflict) {
+		r.conflicts.Add(1)
+	}
+	return err
+}
+
+// saveAgain saves the stored room unchanged, as another writer would
+func (r *racingRoomRepository) saveAgain(id room.RoomID) {
+	stored, _ := r.InMemoryRoomRepository.FindByID(context.Background(), id)
+	r.InMemoryRoomRepository.Save(context.Background(), stored)
+}
+
+// mustSave stores an entity with an in-memory repository while arranging a test
+func mustSave[T any](t *testing.T, save func(context.Context, T) error, entity T) {
+	t.Helper()
+
+	if err := save(context.Background(), entity); err != nil {
+		t.Fatalf("Failed to save %T: %v", entity, err)
+	}
+}
@@ -0,0 +1,98 @@
+package room
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/ratelimit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room_emoji"
+)
+
+// SendReactionInput represents the input for sending an emoji reaction
+type SendReactionInput struct {
+	RoomID string
+	UserID string
+	Emoji  string
+}
+
+// SendReactionOutput represents the output for sending an emoji reaction
+type SendReactionOutput struct {
+	UserID string
+	Emoji  string
+}
+
+// SendReactionUseCase handles the logic for a participant reacting with an emoji
+type SendReactionUseCase struct {
+	roomRepo        room.Repository
+	participantRepo participant.Repository
+	roomEmojiRepo   room_emoji.Repository
+	rateLimiter     ratelimit.Limiter
+}
+
+// NewSendReactionUseCase creates a new SendReactionUseCase
+func NewSendReactionUseCase(
+	roomRepo room.Repository,
+	participantRepo participant.Repository,
+	roomEmojiRepo room_emoji.Repository,
+	rateLimiter ratelimit.Limiter,
+) *SendReactionUseCase {
+	return &SendReactionUseCase{
+		roomRepo:        roomRepo,
+		participantRepo: participantRepo,
+		roomEmojiRepo:   roomEmojiRepo,
+		rateLimiter:     rateLimiter,
+	}
+}
+
+// Execute records an emoji reaction
+func (uc *SendReactionUseCase) Execute(ctx context.Context, input SendReactionInput) (*SendReactionOutput, error) {
+	// Find room
+	roomID, err := room.NewRoomIDFromString(input.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	foundRoom, err := uc.roomRepo.FindByID(ctx, roomID)
+	if err != nil {
+		return nil, errors.New("room not found")
+	}
+
+	if foundRoom.Status() != room.StatusDiscussing && foundRoom.Status() != room.StatusChecking {
+		return nil, room_emoji.ErrReactionNotAllowed
+	}
+
+	// Verify user is a participant
+	participantRoomID, _ := participant.NewRoomIDFromString(input.RoomID)
+	participantUserID, err := participant.NewUserIDFromString(input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	foundParticipant, err := uc.participantRepo.FindByRoomAndUser(ctx, participantRoomID, participantUserID)
+	if err != nil {
+		return nil, errors.New("participant not found")
+	}
+
+	emoji, err := room_emoji.NewReactionEmoji(input.Emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	if !uc.rateLimiter.Allow(input.RoomID + ":" + input.UserID) {
+		return nil, room_emoji.ErrTooManyReactions
+	}
+
+	// Save reaction
+	emojiRoomID, _ := room_emoji.NewRoomIDFromString(input.RoomID)
+	emojiParticipantID, _ := room_emoji.NewParticipantIDFromString(foundParticipant.ID().String())
+	reaction := room_emoji.NewRoomEmoji(room_emoji.NewRoomEmojiID(), emojiRoomID, &emojiParticipantID, emoji)
+	if err := uc.roomEmojiRepo.Save(ctx, reaction); err != nil {
+		return nil, err
+	}
+
+	return &SendReactionOutput{
+		UserID: input.UserID,
+		Emoji:  emoji.String(),
+	}, nil
+}
@@ -0,0 +1,175 @@
+package room_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room_emoji"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
+)
+
+func TestSendReactionUseCaseExecute(t *testing.T) {
+	const testUserID = "550e8400-e29b-41d4-a716-446655440002"
+
+	type fixture struct {
+		roomRepo        room.Repository
+		participantRepo participant.Repository
+		roomEmojiRepo   room_emoji.Repository
+		rateLimiter     *mockRateLimiter
+		testRoom        *room.Room
+		testParticipant *participant.Participant
+	}
+
+	newFixture := func(t *testing.T, status room.RoomStatus) *fixture {
+		t.Helper()
+
+		roomID := room.NewRoomID()
+		themeID, _ := room.NewThemeIDFromString("550e8400-e29b-41d4-a716-446655440000")
+		hostUserID, _ := room.NewHostUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
+		testRoom := room.NewRoom(roomID, room.NewRoomCode(), themeID, hostUserID, testNow)
+		testRoom.ForceStatus(status, testNow)
+
+		participantRoomID, _ := participant.NewRoomIDFromString(roomID.String())
+		participantUserID, _ := participant.NewUserIDFromString(testUserID)
+		testParticipant := participant.NewParticipant(participant.NewParticipantID(), participantRoomID, participantUserID, participant.RolePlayer, testNow)
+
+		store := persistence.NewMemoryStore()
+		f := &fixture{
+			roomRepo:        persistence.NewInMemoryRoomRepository(store),
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+			roomEmojiRepo:   persistence.NewInMemoryRoomEmojiRepository(store),
+			rateLimiter:     &mockRateLimiter{},
+			testRoom:        testRoom,
+			testParticipant: testParticipant,
+		}
+		mustSave(t, f.roomRepo.Save, testRoom)
+		mustSave(t, f.participantRepo.Save, testParticipant)
+		return f
+	}
+
+	newUseCase := func(f *fixture) *roomUseCase.SendReactionUseCase {
+		return roomUseCase.NewSendReactionUseCase(
+			f.roomRepo,
+			f.participantRepo,
+			f.roomEmojiRepo,
+			f.rateLimiter,
+		)
+	}
+
+	// savedReactions reads the reactions stored for the room of the fixture
+	savedReactions := func(t *testing.T, f *fixture) []*room_emoji.RoomEmoji {
+		t.Helper()
+
+		roomID, _ := room_emoji.NewRoomIDFromString(f.testRoom.ID().String())
+		reactions, err := f.roomEmojiRepo.FindByRoomID(context.Background(), roomID)
+		if err != nil {
+			t.Fatalf("Failed to find reactions: %v", err)
+		}
+		return reactions
+	}
+
+	t.Run("ディスカッション中はリアクションが参加者と紐づけて保存されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, room.StatusDiscussing)
+
+		// act
+		output, err := newUseCase(f).Execute(context.Background(), roomUseCase.SendReactionInput{
+			RoomID: f.testRoom.ID().String(),
+			UserID: testUserID,
+			Emoji:  "👍",
+		})
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		saved := savedReactions(t, f)
+		if len(saved) != 1 {
+			t.Fatalf("Expected 1 reaction to be saved, got: %d", len(saved))
+		}
+		if saved[0].ParticipantID() == nil || saved[0].ParticipantID().String() != f.testParticipant.ID().String() {
+			t.Error("Expected reaction to reference the participant")
+		}
+		if output.Emoji != "👍" || output.UserID != testUserID {
+			t.Errorf("Unexpected output: %+v", output)
+		}
+	})
+
+	t.Run("確認中もリアクションできること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, room.StatusChecking)
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), roomUseCase.SendReactionInput{
+			RoomID: f.testRoom.ID().String(),
+			UserID: testUserID,
+			Emoji:  "👨‍👩‍👧",
+		})
+
+		// assert
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+	})
+
+	t.Run("ディスカッション・確認以外ではErrReactionNotAllowedが返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, room.StatusSettingTopic)
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), roomUseCase.SendReactionInput{
+			RoomID: f.testRoom.ID().String(),
+			UserID: testUserID,
+			Emoji:  "👍",
+		})
+
+		// assert
+		if !errors.Is(err, room_emoji.ErrReactionNotAllowed) {
+			t.Errorf("Expected ErrReactionNotAllowed, got: %v", err)
+		}
+	})
+
+	t.Run("絵文字以外はErrInvalidReactionが返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, room.StatusDiscussing)
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), roomUseCase.SendReactionInput{
+			RoomID: f.testRoom.ID().String(),
+			UserID: testUserID,
+			Emoji:  "hello",
+		})
+
+		// assert
+		if !errors.Is(err, room_emoji.ErrInvalidReaction) {
+			t.Errorf("Expected ErrInvalidReaction, got: %v", err)
+		}
+	})
+
+	t.Run("レート制限を超えた場合はErrTooManyReactionsが返され保存されないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t, room.StatusDiscussing)
+		f.rateLimiter.allowFunc = func(key string) bool {
+			return false
+		}
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), roomUseCase.SendReactionInput{
+			RoomID: f.testRoom.ID().String(),
+			UserID: testUserID,
+			Emoji:  "👍",
+		})
+
+		// assert
+		if !errors.Is(err, room_emoji.ErrTooManyReactions) {
+			t.Errorf("Expected ErrTooManyReactions, got: %v", err)
+		}
+		if saved := savedReactions(t, f); len(saved) != 0 {
+			t.Errorf("Expected reaction not to be saved, got: %d", len(saved))
+		}
+	})
+}
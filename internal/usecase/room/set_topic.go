@@ -2,12 +2,15 @@ package room
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/usecase/auditlog"
 )
 
 // SetTopicInput represents the input for setting a topic
@@ -26,21 +29,38 @@ type SetTopicInput struct {
 type SetTopicUseCase struct {
 	roomRepo        room.Repository
 	participantRepo participant.Repository
+	moderator       moderation.Moderator
+	auditRepo       audit.Repository
+	clock           clock.Clock
 }
 
 // NewSetTopicUseCase creates a new SetTopicUseCase
 func NewSetTopicUseCase(
 	roomRepo room.Repository,
 	participantRepo participant.Repository,
+	moderator moderation.Moderator,
+	auditRepo audit.Repository,
+	clk clock.Clock,
 ) *SetTopicUseCase {
 	return &SetTopicUseCase{
 		roomRepo:        roomRepo,
 		participantRepo: participantRepo,
+		moderator:       moderator,
+		auditRepo:       auditRepo,
+		clock:           clk,
 	}
 }
 
-// Execute sets a topic for the room
+// Execute sets a topic for the room.
+// When another writer saves the room first, it starts over from a fresh read.
 func (uc *SetTopicUseCase) Execute(ctx context.Context, input SetTopicInput) error {
+	return room.RetryOnConflict(func() error {
+		return uc.execute(ctx, input)
+	})
+}
+
+// execute applies the change to the room as currently stored
+func (uc *SetTopicUseCase) execute(ctx context.Context, input SetTopicInput) error {
 	// Find room
 	roomID, err := room.NewRoomIDFromString(input.RoomID)
 	if err != nil {
@@ -66,17 +86,18 @@ func (uc *SetTopicUseCase) Execute(ctx context.Context, input SetTopicInput) err
 	}
 
 	// Set topic
-	fmt.Printf("[SetTopic] Received topic from input: '%s'\n", input.Topic)
-	topic, err := room.NewTopic(input.Topic)
+	beforeStatus := foundRoom.Status()
+	moderatedTopic, err := uc.moderator.Moderate(moderation.FieldTopic, input.Topic)
+	if err != nil {
+		return err
+	}
+	topic, err := room.NewTopic(moderatedTopic)
 	if err != nil {
-		fmt.Printf("[SetTopic] Failed to create topic: %v\n", err)
 		return err
 	}
 	if err := foundRoom.SetTopic(topic); err != nil {
-		fmt.Printf("[SetTopic] Failed to set topic: %v\n", err)
 		return err
 	}
-	fmt.Printf("[SetTopic] Successfully set topic: '%s'\n", topic.String())
 
 	// Set game data if provided (dummy emoji information)
 	if len(input.DisplayedEmojis) > 0 && len(input.OriginalEmojis) > 0 {
@@ -101,58 +122,23 @@ func (uc *SetTopicUseCase) Execute(ctx context.Context, input SetTopicInput) err
 			return fmt.Errorf("failed to fetch participants: %w", err)
 		}
 
-		// Filter out host and build assignments
-		type Assignment struct {
-			UserID string `json:"user_id"`
-			Emoji  string `json:"emoji"`
-		}
-
-		assignments := []Assignment{}
-		emojiIndex := 0
-		for _, p := range participants {
-			if p.Role() != participant.RoleHost {
-				if emojiIndex < len(input.DisplayedEmojis) {
-					assignments = append(assignments, Assignment{
-						UserID: p.UserID().String(),
-						Emoji:  input.DisplayedEmojis[emojiIndex],
-					})
-					emojiIndex++
-				}
-			}
-		}
-
-		// Convert assignments to JSON string array
-		assignmentsJSON := []string{}
-		for _, assignment := range assignments {
-			jsonBytes, _ := json.Marshal(assignment)
-			assignmentsJSON = append(assignmentsJSON, string(jsonBytes))
-		}
-
-		fmt.Printf("[SetTopic] Generated %d assignments: %v\n", len(assignmentsJSON), assignmentsJSON)
+		assignmentsJSON := assignEmojis(participants, input.DisplayedEmojis)
 
 		// Set assignments
 		foundRoom.SetAssignments(room.NewAssignments(assignmentsJSON))
 
 		// Change status to discussing
-		fmt.Printf("[SetTopic] Current status: %s, attempting to change to discussing\n", foundRoom.Status().String())
-		if err := foundRoom.ChangeStatus(room.StatusDiscussing); err != nil {
-			fmt.Printf("[SetTopic] Failed to change status: %v\n", err)
+		if err := foundRoom.ChangeStatus(room.StatusDiscussing, uc.clock.Now()); err != nil {
 			return err
 		}
-		fmt.Printf("[SetTopic] Successfully changed status to discussing\n")
 	}
 
 	// Save room
-	topicBeforeSave := ""
-	if foundRoom.Topic() != nil {
-		topicBeforeSave = foundRoom.Topic().String()
-	}
-	fmt.Printf("[SetTopic] About to save room with status: %s, topic: '%s'\n", foundRoom.Status().String(), topicBeforeSave)
 	if err := uc.roomRepo.Save(ctx, foundRoom); err != nil {
-		fmt.Printf("[SetTopic] Save failed: %v\n", err)
 		return err
 	}
-	fmt.Printf("[SetTopic] Room saved successfully\n")
+
+	auditlog.Record(ctx, uc.auditRepo, roomID.String(), audit.NewUserActor(input.UserID), audit.ActionSetTopic, beforeStatus.String(), foundRoom.Status().String(), uc.clock.Now())
 
 	return nil
 }
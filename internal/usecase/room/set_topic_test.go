@@ -5,80 +5,97 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
 	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
 )
 
 func TestSetTopicUseCaseExecute(t *testing.T) {
+	const hostUserID = "550e8400-e29b-41d4-a716-446655440001"
+
 	type fixture struct {
-		useCase         *roomUseCase.SetTopicUseCase
-		roomRepo        *mockRoomRepository
-		participantRepo *mockParticipantRepository
+		roomRepo        room.Repository
+		participantRepo participant.Repository
+		moderator       *mockModerator
+		auditRepo       audit.Repository
 	}
 
 	newFixture := func(t *testing.T) *fixture {
 		t.Helper()
 
-		roomRepo := &mockRoomRepository{}
-		participantRepo := &mockParticipantRepository{}
-
-		useCase := roomUseCase.NewSetTopicUseCase(
-			roomRepo,
-			participantRepo,
-		)
-
+		store := persistence.NewMemoryStore()
 		return &fixture{
-			useCase:         useCase,
-			roomRepo:        roomRepo,
-			participantRepo: participantRepo,
+			roomRepo:        persistence.NewInMemoryRoomRepository(store),
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+			moderator:       &mockModerator{},
+			auditRepo:       persistence.NewInMemoryAuditRepository(store),
 		}
 	}
 
+	newUseCase := func(f *fixture) *roomUseCase.SetTopicUseCase {
+		return roomUseCase.NewSetTopicUseCase(
+			f.roomRepo,
+			f.participantRepo,
+			f.moderator,
+			f.auditRepo,
+			infrastructureClock.NewFakeClock(testNow),
+		)
+	}
+
 	createTestRoom := func() *room.Room {
 		roomID := room.NewRoomID()
 		roomCode := room.NewRoomCode()
 		themeID, _ := room.NewThemeIDFromString("550e8400-e29b-41d4-a716-446655440000")
-		hostUserID, _ := room.NewHostUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
-		r := room.NewRoom(roomID, roomCode, themeID, hostUserID)
-		r.Start() // Set status to setting_topic
+		host, _ := room.NewHostUserIDFromString(hostUserID)
+		r := room.NewRoom(roomID, roomCode, themeID, host, testNow)
+		r.Start(testNow) // Set status to setting_topic
 		return r
 	}
 
-	createHostParticipant := func(roomID, userID string) *participant.Participant {
+	createParticipant := func(roomID, userID string, role participant.ParticipantRole) *participant.Participant {
 		participantID := participant.NewParticipantID()
 		participantRoomID, _ := participant.NewRoomIDFromString(roomID)
 		participantUserID, _ := participant.NewUserIDFromString(userID)
-		return participant.NewParticipant(participantID, participantRoomID, participantUserID, participant.RoleHost)
+		return participant.NewParticipant(participantID, participantRoomID, participantUserID, role, testNow)
+	}
+
+	// saveTestRoom stores a room setting its topic with its host
+	saveTestRoom := func(t *testing.T, f *fixture) *room.Room {
+		t.Helper()
+
+		testRoom := createTestRoom()
+		mustSave(t, f.roomRepo.Save, testRoom)
+		mustSave(t, f.participantRepo.Save, createParticipant(testRoom.ID().String(), hostUserID, participant.RoleHost))
+		return testRoom
 	}
 
 	t.Run("正常にトピックが設定されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-		testParticipant := createHostParticipant(testRoom.ID().String(), "550e8400-e29b-41d4-a716-446655440001")
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
+		testRoom := saveTestRoom(t, f)
 
 		input := roomUseCase.SetTopicInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 			Topic:  "Test Topic",
 			Emojis: []string{"😀", "😁", "😂"},
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err != nil {
 			t.Fatalf("Expected no error, got: %v", err)
 		}
+		stored, _ := f.roomRepo.FindByID(context.Background(), testRoom.ID())
+		if stored.Topic() == nil || stored.Topic().String() != "Test Topic" {
+			t.Errorf("Expected the topic to be saved, got: %v", stored.Topic())
+		}
 	})
 
 	t.Run("無効なRoomIDの場合はエラーが返されること", func(t *testing.T) {
@@ -87,13 +104,13 @@ func TestSetTopicUseCaseExecute(t *testing.T) {
 
 		input := roomUseCase.SetTopicInput{
 			RoomID: "invalid-uuid",
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 			Topic:  "Test Topic",
 			Emojis: []string{"😀"},
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -105,19 +122,15 @@ func TestSetTopicUseCaseExecute(t *testing.T) {
 		// arrange
 		f := newFixture(t)
 
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return nil, errors.New("not found")
-		}
-
 		input := roomUseCase.SetTopicInput{
 			RoomID: "550e8400-e29b-41d4-a716-446655440000",
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 			Topic:  "Test Topic",
 			Emojis: []string{"😀"},
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -128,29 +141,19 @@ func TestSetTopicUseCaseExecute(t *testing.T) {
 	t.Run("ホスト以外のユーザーがトピック設定しようとした場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-
-		participantID := participant.NewParticipantID()
-		participantRoomID, _ := participant.NewRoomIDFromString(testRoom.ID().String())
-		participantUserID, _ := participant.NewUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
-		testParticipant := participant.NewParticipant(participantID, participantRoomID, participantUserID, participant.RolePlayer)
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
+		testRoom := saveTestRoom(t, f)
+		const playerUserID = "550e8400-e29b-41d4-a716-446655440002"
+		mustSave(t, f.participantRepo.Save, createParticipant(testRoom.ID().String(), playerUserID, participant.RolePlayer))
 
 		input := roomUseCase.SetTopicInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: playerUserID,
 			Topic:  "Test Topic",
 			Emojis: []string{"😀"},
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -164,32 +167,126 @@ func TestSetTopicUseCaseExecute(t *testing.T) {
 	t.Run("Roomの保存に失敗した場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-		testParticipant := createHostParticipant(testRoom.ID().String(), "550e8400-e29b-41d4-a716-446655440001")
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
-		f.roomRepo.saveFunc = func(ctx context.Context, r *room.Room) error {
-			return errors.New("save error")
+		testRoom := saveTestRoom(t, f)
+		roomRepo := f.roomRepo
+		f.roomRepo = &mockRoomRepository{
+			findByIDFunc: roomRepo.FindByID,
+			saveFunc: func(ctx context.Context, r *room.Room) error {
+				return errors.New("save error")
+			},
 		}
 
 		input := roomUseCase.SetTopicInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 			Topic:  "Test Topic",
 			Emojis: []string{"😀"},
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
 			t.Error("Expected error when room save fails")
 		}
 	})
+
+	t.Run("不適切なトピックの場合はエラーが返され保存されないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := saveTestRoom(t, f)
+		f.moderator.moderateFunc = func(field moderation.Field, value string) (string, error) {
+			return "", moderation.ErrInappropriateContent
+		}
+
+		input := roomUseCase.SetTopicInput{
+			RoomID: testRoom.ID().String(),
+			UserID: hostUserID,
+			Topic:  "NG Topic",
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if !errors.Is(err, moderation.ErrInappropriateContent) {
+			t.Errorf("Expected ErrInappropriateContent, got: %v", err)
+		}
+		stored, _ := f.roomRepo.FindByID(context.Background(), testRoom.ID())
+		if stored.Version() != testRoom.Version() || stored.Topic() != nil {
+			t.Error("Expected room not to be saved")
+		}
+	})
+
+	t.Run("議論に進むと議論の締め切りが設定されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := saveTestRoom(t, f)
+
+		input := roomUseCase.SetTopicInput{
+			RoomID:          testRoom.ID().String(),
+			UserID:          hostUserID,
+			Topic:           "Test Topic",
+			DisplayedEmojis: []string{"😀", "😁"},
+			OriginalEmojis:  []string{"😀", "😂"},
+			DummyIndex:      1,
+			DummyEmoji:      "😁",
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		stored, _ := f.roomRepo.FindByID(context.Background(), testRoom.ID())
+		deadline := stored.DiscussionDeadline()
+		if deadline == nil {
+			t.Fatal("Expected the discussion deadline to be set")
+		}
+		if got := deadline.EndsAt().Sub(deadline.StartsAt()); got != room.DiscussionDuration {
+			t.Errorf("Expected the countdown to last %v, got: %v", room.DiscussionDuration, got)
+		}
+		if want := testNow.Add(room.DiscussionStartDelay); !deadline.StartsAt().Equal(want) {
+			t.Errorf("Expected the countdown to start at %v, got: %v", want, deadline.StartsAt())
+		}
+	})
+
+	t.Run("保存が競合した場合は最新のRoomを読み直してトピックが設定されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := createTestRoom()
+		mustSave(t, f.participantRepo.Save, createParticipant(testRoom.ID().String(), hostUserID, participant.RoleHost))
+		roomRepo := newRacingRoomRepository(testRoom)
+		roomRepo.beforeSave = func() {
+			// Another writer saves the room once between the read and the save
+			roomRepo.beforeSave = nil
+			roomRepo.saveAgain(testRoom.ID())
+		}
+		f.roomRepo = roomRepo
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), roomUseCase.SetTopicInput{
+			RoomID: testRoom.ID().String(),
+			UserID: hostUserID,
+			Topic:  "Test Topic",
+		})
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if got := roomRepo.conflicts.Load(); got != 1 {
+			t.Errorf("Expected 1 conflict, got: %d", got)
+		}
+		stored, _ := roomRepo.FindByID(context.Background(), testRoom.ID())
+		if stored.Topic() == nil || stored.Topic().String() != "Test Topic" {
+			t.Errorf("Expected the topic to be saved, got: %v", stored.Topic())
+		}
+		if stored.Version() != 3 {
+			t.Errorf("Expected the version to advance past the other writer, got: %d", stored.Version())
+		}
+	})
 }
@@ -3,11 +3,13 @@ package room
 import (
 	"context"
 	"errors"
-	"fmt"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/usecase/auditlog"
 )
 
 // SkipDiscussionInput represents the input for skipping discussion
@@ -21,6 +23,8 @@ type SkipDiscussionUseCase struct {
 	roomRepo        room.Repository
 	participantRepo participant.Repository
 	eventPublisher  event.Publisher
+	auditRepo       audit.Repository
+	clock           clock.Clock
 }
 
 // NewSkipDiscussionUseCase creates a new SkipDiscussionUseCase
@@ -28,16 +32,28 @@ func NewSkipDiscussionUseCase(
 	roomRepo room.Repository,
 	participantRepo participant.Repository,
 	eventPublisher event.Publisher,
+	auditRepo audit.Repository,
+	clk clock.Clock,
 ) *SkipDiscussionUseCase {
 	return &SkipDiscussionUseCase{
 		roomRepo:        roomRepo,
 		participantRepo: participantRepo,
 		eventPublisher:  eventPublisher,
+		auditRepo:       auditRepo,
+		clock:           clk,
 	}
 }
 
-// Execute skips discussion and moves to answering phase
+// Execute skips discussion and moves to answering phase.
+// When another writer saves the room first, it starts over from a fresh read.
 func (uc *SkipDiscussionUseCase) Execute(ctx context.Context, input SkipDiscussionInput) error {
+	return room.RetryOnConflict(func() error {
+		return uc.execute(ctx, input)
+	})
+}
+
+// execute applies the change to the room as currently stored
+func (uc *SkipDiscussionUseCase) execute(ctx context.Context, input SkipDiscussionInput) error {
 	// Find room
 	roomID, err := room.NewRoomIDFromString(input.RoomID)
 	if err != nil {
@@ -49,8 +65,6 @@ func (uc *SkipDiscussionUseCase) Execute(ctx context.Context, input SkipDiscussi
 		return errors.New("room not found")
 	}
 
-	fmt.Printf("[SkipDiscussion] Current room status: %s, RoomID: %s\n", foundRoom.Status().String(), input.RoomID)
-
 	// Verify user is host
 	participantRoomID, _ := participant.NewRoomIDFromString(input.RoomID)
 	participantUserID, _ := participant.NewUserIDFromString(input.UserID)
@@ -71,7 +85,8 @@ func (uc *SkipDiscussionUseCase) Execute(ctx context.Context, input SkipDiscussi
 	}
 
 	// Change status to answering
-	if err := foundRoom.ChangeStatus(room.StatusAnswering); err != nil {
+	beforeStatus := foundRoom.Status()
+	if err := foundRoom.ChangeStatus(room.StatusAnswering, uc.clock.Now()); err != nil {
 		return err
 	}
 
@@ -80,8 +95,10 @@ func (uc *SkipDiscussionUseCase) Execute(ctx context.Context, input SkipDiscussi
 		return err
 	}
 
+	auditlog.Record(ctx, uc.auditRepo, roomID.String(), audit.NewUserActor(input.UserID), audit.ActionSkipDiscussion, beforeStatus.String(), foundRoom.Status().String(), uc.clock.Now())
+
 	// Publish DiscussionSkippedEvent
-	uc.eventPublisher.Publish(event.NewDiscussionSkippedEvent(input.RoomID))
+	uc.eventPublisher.Publish(event.NewDiscussionSkippedEvent(input.RoomID, uc.clock.Now()))
 
 	return nil
 }
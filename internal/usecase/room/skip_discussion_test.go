@@ -5,91 +5,107 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
 	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
 )
 
 func TestSkipDiscussionUseCaseExecute(t *testing.T) {
+	const hostUserID = "550e8400-e29b-41d4-a716-446655440001"
+
 	type fixture struct {
-		useCase         *roomUseCase.SkipDiscussionUseCase
-		roomRepo        *mockRoomRepository
-		participantRepo *mockParticipantRepository
+		roomRepo        room.Repository
+		participantRepo participant.Repository
 		eventPublisher  *mockEventPublisher
+		auditRepo       audit.Repository
 	}
 
 	newFixture := func(t *testing.T) *fixture {
 		t.Helper()
 
-		roomRepo := &mockRoomRepository{}
-		participantRepo := &mockParticipantRepository{}
-		eventPublisher := &mockEventPublisher{}
-
-		useCase := roomUseCase.NewSkipDiscussionUseCase(
-			roomRepo,
-			participantRepo,
-			eventPublisher,
-		)
-
+		store := persistence.NewMemoryStore()
 		return &fixture{
-			useCase:         useCase,
-			roomRepo:        roomRepo,
-			participantRepo: participantRepo,
-			eventPublisher:  eventPublisher,
+			roomRepo:        persistence.NewInMemoryRoomRepository(store),
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+			eventPublisher:  &mockEventPublisher{},
+			auditRepo:       persistence.NewInMemoryAuditRepository(store),
 		}
 	}
 
-	createTestRoomWithDummyData := func() *room.Room {
+	newUseCase := func(f *fixture) *roomUseCase.SkipDiscussionUseCase {
+		return roomUseCase.NewSkipDiscussionUseCase(
+			f.roomRepo,
+			f.participantRepo,
+			f.eventPublisher,
+			f.auditRepo,
+			infrastructureClock.NewFakeClock(testNow),
+		)
+	}
+
+	createDiscussingRoom := func() *room.Room {
 		roomID := room.NewRoomID()
 		roomCode := room.NewRoomCode()
 		themeID, _ := room.NewThemeIDFromString("550e8400-e29b-41d4-a716-446655440000")
-		hostUserID, _ := room.NewHostUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
-		r := room.NewRoom(roomID, roomCode, themeID, hostUserID)
-		r.Start()
-		r.ChangeStatus(room.StatusDiscussing)
-
-		// Set dummy data
-		origEmojis := room.NewEmojiList([]string{"😀", "😁", "😂"})
-		dispEmojis := room.NewEmojiList([]string{"😀", "😁", "😂", "😃"})
-		dummyIdx, _ := room.NewDummyIndex(3)
-		dummyEmoji, _ := room.NewDummyEmoji("😃")
-		r.SetGameData(origEmojis, dispEmojis, dummyIdx, dummyEmoji)
-
+		host, _ := room.NewHostUserIDFromString(hostUserID)
+		r := room.NewRoom(roomID, roomCode, themeID, host, testNow)
+		r.Start(testNow)
+		r.ChangeStatus(room.StatusDiscussing, testNow)
 		return r
 	}
 
-	createHostParticipant := func(roomID, userID string) *participant.Participant {
+	createParticipant := func(roomID, userID string, role participant.ParticipantRole) *participant.Participant {
 		participantID := participant.NewParticipantID()
 		participantRoomID, _ := participant.NewRoomIDFromString(roomID)
 		participantUserID, _ := participant.NewUserIDFromString(userID)
-		return participant.NewParticipant(participantID, participantRoomID, participantUserID, participant.RoleHost)
+		return participant.NewParticipant(participantID, participantRoomID, participantUserID, role, testNow)
+	}
+
+	// saveRoom stores a discussing room with its host
+	saveRoom := func(t *testing.T, f *fixture, r *room.Room) *room.Room {
+		t.Helper()
+
+		mustSave(t, f.roomRepo.Save, r)
+		mustSave(t, f.participantRepo.Save, createParticipant(r.ID().String(), hostUserID, participant.RoleHost))
+		return r
+	}
+
+	// saveRoomWithDummyData stores a discussing room with its game data and host
+	saveRoomWithDummyData := func(t *testing.T, f *fixture) *room.Room {
+		t.Helper()
+
+		r := createDiscussingRoom()
+		origEmojis := room.NewEmojiList([]string{"😀", "😁", "😂"})
+		dispEmojis := room.NewEmojiList([]string{"😀", "😁", "😂", "😃"})
+		dummyIdx, _ := room.NewDummyIndex(3)
+		dummyEmoji, _ := room.NewDummyEmoji("😃")
+		r.SetGameData(origEmojis, dispEmojis, dummyIdx, dummyEmoji)
+		return saveRoom(t, f, r)
 	}
 
 	t.Run("正常にディスカッションがスキップされること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoomWithDummyData()
-		testParticipant := createHostParticipant(testRoom.ID().String(), "550e8400-e29b-41d4-a716-446655440001")
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
+		testRoom := saveRoomWithDummyData(t, f)
 
 		input := roomUseCase.SkipDiscussionInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err != nil {
 			t.Fatalf("Expected no error, got: %v", err)
 		}
+		stored, _ := f.roomRepo.FindByID(context.Background(), testRoom.ID())
+		if stored.Status() != room.StatusAnswering {
+			t.Errorf("Expected the room to be answering, got: %s", stored.Status())
+		}
 	})
 
 	t.Run("無効なRoomIDの場合はエラーが返されること", func(t *testing.T) {
@@ -98,11 +114,11 @@ func TestSkipDiscussionUseCaseExecute(t *testing.T) {
 
 		input := roomUseCase.SkipDiscussionInput{
 			RoomID: "invalid-uuid",
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -114,17 +130,13 @@ func TestSkipDiscussionUseCaseExecute(t *testing.T) {
 		// arrange
 		f := newFixture(t)
 
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return nil, errors.New("not found")
-		}
-
 		input := roomUseCase.SkipDiscussionInput{
 			RoomID: "550e8400-e29b-41d4-a716-446655440000",
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -135,27 +147,17 @@ func TestSkipDiscussionUseCaseExecute(t *testing.T) {
 	t.Run("ホスト以外のユーザーがスキップしようとした場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoomWithDummyData()
-
-		participantID := participant.NewParticipantID()
-		participantRoomID, _ := participant.NewRoomIDFromString(testRoom.ID().String())
-		participantUserID, _ := participant.NewUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
-		testParticipant := participant.NewParticipant(participantID, participantRoomID, participantUserID, participant.RolePlayer)
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
+		testRoom := saveRoomWithDummyData(t, f)
+		const playerUserID = "550e8400-e29b-41d4-a716-446655440002"
+		mustSave(t, f.participantRepo.Save, createParticipant(testRoom.ID().String(), playerUserID, participant.RolePlayer))
 
 		input := roomUseCase.SkipDiscussionInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: playerUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -169,31 +171,15 @@ func TestSkipDiscussionUseCaseExecute(t *testing.T) {
 	t.Run("ダミーデータが設定されていない場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		roomID := room.NewRoomID()
-		roomCode := room.NewRoomCode()
-		themeID, _ := room.NewThemeIDFromString("550e8400-e29b-41d4-a716-446655440000")
-		hostUserID, _ := room.NewHostUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
-		testRoom := room.NewRoom(roomID, roomCode, themeID, hostUserID)
-		testRoom.Start()
-		testRoom.ChangeStatus(room.StatusDiscussing)
-		// Dummy data not set
-
-		testParticipant := createHostParticipant(testRoom.ID().String(), "550e8400-e29b-41d4-a716-446655440001")
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
+		testRoom := saveRoom(t, f, createDiscussingRoom())
 
 		input := roomUseCase.SkipDiscussionInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -206,26 +192,22 @@ func TestSkipDiscussionUseCaseExecute(t *testing.T) {
 	t.Run("Roomの保存に失敗した場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoomWithDummyData()
-		testParticipant := createHostParticipant(testRoom.ID().String(), "550e8400-e29b-41d4-a716-446655440001")
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
-		f.roomRepo.saveFunc = func(ctx context.Context, r *room.Room) error {
-			return errors.New("save error")
+		testRoom := saveRoomWithDummyData(t, f)
+		roomRepo := f.roomRepo
+		f.roomRepo = &mockRoomRepository{
+			findByIDFunc: roomRepo.FindByID,
+			saveFunc: func(ctx context.Context, r *room.Room) error {
+				return errors.New("save error")
+			},
 		}
 
 		input := roomUseCase.SkipDiscussionInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -2,31 +2,38 @@ package room
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/usecase/auditlog"
 )
 
 // StartDiscussionUseCase starts the discussion phase by setting game data and changing room status
 type StartDiscussionUseCase struct {
 	roomRepo        room.Repository
 	participantRepo participant.Repository
+	auditRepo       audit.Repository
+	clock           clock.Clock
 }
 
 // NewStartDiscussionUseCase creates a new StartDiscussionUseCase
-func NewStartDiscussionUseCase(roomRepo room.Repository, participantRepo participant.Repository) *StartDiscussionUseCase {
+func NewStartDiscussionUseCase(roomRepo room.Repository, participantRepo participant.Repository, auditRepo audit.Repository, clk clock.Clock) *StartDiscussionUseCase {
 	return &StartDiscussionUseCase{
 		roomRepo:        roomRepo,
 		participantRepo: participantRepo,
+		auditRepo:       auditRepo,
+		clock:           clk,
 	}
 }
 
 // StartDiscussionInput represents input for starting discussion
 type StartDiscussionInput struct {
 	RoomID          string
+	UserID          string
 	OriginalEmojis  []string
 	DisplayedEmojis []string
 	DummyIndex      int
@@ -46,12 +53,6 @@ func (uc *StartDiscussionUseCase) Execute(ctx context.Context, input StartDiscus
 		return err
 	}
 
-	// Find room
-	foundRoom, err := uc.roomRepo.FindByID(ctx, roomID)
-	if err != nil {
-		return errors.New("room not found")
-	}
-
 	// Validate and create game data
 	originalEmojis := room.NewEmojiList(input.OriginalEmojis)
 	displayedEmojis := room.NewEmojiList(input.DisplayedEmojis)
@@ -66,19 +67,6 @@ func (uc *StartDiscussionUseCase) Execute(ctx context.Context, input StartDiscus
 		return err
 	}
 
-	// Set game data
-	fmt.Printf("[StartDiscussion] Setting game data\n")
-	if err := foundRoom.SetGameData(
-		originalEmojis,
-		displayedEmojis,
-		dummyIndex,
-		dummyEmoji,
-	); err != nil {
-		fmt.Printf("[StartDiscussion] Failed to set game data: %v\n", err)
-		return err
-	}
-	fmt.Printf("[StartDiscussion] Game data set successfully\n")
-
 	// Generate emoji assignments for players (excluding host)
 	participantRoomID, _ := participant.NewRoomIDFromString(input.RoomID)
 	participants, err := uc.participantRepo.FindByRoomID(ctx, participantRoomID)
@@ -86,72 +74,38 @@ func (uc *StartDiscussionUseCase) Execute(ctx context.Context, input StartDiscus
 		return fmt.Errorf("failed to fetch participants: %w", err)
 	}
 
-	// Filter out host and build assignments
-	type Assignment struct {
-		UserID string `json:"user_id"`
-		Emoji  string `json:"emoji"`
-	}
-
-	assignments := []Assignment{}
-	emojiIndex := 0
-	for _, p := range participants {
-		if p.Role() != participant.RoleHost {
-			if emojiIndex < len(input.DisplayedEmojis) {
-				assignments = append(assignments, Assignment{
-					UserID: p.UserID().String(),
-					Emoji:  input.DisplayedEmojis[emojiIndex],
-				})
-				emojiIndex++
-			}
-		}
-	}
-
-	// Convert assignments to JSON string array
-	assignmentsJSON := []string{}
-	for _, assignment := range assignments {
-		jsonBytes, _ := json.Marshal(assignment)
-		assignmentsJSON = append(assignmentsJSON, string(jsonBytes))
-	}
-
-	fmt.Printf("[StartDiscussion] Generated %d assignments: %v\n", len(assignmentsJSON), assignmentsJSON)
-
-	// Set assignments
-	fmt.Printf("[StartDiscussion] Setting assignments\n")
-	if err := foundRoom.SetAssignments(room.NewAssignments(assignmentsJSON)); err != nil {
-		fmt.Printf("[StartDiscussion] Failed to set assignments: %v\n", err)
-		return err
-	}
-	fmt.Printf("[StartDiscussion] Assignments set successfully\n")
+	assignmentsJSON := assignEmojis(participants, input.DisplayedEmojis)
 
 	// Note: Status change to 'discussing' is already handled by SetTopicUseCase (HTTP endpoint)
-	// This WebSocket handler only sets game data and assignments
+	// This WebSocket handler only sets game data and assignments.
 	//
-	// IMPORTANT: Fetch the latest room data again to avoid overwriting topic set by SetTopicUseCase
-	// This is necessary because SetTopicUseCase (HTTP) and StartDiscussionUseCase (WebSocket)
-	// are called almost simultaneously, causing a race condition.
-	fmt.Printf("[StartDiscussion] Re-fetching room to get latest data (including topic)\n")
-	latestRoom, err := uc.roomRepo.FindByID(ctx, roomID)
-	if err != nil {
-		fmt.Printf("[StartDiscussion] Failed to re-fetch room: %v\n", err)
-		return errors.New("failed to re-fetch room")
-	}
-
-	topicStr := ""
-	if latestRoom.Topic() != nil {
-		topicStr = latestRoom.Topic().String()
-	}
-	fmt.Printf("[StartDiscussion] Re-fetched room topic: '%s', status: %s\n", topicStr, latestRoom.Status().String())
+	// SetTopicUseCase (HTTP) and StartDiscussionUseCase (WebSocket) are called almost
+	// simultaneously. Saving fails with a version conflict when the other one saved the
+	// room in between, and the game data is then applied again to the latest room.
+	var latestRoom *room.Room
+	var beforeStatus room.RoomStatus
+	err = room.RetryOnConflict(func() error {
+		var err error
+		latestRoom, err = uc.roomRepo.FindByID(ctx, roomID)
+		if err != nil {
+			return errors.New("room not found")
+		}
+		beforeStatus = latestRoom.Status()
 
-	// Re-apply game data and assignments to the latest room
-	latestRoom.SetGameData(originalEmojis, displayedEmojis, dummyIndex, dummyEmoji)
-	latestRoom.SetAssignments(room.NewAssignments(assignmentsJSON))
+		if err := latestRoom.SetGameData(originalEmojis, displayedEmojis, dummyIndex, dummyEmoji); err != nil {
+			return err
+		}
+		if err := latestRoom.SetAssignments(room.NewAssignments(assignmentsJSON)); err != nil {
+			return err
+		}
 
-	fmt.Printf("[StartDiscussion] Saving room with game data and assignments. Current status: %s\n", latestRoom.Status().String())
-	if err := uc.roomRepo.Save(ctx, latestRoom); err != nil {
-		fmt.Printf("[StartDiscussion] Save failed: %v\n", err)
+		return uc.roomRepo.Save(ctx, latestRoom)
+	})
+	if err != nil {
 		return err
 	}
-	fmt.Printf("[StartDiscussion] Room saved successfully with game data\n")
+
+	auditlog.Record(ctx, uc.auditRepo, roomID.String(), audit.NewUserActor(input.UserID), audit.ActionSetTopic, beforeStatus.String(), latestRoom.Status().String(), uc.clock.Now())
 
 	return nil
 }
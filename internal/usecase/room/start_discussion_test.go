@@ -0,0 +1,179 @@
+package room_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
+	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
+)
+
+func TestStartDiscussionUseCaseExecute(t *testing.T) {
+	const hostUserID = "550e8400-e29b-41d4-a716-446655440001"
+
+	type fixture struct {
+		useCase         *roomUseCase.StartDiscussionUseCase
+		setTopicUseCase *roomUseCase.SetTopicUseCase
+		roomRepo        *racingRoomRepository
+		testRoom        *room.Room
+	}
+
+	newFixture := func(t *testing.T) *fixture {
+		t.Helper()
+
+		roomID := room.NewRoomID()
+		themeID, _ := room.NewThemeIDFromString("550e8400-e29b-41d4-a716-446655440000")
+		host, _ := room.NewHostUserIDFromString(hostUserID)
+		testRoom := room.NewRoom(roomID, room.NewRoomCode(), themeID, host, testNow)
+		testRoom.Start(testNow)
+
+		store := persistence.NewMemoryStore()
+		participantRepo := persistence.NewInMemoryParticipantRepository(store)
+		auditRepo := persistence.NewInMemoryAuditRepository(store)
+		participantRoomID, _ := participant.NewRoomIDFromString(roomID.String())
+		for userID, role := range map[string]participant.ParticipantRole{
+			hostUserID:                             participant.RoleHost,
+			"550e8400-e29b-41d4-a716-446655440003": participant.RolePlayer,
+			"550e8400-e29b-41d4-a716-446655440004": participant.RolePlayer,
+		} {
+			participantUserID, _ := participant.NewUserIDFromString(userID)
+			mustSave(t, participantRepo.Save, participant.NewParticipant(participant.NewParticipantID(), participantRoomID, participantUserID, role, testNow))
+		}
+
+		roomRepo := newRacingRoomRepository(testRoom)
+		clk := infrastructureClock.NewFakeClock(testNow)
+		return &fixture{
+			useCase:         roomUseCase.NewStartDiscussionUseCase(roomRepo, participantRepo, auditRepo, clk),
+			setTopicUseCase: roomUseCase.NewSetTopicUseCase(roomRepo, participantRepo, &mockModerator{}, auditRepo, clk),
+			roomRepo:        roomRepo,
+			testRoom:        testRoom,
+		}
+	}
+
+	startInput := func(roomID room.RoomID) roomUseCase.StartDiscussionInput {
+		return roomUseCase.StartDiscussionInput{
+			RoomID:          roomID.String(),
+			UserID:          hostUserID,
+			OriginalEmojis:  []string{"🍜", "🥢"},
+			DisplayedEmojis: []string{"🍜", "🍣"},
+			DummyIndex:      1,
+			DummyEmoji:      "🍣",
+		}
+	}
+
+	setTopicInput := func(roomID room.RoomID) roomUseCase.SetTopicInput {
+		return roomUseCase.SetTopicInput{
+			RoomID: roomID.String(),
+			UserID: hostUserID,
+			Topic:  "ラーメン",
+		}
+	}
+
+	// assertBothChanges checks that the stored room has both the topic and the game data
+	assertBothChanges := func(t *testing.T, f *fixture) {
+		t.Helper()
+
+		stored, _ := f.roomRepo.FindByID(context.Background(), f.testRoom.ID())
+		if stored.Topic() == nil || stored.Topic().String() != "ラーメン" {
+			t.Errorf("Expected the topic to be kept, got: %v", stored.Topic())
+		}
+		if stored.DisplayedEmojis() == nil || stored.DisplayedEmojis().Count() != 2 || stored.Assignments().Count() != 2 {
+			t.Errorf("Expected the game data and assignments to be kept, got: %v %v", stored.DisplayedEmojis(), stored.Assignments())
+		}
+	}
+
+	t.Run("正常にゲームデータと割り当てが保存されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+
+		// act
+		err := f.useCase.Execute(context.Background(), startInput(f.testRoom.ID()))
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		stored, _ := f.roomRepo.FindByID(context.Background(), f.testRoom.ID())
+		if stored.DummyEmoji() == nil || stored.DummyEmoji().String() != "🍣" {
+			t.Errorf("Expected the dummy emoji to be saved, got: %v", stored.DummyEmoji())
+		}
+		if stored.Version() != 2 {
+			t.Errorf("Expected the version to advance to 2, got: %d", stored.Version())
+		}
+	})
+
+	t.Run("読み込み後にトピックが保存された場合は最新のRoomに再適用され両方の変更が残ること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		f.roomRepo.beforeSave = func() {
+			// The HTTP request of the host saves the topic in between
+			f.roomRepo.beforeSave = nil
+			if err := f.setTopicUseCase.Execute(context.Background(), setTopicInput(f.testRoom.ID())); err != nil {
+				t.Fatalf("Expected the topic to be set, got: %v", err)
+			}
+		}
+
+		// act
+		err := f.useCase.Execute(context.Background(), startInput(f.testRoom.ID()))
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if got := f.roomRepo.conflicts.Load(); got != 1 {
+			t.Errorf("Expected 1 conflict, got: %d", got)
+		}
+		assertBothChanges(t, f)
+	})
+
+	t.Run("同時に実行してもお互いの変更を上書きしないこと", func(t *testing.T) {
+		for i := 0; i < 50; i++ {
+			// arrange
+			f := newFixture(t)
+			var wg sync.WaitGroup
+			errs := make([]error, 2)
+
+			// act
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				errs[0] = f.setTopicUseCase.Execute(context.Background(), setTopicInput(f.testRoom.ID()))
+			}()
+			go func() {
+				defer wg.Done()
+				errs[1] = f.useCase.Execute(context.Background(), startInput(f.testRoom.ID()))
+			}()
+			wg.Wait()
+
+			// assert
+			if err := errors.Join(errs...); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			assertBothChanges(t, f)
+		}
+	})
+
+	t.Run("他の書き込みが続く場合は再試行の上限で競合エラーが返されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		f.roomRepo.beforeSave = func() {
+			f.roomRepo.saveAgain(f.testRoom.ID())
+		}
+
+		// act
+		err := f.useCase.Execute(context.Background(), startInput(f.testRoom.ID()))
+
+		// assert
+		if !errors.Is(err, room.ErrVersionConflict) {
+			t.Fatalf("Expected ErrVersionConflict, got: %v", err)
+		}
+		if got := f.roomRepo.conflicts.Load(); got != room.MaxConflictRetries+1 {
+			t.Errorf("Expected %d attempts, got: %d", room.MaxConflictRetries+1, got)
+		}
+	})
+}
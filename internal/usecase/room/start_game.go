@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/usecase/auditlog"
 )
 
 // StartGameInput represents the input for starting a game
@@ -20,6 +23,8 @@ type StartGameUseCase struct {
 	roomRepo        room.Repository
 	participantRepo participant.Repository
 	eventPublisher  event.Publisher
+	auditRepo       audit.Repository
+	clock           clock.Clock
 }
 
 // NewStartGameUseCase creates a new StartGameUseCase
@@ -27,16 +32,28 @@ func NewStartGameUseCase(
 	roomRepo room.Repository,
 	participantRepo participant.Repository,
 	eventPublisher event.Publisher,
+	auditRepo audit.Repository,
+	clk clock.Clock,
 ) *StartGameUseCase {
 	return &StartGameUseCase{
 		roomRepo:        roomRepo,
 		participantRepo: participantRepo,
 		eventPublisher:  eventPublisher,
+		auditRepo:       auditRepo,
+		clock:           clk,
 	}
 }
 
-// Execute starts a game
+// Execute starts a game.
+// When another writer saves the room first, it starts over from a fresh read.
 func (uc *StartGameUseCase) Execute(ctx context.Context, input StartGameInput) error {
+	return room.RetryOnConflict(func() error {
+		return uc.execute(ctx, input)
+	})
+}
+
+// execute applies the change to the room as currently stored
+func (uc *StartGameUseCase) execute(ctx context.Context, input StartGameInput) error {
 	// Find room
 	roomID, err := room.NewRoomIDFromString(input.RoomID)
 	if err != nil {
@@ -62,7 +79,8 @@ func (uc *StartGameUseCase) Execute(ctx context.Context, input StartGameInput) e
 	}
 
 	// Start the game
-	if err := foundRoom.Start(); err != nil {
+	beforeStatus := foundRoom.Status()
+	if err := foundRoom.Start(uc.clock.Now()); err != nil {
 		return err
 	}
 
@@ -71,8 +89,10 @@ func (uc *StartGameUseCase) Execute(ctx context.Context, input StartGameInput) e
 		return err
 	}
 
+	auditlog.Record(ctx, uc.auditRepo, roomID.String(), audit.NewUserActor(input.UserID), audit.ActionStartGame, beforeStatus.String(), foundRoom.Status().String(), uc.clock.Now())
+
 	// Publish GameStartedEvent
-	uc.eventPublisher.Publish(event.NewGameStartedEvent(input.RoomID))
+	uc.eventPublisher.Publish(event.NewGameStartedEvent(input.RoomID, uc.clock.Now()))
 
 	return nil
 }
@@ -5,81 +5,92 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
 	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
 )
 
 func TestStartGameUseCaseExecute(t *testing.T) {
+	const hostUserID = "550e8400-e29b-41d4-a716-446655440001"
+
 	type fixture struct {
-		useCase         *roomUseCase.StartGameUseCase
-		roomRepo        *mockRoomRepository
-		participantRepo *mockParticipantRepository
+		roomRepo        room.Repository
+		participantRepo participant.Repository
 		eventPublisher  *mockEventPublisher
+		auditRepo       audit.Repository
 	}
 
 	newFixture := func(t *testing.T) *fixture {
 		t.Helper()
 
-		roomRepo := &mockRoomRepository{}
-		participantRepo := &mockParticipantRepository{}
-		eventPublisher := &mockEventPublisher{}
-
-		useCase := roomUseCase.NewStartGameUseCase(
-			roomRepo,
-			participantRepo,
-			eventPublisher,
-		)
-
+		store := persistence.NewMemoryStore()
 		return &fixture{
-			useCase:         useCase,
-			roomRepo:        roomRepo,
-			participantRepo: participantRepo,
-			eventPublisher:  eventPublisher,
+			roomRepo:        persistence.NewInMemoryRoomRepository(store),
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+			eventPublisher:  &mockEventPublisher{},
+			auditRepo:       persistence.NewInMemoryAuditRepository(store),
 		}
 	}
 
+	newUseCase := func(f *fixture) *roomUseCase.StartGameUseCase {
+		return roomUseCase.NewStartGameUseCase(
+			f.roomRepo,
+			f.participantRepo,
+			f.eventPublisher,
+			f.auditRepo,
+			infrastructureClock.NewFakeClock(testNow),
+		)
+	}
+
 	createTestRoom := func() *room.Room {
 		roomID := room.NewRoomID()
 		roomCode := room.NewRoomCode()
 		themeID, _ := room.NewThemeIDFromString("550e8400-e29b-41d4-a716-446655440000")
-		hostUserID, _ := room.NewHostUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
-		return room.NewRoom(roomID, roomCode, themeID, hostUserID)
+		host, _ := room.NewHostUserIDFromString(hostUserID)
+		return room.NewRoom(roomID, roomCode, themeID, host, testNow)
 	}
 
-	createHostParticipant := func(roomID, userID string) *participant.Participant {
+	createParticipant := func(roomID, userID string, role participant.ParticipantRole) *participant.Participant {
 		participantID := participant.NewParticipantID()
 		participantRoomID, _ := participant.NewRoomIDFromString(roomID)
 		participantUserID, _ := participant.NewUserIDFromString(userID)
-		p := participant.NewParticipant(participantID, participantRoomID, participantUserID, participant.RoleHost)
-		return p
+		return participant.NewParticipant(participantID, participantRoomID, participantUserID, role, testNow)
+	}
+
+	// saveWaitingRoom stores a waiting room with its host
+	saveWaitingRoom := func(t *testing.T, f *fixture) *room.Room {
+		t.Helper()
+
+		testRoom := createTestRoom()
+		mustSave(t, f.roomRepo.Save, testRoom)
+		mustSave(t, f.participantRepo.Save, createParticipant(testRoom.ID().String(), hostUserID, participant.RoleHost))
+		return testRoom
 	}
 
 	t.Run("正常にゲームが開始されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-		testParticipant := createHostParticipant(testRoom.ID().String(), "550e8400-e29b-41d4-a716-446655440001")
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
+		testRoom := saveWaitingRoom(t, f)
 
 		input := roomUseCase.StartGameInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err != nil {
 			t.Fatalf("Expected no error, got: %v", err)
 		}
+		stored, _ := f.roomRepo.FindByID(context.Background(), testRoom.ID())
+		if stored.Status() != room.StatusSettingTopic {
+			t.Errorf("Expected the room to be setting_topic, got: %s", stored.Status())
+		}
 	})
 
 	t.Run("無効なRoomIDの場合はエラーが返されること", func(t *testing.T) {
@@ -88,11 +99,11 @@ func TestStartGameUseCaseExecute(t *testing.T) {
 
 		input := roomUseCase.StartGameInput{
 			RoomID: "invalid-uuid",
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -104,17 +115,13 @@ func TestStartGameUseCaseExecute(t *testing.T) {
 		// arrange
 		f := newFixture(t)
 
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return nil, errors.New("not found")
-		}
-
 		input := roomUseCase.StartGameInput{
 			RoomID: "550e8400-e29b-41d4-a716-446655440000",
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -129,21 +136,15 @@ func TestStartGameUseCaseExecute(t *testing.T) {
 		// arrange
 		f := newFixture(t)
 		testRoom := createTestRoom()
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return nil, errors.New("not found")
-		}
+		mustSave(t, f.roomRepo.Save, testRoom)
 
 		input := roomUseCase.StartGameInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -157,27 +158,17 @@ func TestStartGameUseCaseExecute(t *testing.T) {
 	t.Run("ホスト以外のユーザーがゲーム開始しようとした場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-
-		participantID := participant.NewParticipantID()
-		participantRoomID, _ := participant.NewRoomIDFromString(testRoom.ID().String())
-		participantUserID, _ := participant.NewUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
-		testParticipant := participant.NewParticipant(participantID, participantRoomID, participantUserID, participant.RolePlayer)
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
+		testRoom := saveWaitingRoom(t, f)
+		const playerUserID = "550e8400-e29b-41d4-a716-446655440002"
+		mustSave(t, f.participantRepo.Save, createParticipant(testRoom.ID().String(), playerUserID, participant.RolePlayer))
 
 		input := roomUseCase.StartGameInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: playerUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -191,30 +182,88 @@ func TestStartGameUseCaseExecute(t *testing.T) {
 	t.Run("Roomの保存に失敗した場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-		testParticipant := createHostParticipant(testRoom.ID().String(), "550e8400-e29b-41d4-a716-446655440001")
+		testRoom := saveWaitingRoom(t, f)
+		roomRepo := f.roomRepo
+		f.roomRepo = &mockRoomRepository{
+			findByIDFunc: roomRepo.FindByID,
+			saveFunc: func(ctx context.Context, r *room.Room) error {
+				return errors.New("save error")
+			},
+		}
+
+		input := roomUseCase.StartGameInput{
+			RoomID: testRoom.ID().String(),
+			UserID: hostUserID,
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
 
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
+		// assert
+		if err == nil {
+			t.Error("Expected error when room save fails")
 		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
+	})
+
+	t.Run("ゲーム開始時に監査ログが記録されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := saveWaitingRoom(t, f)
+
+		input := roomUseCase.StartGameInput{
+			RoomID: testRoom.ID().String(),
+			UserID: hostUserID,
 		}
-		f.roomRepo.saveFunc = func(ctx context.Context, r *room.Room) error {
-			return errors.New("save error")
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		auditRoomID, _ := audit.NewRoomIDFromString(testRoom.ID().String())
+		logs, _ := f.auditRepo.FindByRoomID(context.Background(), auditRoomID)
+		if len(logs) != 1 {
+			t.Fatalf("Expected 1 audit log, got: %d", len(logs))
+		}
+		savedLog := logs[0]
+		if savedLog.Action() != audit.ActionStartGame {
+			t.Errorf("Expected action %s, got: %s", audit.ActionStartGame, savedLog.Action())
+		}
+		if savedLog.Actor().ID() != hostUserID {
+			t.Errorf("Expected actor to be the host, got: %s", savedLog.Actor().ID())
+		}
+		if savedLog.BeforeStatus() != "waiting" || savedLog.AfterStatus() != "setting_topic" {
+			t.Errorf("Expected waiting -> setting_topic, got: %s -> %s", savedLog.BeforeStatus(), savedLog.AfterStatus())
+		}
+	})
+
+	t.Run("監査ログの保存に失敗してもゲームは開始されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := saveWaitingRoom(t, f)
+		f.auditRepo = &mockAuditRepository{
+			saveFunc: func(ctx context.Context, l *audit.AuditLog) error {
+				return errors.New("save error")
+			},
 		}
 
 		input := roomUseCase.StartGameInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: hostUserID,
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
-		if err == nil {
-			t.Error("Expected error when room save fails")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		stored, _ := f.roomRepo.FindByID(context.Background(), testRoom.ID())
+		if stored.Status() != room.StatusSettingTopic {
+			t.Errorf("Expected the game to start, got: %s", stored.Status())
 		}
 	})
 }
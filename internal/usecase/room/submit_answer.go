@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/event"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/usecase/auditlog"
 )
 
 // SubmitAnswerInput represents the input for submitting an answer
@@ -21,6 +25,9 @@ type SubmitAnswerUseCase struct {
 	roomRepo        room.Repository
 	participantRepo participant.Repository
 	eventPublisher  event.Publisher
+	moderator       moderation.Moderator
+	auditRepo       audit.Repository
+	clock           clock.Clock
 }
 
 // NewSubmitAnswerUseCase creates a new SubmitAnswerUseCase
@@ -28,16 +35,30 @@ func NewSubmitAnswerUseCase(
 	roomRepo room.Repository,
 	participantRepo participant.Repository,
 	eventPublisher event.Publisher,
+	moderator moderation.Moderator,
+	auditRepo audit.Repository,
+	clk clock.Clock,
 ) *SubmitAnswerUseCase {
 	return &SubmitAnswerUseCase{
 		roomRepo:        roomRepo,
 		participantRepo: participantRepo,
 		eventPublisher:  eventPublisher,
+		moderator:       moderator,
+		auditRepo:       auditRepo,
+		clock:           clk,
 	}
 }
 
-// Execute submits an answer
+// Execute submits an answer.
+// When another writer saves the room first, it starts over from a fresh read.
 func (uc *SubmitAnswerUseCase) Execute(ctx context.Context, input SubmitAnswerInput) error {
+	return room.RetryOnConflict(func() error {
+		return uc.execute(ctx, input)
+	})
+}
+
+// execute applies the change to the room as currently stored
+func (uc *SubmitAnswerUseCase) execute(ctx context.Context, input SubmitAnswerInput) error {
 	// Find room
 	roomID, err := room.NewRoomIDFromString(input.RoomID)
 	if err != nil {
@@ -63,7 +84,12 @@ func (uc *SubmitAnswerUseCase) Execute(ctx context.Context, input SubmitAnswerIn
 	}
 
 	// Set answer
-	answer, err := room.NewAnswer(input.Answer)
+	beforeStatus := foundRoom.Status()
+	moderatedAnswer, err := uc.moderator.Moderate(moderation.FieldAnswer, input.Answer)
+	if err != nil {
+		return err
+	}
+	answer, err := room.NewAnswer(moderatedAnswer)
 	if err != nil {
 		return err
 	}
@@ -72,7 +98,7 @@ func (uc *SubmitAnswerUseCase) Execute(ctx context.Context, input SubmitAnswerIn
 	}
 
 	// Change status to checking
-	if err := foundRoom.ChangeStatus(room.StatusChecking); err != nil {
+	if err := foundRoom.ChangeStatus(room.StatusChecking, uc.clock.Now()); err != nil {
 		return err
 	}
 
@@ -81,8 +107,10 @@ func (uc *SubmitAnswerUseCase) Execute(ctx context.Context, input SubmitAnswerIn
 		return err
 	}
 
+	auditlog.Record(ctx, uc.auditRepo, roomID.String(), audit.NewUserActor(input.UserID), audit.ActionSubmitAnswer, beforeStatus.String(), foundRoom.Status().String(), uc.clock.Now())
+
 	// Publish AnswerSubmittedEvent
-	uc.eventPublisher.Publish(event.NewAnswerSubmittedEvent(input.RoomID))
+	uc.eventPublisher.Publish(event.NewAnswerSubmittedEvent(input.RoomID, uc.clock.Now()))
 
 	return nil
 }
@@ -5,87 +5,99 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
 	roomUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/room"
 )
 
 func TestSubmitAnswerUseCaseExecute(t *testing.T) {
+	const leaderUserID = "550e8400-e29b-41d4-a716-446655440002"
+
 	type fixture struct {
-		useCase         *roomUseCase.SubmitAnswerUseCase
-		roomRepo        *mockRoomRepository
-		participantRepo *mockParticipantRepository
+		roomRepo        room.Repository
+		participantRepo participant.Repository
 		eventPublisher  *mockEventPublisher
+		moderator       *mockModerator
+		auditRepo       audit.Repository
 	}
 
 	newFixture := func(t *testing.T) *fixture {
 		t.Helper()
 
-		roomRepo := &mockRoomRepository{}
-		participantRepo := &mockParticipantRepository{}
-		eventPublisher := &mockEventPublisher{}
+		store := persistence.NewMemoryStore()
+		return &fixture{
+			roomRepo:        persistence.NewInMemoryRoomRepository(store),
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+			eventPublisher:  &mockEventPublisher{},
+			moderator:       &mockModerator{},
+			auditRepo:       persistence.NewInMemoryAuditRepository(store),
+		}
+	}
 
-		useCase := roomUseCase.NewSubmitAnswerUseCase(
-			roomRepo,
-			participantRepo,
-			eventPublisher,
+	newUseCase := func(f *fixture) *roomUseCase.SubmitAnswerUseCase {
+		return roomUseCase.NewSubmitAnswerUseCase(
+			f.roomRepo,
+			f.participantRepo,
+			f.eventPublisher,
+			f.moderator,
+			f.auditRepo,
+			infrastructureClock.NewFakeClock(testNow),
 		)
+	}
 
-		return &fixture{
-			useCase:         useCase,
-			roomRepo:        roomRepo,
-			participantRepo: participantRepo,
-			eventPublisher:  eventPublisher,
-		}
+	createPlayer := func(roomID, userID string) *participant.Participant {
+		participantID := participant.NewParticipantID()
+		participantRoomID, _ := participant.NewRoomIDFromString(roomID)
+		participantUserID, _ := participant.NewUserIDFromString(userID)
+		return participant.NewParticipant(participantID, participantRoomID, participantUserID, participant.RolePlayer, testNow)
 	}
 
-	createTestRoom := func() *room.Room {
+	// saveAnsweringRoom stores a room in the answering phase with its leader
+	saveAnsweringRoom := func(t *testing.T, f *fixture) *room.Room {
+		t.Helper()
+
 		roomID := room.NewRoomID()
 		roomCode := room.NewRoomCode()
 		themeID, _ := room.NewThemeIDFromString("550e8400-e29b-41d4-a716-446655440000")
 		hostUserID, _ := room.NewHostUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
-		r := room.NewRoom(roomID, roomCode, themeID, hostUserID)
-		r.Start()
-		r.ChangeStatus(room.StatusDiscussing)
-		r.ChangeStatus(room.StatusAnswering)
+		r := room.NewRoom(roomID, roomCode, themeID, hostUserID, testNow)
+		r.Start(testNow)
+		r.ChangeStatus(room.StatusDiscussing, testNow)
+		r.ChangeStatus(room.StatusAnswering, testNow)
+		mustSave(t, f.roomRepo.Save, r)
+
+		leader := createPlayer(r.ID().String(), leaderUserID)
+		leader.SetAsLeader()
+		mustSave(t, f.participantRepo.Save, leader)
 		return r
 	}
 
-	createLeaderParticipant := func(roomID, userID string) *participant.Participant {
-		participantID := participant.NewParticipantID()
-		participantRoomID, _ := participant.NewRoomIDFromString(roomID)
-		participantUserID, _ := participant.NewUserIDFromString(userID)
-		p := participant.NewParticipant(participantID, participantRoomID, participantUserID, participant.RolePlayer)
-		p.SetAsLeader()
-		return p
-	}
-
 	t.Run("正常に解答が提出されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-		testParticipant := createLeaderParticipant(testRoom.ID().String(), "550e8400-e29b-41d4-a716-446655440001")
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
+		testRoom := saveAnsweringRoom(t, f)
 
 		input := roomUseCase.SubmitAnswerInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: leaderUserID,
 			Answer: "Test Answer",
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err != nil {
 			t.Fatalf("Expected no error, got: %v", err)
 		}
+		stored, _ := f.roomRepo.FindByID(context.Background(), testRoom.ID())
+		if stored.Answer() == nil || stored.Answer().String() != "Test Answer" {
+			t.Errorf("Expected the answer to be saved, got: %v", stored.Answer())
+		}
 	})
 
 	t.Run("無効なRoomIDの場合はエラーが返されること", func(t *testing.T) {
@@ -94,12 +106,12 @@ func TestSubmitAnswerUseCaseExecute(t *testing.T) {
 
 		input := roomUseCase.SubmitAnswerInput{
 			RoomID: "invalid-uuid",
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: leaderUserID,
 			Answer: "Test Answer",
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -111,18 +123,14 @@ func TestSubmitAnswerUseCaseExecute(t *testing.T) {
 		// arrange
 		f := newFixture(t)
 
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return nil, errors.New("not found")
-		}
-
 		input := roomUseCase.SubmitAnswerInput{
 			RoomID: "550e8400-e29b-41d4-a716-446655440000",
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: leaderUserID,
 			Answer: "Test Answer",
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -133,28 +141,18 @@ func TestSubmitAnswerUseCaseExecute(t *testing.T) {
 	t.Run("リーダー以外のユーザーが解答提出しようとした場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-
-		participantID := participant.NewParticipantID()
-		participantRoomID, _ := participant.NewRoomIDFromString(testRoom.ID().String())
-		participantUserID, _ := participant.NewUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
-		testParticipant := participant.NewParticipant(participantID, participantRoomID, participantUserID, participant.RolePlayer)
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
+		testRoom := saveAnsweringRoom(t, f)
+		const playerUserID = "550e8400-e29b-41d4-a716-446655440003"
+		mustSave(t, f.participantRepo.Save, createPlayer(testRoom.ID().String(), playerUserID))
 
 		input := roomUseCase.SubmitAnswerInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: playerUserID,
 			Answer: "Test Answer",
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -168,31 +166,57 @@ func TestSubmitAnswerUseCaseExecute(t *testing.T) {
 	t.Run("Roomの保存に失敗した場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-		testParticipant := createLeaderParticipant(testRoom.ID().String(), "550e8400-e29b-41d4-a716-446655440001")
-
-		f.roomRepo.findByIDFunc = func(ctx context.Context, id room.RoomID) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomAndUserFunc = func(ctx context.Context, roomID participant.RoomID, userID participant.UserID) (*participant.Participant, error) {
-			return testParticipant, nil
-		}
-		f.roomRepo.saveFunc = func(ctx context.Context, r *room.Room) error {
-			return errors.New("save error")
+		testRoom := saveAnsweringRoom(t, f)
+		roomRepo := f.roomRepo
+		f.roomRepo = &mockRoomRepository{
+			findByIDFunc: roomRepo.FindByID,
+			saveFunc: func(ctx context.Context, r *room.Room) error {
+				return errors.New("save error")
+			},
 		}
 
 		input := roomUseCase.SubmitAnswerInput{
 			RoomID: testRoom.ID().String(),
-			UserID: "550e8400-e29b-41d4-a716-446655440001",
+			UserID: leaderUserID,
 			Answer: "Test Answer",
 		}
 
 		// act
-		err := f.useCase.Execute(context.Background(), input)
+		err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
 			t.Error("Expected error when room save fails")
 		}
 	})
+
+	t.Run("マスクモードの場合はマスクされた解答が保存されること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := saveAnsweringRoom(t, f)
+		f.moderator.moderateFunc = func(field moderation.Field, value string) (string, error) {
+			if field != moderation.FieldAnswer {
+				t.Errorf("Expected FieldAnswer, got: %v", field)
+			}
+			return "***", nil
+		}
+
+		input := roomUseCase.SubmitAnswerInput{
+			RoomID: testRoom.ID().String(),
+			UserID: leaderUserID,
+			Answer: "NG Answer",
+		}
+
+		// act
+		err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		stored, _ := f.roomRepo.FindByID(context.Background(), testRoom.ID())
+		if savedAnswer := stored.Answer().String(); savedAnswer != "***" {
+			t.Errorf("Expected masked answer '***', got: '%s'", savedAnswer)
+		}
+	})
 }
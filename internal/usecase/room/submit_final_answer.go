@@ -4,24 +4,35 @@ import (
 	"context"
 	"errors"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/audit"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/usecase/auditlog"
 )
 
 // SubmitFinalAnswerUseCase submits the final answer with game data and transitions to checking
 type SubmitFinalAnswerUseCase struct {
-	roomRepo room.Repository
+	roomRepo  room.Repository
+	moderator moderation.Moderator
+	auditRepo audit.Repository
+	clock     clock.Clock
 }
 
 // NewSubmitFinalAnswerUseCase creates a new SubmitFinalAnswerUseCase
-func NewSubmitFinalAnswerUseCase(roomRepo room.Repository) *SubmitFinalAnswerUseCase {
+func NewSubmitFinalAnswerUseCase(roomRepo room.Repository, moderator moderation.Moderator, auditRepo audit.Repository, clk clock.Clock) *SubmitFinalAnswerUseCase {
 	return &SubmitFinalAnswerUseCase{
-		roomRepo: roomRepo,
+		roomRepo:  roomRepo,
+		moderator: moderator,
+		auditRepo: auditRepo,
+		clock:     clk,
 	}
 }
 
 // SubmitFinalAnswerInput represents input for submitting final answer
 type SubmitFinalAnswerInput struct {
 	RoomID          string
+	UserID          string
 	Answer          string
 	OriginalEmojis  []string
 	DisplayedEmojis []string
@@ -29,8 +40,16 @@ type SubmitFinalAnswerInput struct {
 	DummyEmoji      string
 }
 
-// Execute submits the final answer and transitions to checking phase
+// Execute submits the final answer and transitions to checking phase.
+// When another writer saves the room first, it starts over from a fresh read.
 func (uc *SubmitFinalAnswerUseCase) Execute(ctx context.Context, input SubmitFinalAnswerInput) error {
+	return room.RetryOnConflict(func() error {
+		return uc.execute(ctx, input)
+	})
+}
+
+// execute applies the change to the room as currently stored
+func (uc *SubmitFinalAnswerUseCase) execute(ctx context.Context, input SubmitFinalAnswerInput) error {
 	// Validate input
 	if input.RoomID == "" {
 		return errors.New("room ID is required")
@@ -49,7 +68,11 @@ func (uc *SubmitFinalAnswerUseCase) Execute(ctx context.Context, input SubmitFin
 	}
 
 	// Validate and set answer
-	answer, err := room.NewAnswer(input.Answer)
+	moderatedAnswer, err := uc.moderator.Moderate(moderation.FieldAnswer, input.Answer)
+	if err != nil {
+		return err
+	}
+	answer, err := room.NewAnswer(moderatedAnswer)
 	if err != nil {
 		return err
 	}
@@ -77,12 +100,15 @@ func (uc *SubmitFinalAnswerUseCase) Execute(ctx context.Context, input SubmitFin
 	)
 
 	// Change status to checking
-	foundRoom.ChangeStatus(room.StatusChecking)
+	beforeStatus := foundRoom.Status()
+	foundRoom.ChangeStatus(room.StatusChecking, uc.clock.Now())
 
 	// Save room
 	if err := uc.roomRepo.Save(ctx, foundRoom); err != nil {
 		return err
 	}
 
+	auditlog.Record(ctx, uc.auditRepo, roomID.String(), audit.NewUserActor(input.UserID), audit.ActionSubmitAnswer, beforeStatus.String(), foundRoom.Status().String(), uc.clock.Now())
+
 	return nil
 }
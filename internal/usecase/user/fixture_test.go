@@ -3,7 +3,9 @@ package user_test
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
@@ -42,6 +44,7 @@ type mockRoomRepository struct {
 	saveFunc       func(context.Context, *room.Room) error
 	findByIDFunc   func(context.Context, room.RoomID) (*room.Room, error)
 	findByCodeFunc func(context.Context, room.RoomCode) (*room.Room, error)
+	searchFunc     func(context.Context, room.SearchCriteria) ([]room.SearchResult, error)
 	deleteFunc     func(context.Context, room.RoomID) error
 }
 
@@ -66,6 +69,13 @@ func (m *mockRoomRepository) FindByCode(ctx context.Context, code room.RoomCode)
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockRoomRepository) Search(ctx context.Context, criteria room.SearchCriteria) ([]room.SearchResult, error) {
+	if m.searchFunc != nil {
+		return m.searchFunc(ctx, criteria)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockRoomRepository) Delete(ctx context.Context, id room.RoomID) error {
 	if m.deleteFunc != nil {
 		return m.deleteFunc(ctx, id)
@@ -116,3 +126,18 @@ func (m *mockParticipantRepository) Delete(ctx context.Context, roomID participa
 	}
 	return errors.New("not implemented")
 }
+
+// Mock Moderator
+type mockModerator struct {
+	moderateFunc func(moderation.Field, string) (string, error)
+}
+
+func (m *mockModerator) Moderate(field moderation.Field, value string) (string, error) {
+	if m.moderateFunc != nil {
+		return m.moderateFunc(field, value)
+	}
+	return value, nil
+}
+
+// testNow is the fixed time used by the use cases and entities under test
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
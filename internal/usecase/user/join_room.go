@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/transaction"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/user"
 )
 
@@ -27,6 +30,9 @@ type JoinRoomUseCase struct {
 	userRepo        user.Repository
 	roomRepo        room.Repository
 	participantRepo participant.Repository
+	moderator       moderation.Moderator
+	txManager       transaction.Manager
+	clock           clock.Clock
 }
 
 // NewJoinRoomUseCase creates a new JoinRoomUseCase
@@ -34,11 +40,17 @@ func NewJoinRoomUseCase(
 	userRepo user.Repository,
 	roomRepo room.Repository,
 	participantRepo participant.Repository,
+	moderator moderation.Moderator,
+	txManager transaction.Manager,
+	clk clock.Clock,
 ) *JoinRoomUseCase {
 	return &JoinRoomUseCase{
 		userRepo:        userRepo,
 		roomRepo:        roomRepo,
 		participantRepo: participantRepo,
+		moderator:       moderator,
+		txManager:       txManager,
+		clock:           clk,
 	}
 }
 
@@ -63,50 +75,63 @@ func (uc *JoinRoomUseCase) Execute(ctx context.Context, input JoinRoomInput) (*J
 		return nil, errors.New("room not found")
 	}
 
-	// Create new user
-	userID := user.NewUserID()
-	userName, err := user.NewUserName(input.UserName)
+	// Moderate user name
+	moderatedName, err := uc.moderator.Moderate(moderation.FieldUserName, input.UserName)
 	if err != nil {
 		return nil, err
 	}
 
-	newUser := user.NewUser(userID, userName)
-	if err := uc.userRepo.Save(ctx, newUser); err != nil {
+	// Create new user
+	userID := user.NewUserID()
+	userName, err := user.NewUserName(moderatedName)
+	if err != nil {
 		return nil, err
 	}
 
-	// Check if this is the first non-host participant (first joiner becomes Leader)
+	newUser := user.NewUser(userID, userName, uc.clock.Now())
+
+	// Save the user and its participant together, so that a failure leaves no orphaned user
 	participantRoomID, _ := participant.NewRoomIDFromString(foundRoom.ID().String())
-	existingParticipants, err := uc.participantRepo.FindByRoomID(ctx, participantRoomID)
-	if err != nil {
-		existingParticipants = []*participant.Participant{}
-	}
-	// Count only non-host participants to determine if this is the first joiner
-	nonHostCount := 0
-	for _, p := range existingParticipants {
-		if p.Role() != participant.RoleHost {
-			nonHostCount++
+	var isLeader bool
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.Save(ctx, newUser); err != nil {
+			return err
 		}
-	}
-	isLeader := nonHostCount == 0
-
-	// Create participant
-	participantID := participant.NewParticipantID()
-	participantUserID, _ := participant.NewUserIDFromString(userID.String())
-	role := participant.RolePlayer
-
-	newParticipant := participant.NewParticipant(
-		participantID,
-		participantRoomID,
-		participantUserID,
-		role,
-	)
-
-	if isLeader {
-		newParticipant.SetAsLeader()
-	}
 
-	if err := uc.participantRepo.Save(ctx, newParticipant); err != nil {
+		// Check if this is the first non-host participant (first joiner becomes Leader)
+		existingParticipants, err := uc.participantRepo.FindByRoomID(ctx, participantRoomID)
+		if err != nil {
+			return err
+		}
+		// Count only non-host participants to determine if this is the first joiner
+		nonHostCount := 0
+		for _, p := range existingParticipants {
+			if p.Role() != participant.RoleHost {
+				nonHostCount++
+			}
+		}
+		isLeader = nonHostCount == 0
+
+		// Create participant
+		participantID := participant.NewParticipantID()
+		participantUserID, _ := participant.NewUserIDFromString(userID.String())
+		role := participant.RolePlayer
+
+		newParticipant := participant.NewParticipant(
+			participantID,
+			participantRoomID,
+			participantUserID,
+			role,
+			uc.clock.Now(),
+		)
+
+		if isLeader {
+			newParticipant.SetAsLeader()
+		}
+
+		return uc.participantRepo.Save(ctx, newParticipant)
+	})
+	if err != nil {
 		return nil, err
 	}
 
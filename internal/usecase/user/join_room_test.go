@@ -5,60 +5,80 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/shooooooma415/guess-title-game-api/internal/domain/moderation"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/participant"
 	"github.com/shooooooma415/guess-title-game-api/internal/domain/room"
 	domainUser "github.com/shooooooma415/guess-title-game-api/internal/domain/user"
+	infrastructureClock "github.com/shooooooma415/guess-title-game-api/internal/infrastructure/clock"
+	"github.com/shooooooma415/guess-title-game-api/internal/infrastructure/persistence"
 	userUseCase "github.com/shooooooma415/guess-title-game-api/internal/usecase/user"
 )
 
 func TestJoinRoomUseCaseExecute(t *testing.T) {
 	type fixture struct {
-		useCase         *userUseCase.JoinRoomUseCase
-		userRepo        *mockUserRepository
-		roomRepo        *mockRoomRepository
-		participantRepo *mockParticipantRepository
+		userRepo        domainUser.Repository
+		roomRepo        room.Repository
+		participantRepo participant.Repository
+		moderator       *mockModerator
+		txManager       *persistence.InMemoryTxManager
 	}
 
 	newFixture := func(t *testing.T) *fixture {
 		t.Helper()
 
-		userRepo := &mockUserRepository{}
-		roomRepo := &mockRoomRepository{}
-		participantRepo := &mockParticipantRepository{}
-
-		useCase := userUseCase.NewJoinRoomUseCase(
-			userRepo,
-			roomRepo,
-			participantRepo,
-		)
-
+		store := persistence.NewMemoryStore()
 		return &fixture{
-			useCase:         useCase,
-			userRepo:        userRepo,
-			roomRepo:        roomRepo,
-			participantRepo: participantRepo,
+			userRepo:        persistence.NewInMemoryUserRepository(store),
+			roomRepo:        persistence.NewInMemoryRoomRepository(store),
+			participantRepo: persistence.NewInMemoryParticipantRepository(store),
+			moderator:       &mockModerator{},
+			txManager:       persistence.NewInMemoryTxManager(store),
 		}
 	}
 
-	createTestRoom := func() *room.Room {
+	newUseCase := func(f *fixture) *userUseCase.JoinRoomUseCase {
+		return userUseCase.NewJoinRoomUseCase(
+			f.userRepo,
+			f.roomRepo,
+			f.participantRepo,
+			f.moderator,
+			f.txManager,
+			infrastructureClock.NewFakeClock(testNow),
+		)
+	}
+
+	// saveTestRoom stores a new waiting room
+	saveTestRoom := func(t *testing.T, f *fixture) *room.Room {
+		t.Helper()
+
 		roomID := room.NewRoomID()
 		roomCode := room.NewRoomCode()
 		themeID, _ := room.NewThemeIDFromString("550e8400-e29b-41d4-a716-446655440000")
 		hostUserID, _ := room.NewHostUserIDFromString("550e8400-e29b-41d4-a716-446655440001")
-		return room.NewRoom(roomID, roomCode, themeID, hostUserID)
+		testRoom := room.NewRoom(roomID, roomCode, themeID, hostUserID, testNow)
+		if err := f.roomRepo.Save(context.Background(), testRoom); err != nil {
+			t.Fatalf("Failed to save room: %v", err)
+		}
+		return testRoom
+	}
+
+	// recordSavedUsers keeps the users the use case saves while still storing them
+	recordSavedUsers := func(f *fixture) *[]*domainUser.User {
+		var saved []*domainUser.User
+		repo := f.userRepo
+		f.userRepo = &mockUserRepository{
+			saveFunc: func(ctx context.Context, u *domainUser.User) error {
+				saved = append(saved, u)
+				return repo.Save(ctx, u)
+			},
+		}
+		return &saved
 	}
 
 	t.Run("正常にルームに参加できること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-
-		f.roomRepo.findByCodeFunc = func(ctx context.Context, code room.RoomCode) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomIDFunc = func(ctx context.Context, roomID participant.RoomID) ([]*participant.Participant, error) {
-			return []*participant.Participant{}, nil
-		}
+		testRoom := saveTestRoom(t, f)
 
 		input := userUseCase.JoinRoomInput{
 			RoomCode: testRoom.Code().String(),
@@ -66,7 +86,7 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background(), input)
+		output, err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err != nil {
@@ -77,8 +97,8 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 			t.Fatal("Expected output, got nil")
 		}
 
-		if output.RoomID == "" {
-			t.Error("Expected RoomID to be set")
+		if output.RoomID != testRoom.ID().String() {
+			t.Errorf("Expected RoomID %s, got: %s", testRoom.ID().String(), output.RoomID)
 		}
 
 		if output.UserID == "" {
@@ -88,6 +108,17 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 		if !output.IsLeader {
 			t.Error("Expected first participant to be leader")
 		}
+
+		userID, _ := domainUser.NewUserIDFromString(output.UserID)
+		if _, err := f.userRepo.FindByID(context.Background(), userID); err != nil {
+			t.Errorf("Expected the user to be saved, got: %v", err)
+		}
+		participantRoomID, _ := participant.NewRoomIDFromString(output.RoomID)
+		participantUserID, _ := participant.NewUserIDFromString(output.UserID)
+		joined, err := f.participantRepo.FindByRoomAndUser(context.Background(), participantRoomID, participantUserID)
+		if err != nil || !joined.IsLeader() {
+			t.Errorf("Expected the participant to be saved as the leader, got: %v", err)
+		}
 	})
 
 	t.Run("RoomCodeが空の場合はエラーが返されること", func(t *testing.T) {
@@ -100,7 +131,7 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background(), input)
+		output, err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -124,7 +155,7 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background(), input)
+		output, err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -148,7 +179,7 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background(), input)
+		output, err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -163,17 +194,13 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 		// arrange
 		f := newFixture(t)
 
-		f.roomRepo.findByCodeFunc = func(ctx context.Context, code room.RoomCode) (*room.Room, error) {
-			return nil, errors.New("not found")
-		}
-
 		input := userUseCase.JoinRoomInput{
 			RoomCode: "123456",
 			UserName: "Test User",
 		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background(), input)
+		output, err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -190,13 +217,11 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 	t.Run("Userの保存に失敗した場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-
-		f.roomRepo.findByCodeFunc = func(ctx context.Context, code room.RoomCode) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.userRepo.saveFunc = func(ctx context.Context, u *domainUser.User) error {
-			return errors.New("save error")
+		testRoom := saveTestRoom(t, f)
+		f.userRepo = &mockUserRepository{
+			saveFunc: func(ctx context.Context, u *domainUser.User) error {
+				return errors.New("save error")
+			},
 		}
 
 		input := userUseCase.JoinRoomInput{
@@ -205,7 +230,7 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background(), input)
+		output, err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -219,16 +244,14 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 	t.Run("Participantの保存に失敗した場合はエラーが返されること", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
-
-		f.roomRepo.findByCodeFunc = func(ctx context.Context, code room.RoomCode) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomIDFunc = func(ctx context.Context, roomID participant.RoomID) ([]*participant.Participant, error) {
-			return []*participant.Participant{}, nil
-		}
-		f.participantRepo.saveFunc = func(ctx context.Context, p *participant.Participant) error {
-			return errors.New("save error")
+		testRoom := saveTestRoom(t, f)
+		f.participantRepo = &mockParticipantRepository{
+			findByRoomIDFunc: func(ctx context.Context, roomID participant.RoomID) ([]*participant.Participant, error) {
+				return []*participant.Participant{}, nil
+			},
+			saveFunc: func(ctx context.Context, p *participant.Participant) error {
+				return errors.New("save error")
+			},
 		}
 
 		input := userUseCase.JoinRoomInput{
@@ -237,7 +260,7 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background(), input)
+		output, err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err == nil {
@@ -251,20 +274,16 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 	t.Run("2人目以降の参加者はリーダーにならないこと", func(t *testing.T) {
 		// arrange
 		f := newFixture(t)
-		testRoom := createTestRoom()
+		testRoom := saveTestRoom(t, f)
 
 		// Existing participant
 		existingParticipantID := participant.NewParticipantID()
 		existingRoomID, _ := participant.NewRoomIDFromString(testRoom.ID().String())
 		existingUserID, _ := participant.NewUserIDFromString("550e8400-e29b-41d4-a716-446655440002")
-		existingParticipant := participant.NewParticipant(existingParticipantID, existingRoomID, existingUserID, participant.RolePlayer)
+		existingParticipant := participant.NewParticipant(existingParticipantID, existingRoomID, existingUserID, participant.RolePlayer, testNow)
 		existingParticipant.SetAsLeader()
-
-		f.roomRepo.findByCodeFunc = func(ctx context.Context, code room.RoomCode) (*room.Room, error) {
-			return testRoom, nil
-		}
-		f.participantRepo.findByRoomIDFunc = func(ctx context.Context, roomID participant.RoomID) ([]*participant.Participant, error) {
-			return []*participant.Participant{existingParticipant}, nil
+		if err := f.participantRepo.Save(context.Background(), existingParticipant); err != nil {
+			t.Fatalf("Failed to save participant: %v", err)
 		}
 
 		input := userUseCase.JoinRoomInput{
@@ -273,7 +292,7 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 		}
 
 		// act
-		output, err := f.useCase.Execute(context.Background(), input)
+		output, err := newUseCase(f).Execute(context.Background(), input)
 
 		// assert
 		if err != nil {
@@ -288,4 +307,101 @@ func TestJoinRoomUseCaseExecute(t *testing.T) {
 			t.Error("Expected second participant not to be leader")
 		}
 	})
+
+	t.Run("不適切なユーザー名の場合はエラーが返されユーザーが保存されないこと", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := saveTestRoom(t, f)
+		f.moderator.moderateFunc = func(field moderation.Field, value string) (string, error) {
+			return "", moderation.ErrInappropriateContent
+		}
+		saved := recordSavedUsers(f)
+
+		input := userUseCase.JoinRoomInput{
+			RoomCode: testRoom.Code().String(),
+			UserName: "NG Name",
+		}
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), input)
+
+		// assert
+		if !errors.Is(err, moderation.ErrInappropriateContent) {
+			t.Errorf("Expected ErrInappropriateContent, got: %v", err)
+		}
+		if len(*saved) != 0 {
+			t.Error("Expected user not to be saved")
+		}
+	})
+
+	t.Run("参加者の取得に失敗した場合はエラーが返されロールバックされること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := saveTestRoom(t, f)
+		findErr := errors.New("find error")
+		var participantSaved bool
+		f.participantRepo = &mockParticipantRepository{
+			findByRoomIDFunc: func(ctx context.Context, roomID participant.RoomID) ([]*participant.Participant, error) {
+				return nil, findErr
+			},
+			saveFunc: func(ctx context.Context, p *participant.Participant) error {
+				participantSaved = true
+				return nil
+			},
+		}
+		userRepo := f.userRepo
+		saved := recordSavedUsers(f)
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), userUseCase.JoinRoomInput{
+			RoomCode: testRoom.Code().String(),
+			UserName: "Test User",
+		})
+
+		// assert
+		if !errors.Is(err, findErr) {
+			t.Fatalf("Expected the find error, got: %v", err)
+		}
+		if participantSaved {
+			t.Error("Expected the participant not to be saved")
+		}
+		if len(*saved) != 1 {
+			t.Fatalf("Expected the user to be saved before the failure, got: %d", len(*saved))
+		}
+		if _, err := userRepo.FindByID(context.Background(), (*saved)[0].ID()); err == nil {
+			t.Error("Expected the user to be rolled back")
+		}
+	})
+
+	t.Run("Participantの保存に失敗した場合はユーザーの保存もロールバックされること", func(t *testing.T) {
+		// arrange
+		f := newFixture(t)
+		testRoom := saveTestRoom(t, f)
+		participantRepo := f.participantRepo
+		f.participantRepo = &mockParticipantRepository{
+			findByRoomIDFunc: participantRepo.FindByRoomID,
+			saveFunc: func(ctx context.Context, p *participant.Participant) error {
+				return errors.New("save error")
+			},
+		}
+		userRepo := f.userRepo
+		saved := recordSavedUsers(f)
+
+		// act
+		_, err := newUseCase(f).Execute(context.Background(), userUseCase.JoinRoomInput{
+			RoomCode: testRoom.Code().String(),
+			UserName: "Test User",
+		})
+
+		// assert
+		if err == nil {
+			t.Fatal("Expected error when participant save fails")
+		}
+		if len(*saved) != 1 {
+			t.Fatalf("Expected the user to be saved before the failure, got: %d", len(*saved))
+		}
+		if _, err := userRepo.FindByID(context.Background(), (*saved)[0].ID()); err == nil {
+			t.Error("Expected the user to be rolled back")
+		}
+	})
 }